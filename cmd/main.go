@@ -11,9 +11,17 @@ import (
 	"os/signal"
 	"remy_explorer/internal/config"
 	handler "remy_explorer/internal/explorer/handler/http"
+	"remy_explorer/internal/explorer/model"
 	repo "remy_explorer/internal/explorer/repository/postgresql"
+	"remy_explorer/internal/explorer/service/audit"
+	"remy_explorer/internal/explorer/service/bulk"
+	"remy_explorer/internal/explorer/service/change"
 	"remy_explorer/internal/explorer/service/file"
 	"remy_explorer/internal/explorer/service/folder"
+	"remy_explorer/internal/explorer/service/recent"
+	"remy_explorer/internal/explorer/service/search"
+	"remy_explorer/internal/explorer/service/star"
+	"remy_explorer/internal/explorer/service/trash"
 	"syscall"
 )
 
@@ -54,16 +62,77 @@ func main() {
 	}
 	defer pool.Close()
 
+	// Run a subcommand instead of the server if one is given
+	if args := flag.Args(); len(args) > 0 {
+		switch args[0] {
+		case "migrate":
+			if err := runMigrate(ctx, logger, pool, args[1:]); err != nil {
+				level.Error(logger).Log("message", "Migration failed", "err", err)
+				os.Exit(1)
+			}
+		default:
+			level.Error(logger).Log("message", "Unknown command", "command", args[0])
+			os.Exit(2)
+		}
+		return
+	}
+
+	if cfg.Storage.AutoMigrate {
+		if err := runMigrate(ctx, logger, pool, []string{"up"}); err != nil {
+			level.Error(logger).Log("message", "Failed to apply migrations", "err", err)
+			return
+		}
+	}
+
+	// Unit of work shared by the services, so their repository calls can join one transaction
+	tx := repo.NewTransactor(pool)
+
+	fileRepo := repo.NewFileRepo(pool, logger)
+	folderRepo := repo.NewFolderRepo(pool, logger)
+	quotaRepo := repo.NewQuotaRepo(pool, logger)
+	auditRepo := repo.NewAuditRepo(pool, logger)
+	quota := model.Quota{MaxBytes: cfg.Quota.DefaultMaxBytes, MaxFiles: cfg.Quota.DefaultMaxFiles}
+
 	// Create file service
 	var fileSvc file.FileService
 	{
-		rep := repo.NewFileRepo(pool, logger)
-		fileSvc = file.NewService(rep, logger)
+		fileSvc = file.NewService(fileRepo, folderRepo, quotaRepo, auditRepo, tx, cfg.Versions.MaxPerFile, quota, logger)
 	}
 	var folderSvc folder.FolderService
 	{
-		rep := repo.NewFolderRepo(pool, logger)
-		folderSvc = folder.NewService(rep, logger)
+		folderSvc = folder.NewService(folderRepo, quotaRepo, auditRepo, tx, quota, logger)
+	}
+	var trashSvc trash.TrashService
+	{
+		rep := repo.NewTrashRepo(pool, logger)
+		trashSvc = trash.NewService(rep, fileRepo, folderRepo, tx, cfg.Trash.Retention, logger)
+	}
+	var searchSvc search.SearchService
+	{
+		searchSvc = search.NewService(repo.NewSearchRepo(pool, logger), logger)
+	}
+	var bulkSvc bulk.BulkService
+	{
+		bulkSvc = bulk.NewService(fileSvc, folderSvc, tx, logger)
+	}
+	var starSvc star.StarService
+	{
+		starSvc = star.NewService(repo.NewStarRepo(pool, logger), logger)
+	}
+	var auditSvc audit.AuditService
+	{
+		auditSvc = audit.NewService(auditRepo, logger)
+	}
+	var changeSvc change.ChangeService
+	{
+		changeSvc = change.NewService(repo.NewChangeRepo(pool, logger), logger)
+	}
+	var recentSvc recent.RecentService
+	{
+		recentSvc = recent.NewService(repo.NewRecentRepo(pool, logger), tx, cfg.Recent.MaxPerOwner, logger)
+	}
+	if cfg.Trash.PurgeInterval > 0 {
+		go runTrashPurge(ctx, logger, trashSvc, cfg.Trash.PurgeInterval)
 	}
 	errs := make(chan error)
 	go func() {
@@ -76,7 +145,7 @@ func main() {
 	}()
 	level.Info(logger).Log("message", "Service is ready to listen and serve", "type", cfg.Listen.Type, "bind_ip", cfg.Listen.BindIP, "port", cfg.Listen.Port)
 
-	endpoints := handler.MakeEndpoints(logger, fileSvc, folderSvc)
+	endpoints := handler.MakeEndpoints(logger, fileSvc, folderSvc, trashSvc, searchSvc, bulkSvc, starSvc, recentSvc, auditSvc, changeSvc)
 
 	go func() {
 		address := cfg.Listen.BindIP + ":" + cfg.Listen.Port
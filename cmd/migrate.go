@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kit/log"
+	"os"
+	repo "remy_explorer/internal/explorer/repository/postgresql"
+	"strconv"
+	"text/tabwriter"
+)
+
+const migrateUsage = `usage: explorer migrate <command>
+
+commands:
+  up          apply all pending migrations
+  down [n]    roll back the last n applied migrations (default 1)
+  status      list migrations and whether they are applied`
+
+// runMigrate executes the "migrate" subcommand with the given arguments.
+func runMigrate(ctx context.Context, logger log.Logger, client repo.Client, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing migrate command\n%s", migrateUsage)
+	}
+	migrator, err := repo.NewMigrator(client, logger)
+	if err != nil {
+		return err
+	}
+	switch args[0] {
+	case "up":
+		n, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("applied %d migration(s)\n", n)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of steps %q", args[1])
+			}
+		}
+		n, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("rolled back %d migration(s)\n", n)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, st := range statuses {
+			applied := "pending"
+			if st.AppliedAt != nil {
+				applied = st.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", st.Version, st.Name, applied)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("unknown migrate command %q\n%s", args[0], migrateUsage)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"remy_explorer/internal/explorer/service/trash"
+	"time"
+)
+
+// runTrashPurge purges the expired items of the trash every interval until ctx is cancelled.
+// There is no object storage client in this service, so the object paths of the purged files are logged for cleanup.
+func runTrashPurge(ctx context.Context, logger log.Logger, svc trash.TrashService, interval time.Duration) {
+	logger = log.With(logger, "job", "trash_purge")
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			files, err := svc.PurgeExpired(ctx)
+			if err != nil {
+				level.Error(logger).Log("message", "Failed to purge the trash", "err", err)
+				continue
+			}
+			for _, f := range files {
+				level.Info(logger).Log("message", "Stored object can be removed", "file", f.ID, "object_path", f.ObjectPath)
+			}
+		}
+	}
+}
@@ -25,9 +25,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/files": {
-            "put": {
-                "description": "Update the details of an existing file",
+        "/audit": {
+            "get": {
+                "description": "Get a page of the changes made to folders and files, newest first: every create, update, move and delete\nwith the fields it changed, the actor named by the X-Actor header and the ID of the request (X-Request-ID).",
                 "consumes": [
                     "application/json"
                 ],
@@ -35,25 +35,63 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "files"
+                    "audit"
                 ],
-                "summary": "Update a file",
+                "summary": "Get audit log",
                 "parameters": [
                     {
-                        "description": "Update File Request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schemas.UpdateFileRequest"
-                        }
+                        "enum": [
+                            "folder",
+                            "file"
+                        ],
+                        "type": "string",
+                        "description": "Kind of the item",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the item, requires the kind",
+                        "name": "item_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Actor who made the changes",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the time range, inclusive (RFC 3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the time range, exclusive (RFC 3339 or YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size (1-1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned with the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schemas.UpdateFileResponse"
+                            "$ref": "#/definitions/schemas.GetAuditResponse"
                         }
                     },
                     "400": {
@@ -62,12 +100,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/schemas.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/schemas.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -75,9 +107,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/bulk/delete": {
             "post": {
-                "description": "Create a new file in the system",
+                "description": "Move folders and files to the trash. A folder with content is only deleted with recursive set.\nThe outcome of every item is reported. With atomic set the items are deleted in one transaction:\nthe first failure rolls back the items already deleted and skips the remaining ones.",
                 "consumes": [
                     "application/json"
                 ],
@@ -85,17 +119,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "files"
+                    "bulk"
                 ],
-                "summary": "Create a new file",
+                "summary": "Delete items",
                 "parameters": [
                     {
-                        "description": "Create File Request",
+                        "description": "Bulk Delete Request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.CreateFileRequest"
+                            "$ref": "#/definitions/schemas.BulkDeleteRequest"
                         }
                     }
                 ],
@@ -103,7 +137,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schemas.CreateFileResponse"
+                            "$ref": "#/definitions/schemas.BulkResponse"
                         }
                     },
                     "400": {
@@ -121,9 +155,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/files/{id}": {
-            "get": {
-                "description": "Retrieve a file's details by its ID",
+        "/bulk/move": {
+            "post": {
+                "description": "Move folders and files into a folder of the same owner. The outcome of every item is reported.\nWith atomic set the items are moved in one transaction: the first failure rolls back the items already moved\nand skips the remaining ones. Otherwise every item is moved on its own.",
                 "consumes": [
                     "application/json"
                 ],
@@ -131,69 +165,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "files"
+                    "bulk"
                 ],
-                "summary": "Get file by ID",
+                "summary": "Move items",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "File ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/schemas.GetFileByIDResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                        "description": "Bulk Move Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.ErrorResponse"
+                            "$ref": "#/definitions/schemas.BulkMoveRequest"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/schemas.ErrorResponse"
-                        }
-                    }
-                }
-            },
-            "delete": {
-                "description": "Delete a file by its ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "files"
-                ],
-                "summary": "Delete a file",
-                "parameters": [
                     {
+                        "enum": [
+                            "fail",
+                            "rename",
+                            "overwrite"
+                        ],
                         "type": "string",
-                        "description": "File ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Policy applied when a name is taken in the target folder",
+                        "name": "on_conflict",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schemas.DeleteFileResponse"
+                            "$ref": "#/definitions/schemas.BulkResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/schemas.ErrorResponse"
                         }
@@ -207,9 +212,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/folders": {
-            "put": {
-                "description": "Update the details of an existing folder",
+        "/bulk/tag": {
+            "post": {
+                "description": "Add a tag to files. Folders cannot be tagged and fail. The outcome of every item is reported.\nWith atomic set the files are tagged in one transaction: the first failure rolls back the files already tagged\nand skips the remaining ones.",
                 "consumes": [
                     "application/json"
                 ],
@@ -217,17 +222,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "folders"
+                    "bulk"
                 ],
-                "summary": "Update a folder",
+                "summary": "Tag items",
                 "parameters": [
                     {
-                        "description": "Update Folder Request",
+                        "description": "Bulk Tag Request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.UpdateFolderRequest"
+                            "$ref": "#/definitions/schemas.BulkTagRequest"
                         }
                     }
                 ],
@@ -235,7 +240,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schemas.UpdateFolderResponse"
+                            "$ref": "#/definitions/schemas.BulkResponse"
                         }
                     },
                     "400": {
@@ -244,12 +249,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/schemas.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/schemas.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -257,9 +256,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/bulk/untag": {
             "post": {
-                "description": "Create a new folder in the system",
+                "description": "Remove a tag from files. Folders cannot be tagged and fail. The outcome of every item is reported.\nWith atomic set the files are untagged in one transaction: the first failure rolls back the files already untagged\nand skips the remaining ones.",
                 "consumes": [
                     "application/json"
                 ],
@@ -267,17 +268,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "folders"
+                    "bulk"
                 ],
-                "summary": "Create a new folder",
+                "summary": "Untag items",
                 "parameters": [
                     {
-                        "description": "Create Folder Request",
+                        "description": "Bulk Untag Request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.CreateFolderRequest"
+                            "$ref": "#/definitions/schemas.BulkTagRequest"
                         }
                     }
                 ],
@@ -285,7 +286,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schemas.CreateFolderResponse"
+                            "$ref": "#/definitions/schemas.BulkResponse"
                         }
                     },
                     "400": {
@@ -303,9 +304,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/folders/{folderID}/files": {
+        "/changes": {
             "get": {
-                "description": "Retrieve a list of files in a specific folder",
+                "description": "Get the creates, updates, moves and deletes of the folders and files of an owner after a cursor, oldest first.\nWithout a cursor the feed starts with the creation of every item, parents before children.\nMoving an item to the trash deletes it and restoring it creates it again; a folder moved to the trash\nwith its subtree deletes every item of the subtree. Keep next_cursor to ask for the following changes,\nright away while has_more is true, later otherwise.",
                 "consumes": [
                     "application/json"
                 ],
@@ -313,27 +314,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "files"
+                    "changes"
                 ],
-                "summary": "Get files by folder ID",
+                "summary": "Get changes",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Folder ID",
-                        "name": "folderID",
-                        "in": "path",
+                        "description": "Owner ID",
+                        "name": "owner_id",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of changes (1-1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned with the previous changes",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schemas.GetFilesByFolderIDResponse"
+                            "$ref": "#/definitions/schemas.GetChangesResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/schemas.ErrorResponse"
                         }
@@ -347,9 +361,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/folders/{id}": {
-            "get": {
-                "description": "Retrieve a folder's details by its ID",
+        "/files": {
+            "put": {
+                "description": "Update the details of an existing file. A new content is recorded as the next version of the file.\nA new folder_id moves the file and must pass the same checks as a move, an empty one keeps the current folder.\nThe stored objects of the versions dropped beyond the cap are listed for object cleanup.",
                 "consumes": [
                     "application/json"
                 ],
@@ -357,23 +371,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "folders"
+                    "files"
                 ],
-                "summary": "Get folder by ID",
+                "summary": "Update a file",
                 "parameters": [
+                    {
+                        "description": "Update File Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.UpdateFileRequest"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "Folder ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "ETag of the revision the file must be at",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schemas.GetFolderByIDResponse"
+                            "$ref": "#/definitions/schemas.UpdateFileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
                         }
                     },
                     "404": {
@@ -382,16 +410,34 @@ const docTemplate = `{
                             "$ref": "#/definitions/schemas.ErrorResponse"
                         }
                     },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/schemas.ErrorResponse"
                         }
+                    },
+                    "507": {
+                        "description": "Insufficient Storage",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
                     }
                 }
             },
-            "delete": {
-                "description": "Delete a folder by its ID",
+            "post": {
+                "description": "Create a new file in the system",
                 "consumes": [
                     "application/json"
                 ],
@@ -399,27 +445,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "folders"
+                    "files"
                 ],
-                "summary": "Delete a folder",
+                "summary": "Create a new file",
                 "parameters": [
                     {
+                        "description": "Create File Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CreateFileRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "fail",
+                            "rename",
+                            "overwrite"
+                        ],
                         "type": "string",
-                        "description": "Folder ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Policy applied when the name is taken in the folder",
+                        "name": "on_conflict",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schemas.DeleteFolderResponse"
+                            "$ref": "#/definitions/schemas.CreateFileResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/schemas.ErrorResponse"
                         }
@@ -429,13 +494,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/schemas.ErrorResponse"
                         }
+                    },
+                    "507": {
+                        "description": "Insufficient Storage",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/folders/{id}/content": {
+        "/files/{id}": {
             "get": {
-                "description": "Get files and folders inside folder",
+                "description": "Retrieve a file's details by its ID. The file is recorded as opened in the recent feed of its owner.",
                 "consumes": [
                     "application/json"
                 ],
@@ -443,13 +514,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "folders"
+                    "files"
                 ],
-                "summary": "Get folder content",
+                "summary": "Get file by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Folder ID",
+                        "description": "File ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -459,7 +530,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schemas.GetFolderContentResponse"
+                            "$ref": "#/definitions/schemas.GetFileByIDResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the revision of the file, to send in If-Match"
+                            }
                         }
                     },
                     "404": {
@@ -475,11 +552,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/folders/{parentID}/subfolders": {
-            "get": {
-                "description": "Retrieve a list of folders within a specific parent folder",
+            },
+            "delete": {
+                "description": "Move a file to the trash by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -487,23 +562,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "folders"
+                    "files"
                 ],
-                "summary": "Get folders by parent ID",
+                "summary": "Delete a file",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Parent Folder ID",
-                        "name": "parentID",
+                        "description": "File ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the revision the file must be at",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schemas.GetFoldersByParentIDResponse"
+                            "$ref": "#/definitions/schemas.DeleteFileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
                         }
                     },
                     "404": {
@@ -512,6 +599,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/schemas.ErrorResponse"
                         }
                     },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -519,26 +612,3510 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
+            },
+            "patch": {
+                "description": "Apply a JSON Merge Patch to a file: only the members present in the body are changed.\nA new folder_id moves the file and must pass the same checks as a move.\nA new path, given with its size, is recorded as the next version of the file. Returns the updated file.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Patch a file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Members of the file to change",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.PatchFileRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the revision the file must be at",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.PatchFileResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the new revision of the file"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "507": {
+                        "description": "Insufficient Storage",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/{id}/access": {
+            "post": {
+                "description": "Record that a file was opened without reading it, for clients that open files through a cached copy.\nGET /files/{id} records the access as well.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recent"
+                ],
+                "summary": "Record a file access",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.RecordAccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/{id}/copy": {
+            "post": {
+                "description": "Copy a file into a folder of the same owner, which may be its own folder.\nThe copy gets the tags and the current content of the file and starts a new version history.\nWith overwrite into its own folder the file is not replaced, the copy gets a numbered name instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Copy a file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Copy File Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CopyFileRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "fail",
+                            "rename",
+                            "overwrite"
+                        ],
+                        "type": "string",
+                        "description": "Policy applied when the name is taken in the target folder",
+                        "name": "on_conflict",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CopyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "507": {
+                        "description": "Insufficient Storage",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/{id}/move": {
+            "post": {
+                "description": "Move a file into another folder of the same owner.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Move a file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Move File Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.MoveFileRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "fail",
+                            "rename",
+                            "overwrite"
+                        ],
+                        "type": "string",
+                        "description": "Policy applied when the name is taken in the target folder",
+                        "name": "on_conflict",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the revision the file must be at",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.MoveFileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/{id}/path": {
+            "get": {
+                "description": "Retrieve the folders containing a file ordered from the root, ending with its parent folder",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Get file path",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.GetPathResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/{id}/star": {
+            "put": {
+                "description": "Star a file, or a folder with PUT /folders/{id}/star, to find it in the starred items of its owner.\nStarring a starred item changes nothing. Items in the trash cannot be starred.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stars"
+                ],
+                "summary": "Star an item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File or folder ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.StarResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove the star of a file, or of a folder with DELETE /folders/{id}/star.\nUnstarring an item without a star changes nothing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stars"
+                ],
+                "summary": "Unstar an item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File or folder ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.StarResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/{id}/tags/{tag}": {
+            "post": {
+                "description": "Add a tag to a file. Tags are trimmed and lower-cased, inner whitespace is collapsed to single spaces.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Tag a file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag",
+                        "name": "tag",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the revision the file must be at",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.FileTagsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a tag from a file. Removing a tag the file does not have changes nothing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Untag a file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag",
+                        "name": "tag",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the revision the file must be at",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.FileTagsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/{id}/versions": {
+            "get": {
+                "description": "Retrieve the kept versions of a file content, latest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Get file versions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.GetFileVersionsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/{id}/versions/{v}/restore": {
+            "post": {
+                "description": "Make the content of a past version current again. The restored content is recorded as a new version.\nThe stored objects of the versions dropped beyond the cap are listed for object cleanup.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Restore a file version",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Version number",
+                        "name": "v",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the revision the file must be at",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.RestoreFileVersionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/folders": {
+            "put": {
+                "description": "Update the details of an existing folder",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "folders"
+                ],
+                "summary": "Update a folder",
+                "parameters": [
+                    {
+                        "description": "Update Folder Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.UpdateFolderRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the revision the folder must be at",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.UpdateFolderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new folder in the system",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "folders"
+                ],
+                "summary": "Create a new folder",
+                "parameters": [
+                    {
+                        "description": "Create Folder Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CreateFolderRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "fail",
+                            "rename"
+                        ],
+                        "type": "string",
+                        "description": "Policy applied when the name is taken in the parent folder",
+                        "name": "on_conflict",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CreateFolderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/folders/{folderID}/files": {
+            "get": {
+                "description": "Retrieve a page of the files in a specific folder",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Get files by folder ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Folder ID",
+                        "name": "folderID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "name",
+                            "size",
+                            "created_at",
+                            "updated_at"
+                        ],
+                        "type": "string",
+                        "default": "id",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ASC",
+                            "DESC"
+                        ],
+                        "type": "string",
+                        "default": "ASC",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size (1-1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned with the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.GetFilesByFolderIDResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/folders/{id}": {
+            "get": {
+                "description": "Retrieve a folder's details by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "folders"
+                ],
+                "summary": "Get folder by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Folder ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.GetFolderByIDResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the revision of the folder, to send in If-Match"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Move a folder to the trash by its ID. A folder with content can only be deleted recursively,\nwhich moves the whole subtree to the trash, to be restored or purged as a whole.\nThe root folder of an owner cannot be deleted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "folders"
+                ],
+                "summary": "Delete a folder",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Folder ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete subfolders and files as well",
+                        "name": "recursive",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the revision the folder must be at",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.DeleteFolderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Apply a JSON Merge Patch to a folder: only the members present in the body are changed.\nA new parent_id moves the folder and must pass the same checks as a move. Returns the updated folder.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "folders"
+                ],
+                "summary": "Patch a folder",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Folder ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Members of the folder to change",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.PatchFolderRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the revision the folder must be at",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.GetFolderByIDResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the new revision of the folder"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/folders/{id}/content": {
+            "get": {
+                "description": "Get a page of the files and folders inside a folder.\nSubfolders always come first, each group is sorted by the requested field; folders sort as empty by size.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "folders"
+                ],
+                "summary": "Get folder content",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Folder ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "name",
+                            "size",
+                            "created_at",
+                            "updated_at"
+                        ],
+                        "type": "string",
+                        "default": "id",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ASC",
+                            "DESC"
+                        ],
+                        "type": "string",
+                        "default": "ASC",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size (1-1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned with the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.GetFolderContentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/folders/{id}/copy": {
+            "post": {
+                "description": "Copy a folder with all the subfolders and files below it under a parent of the same owner, in one transaction.\nItems in the trash are not copied. The copied files share the stored objects of the original ones.\nThe response maps the ID of every copied item to the ID of its copy.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "folders"
+                ],
+                "summary": "Copy a folder",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Folder ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Copy Folder Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CopyFolderRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "fail",
+                            "rename"
+                        ],
+                        "type": "string",
+                        "description": "Policy applied when the name is taken in the parent",
+                        "name": "on_conflict",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CopyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "507": {
+                        "description": "Insufficient Storage",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/folders/{id}/move": {
+            "post": {
+                "description": "Move a folder under a new parent of the same owner.\nA folder cannot be moved into itself or one of its descendants.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "folders"
+                ],
+                "summary": "Move a folder",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Folder ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Move Folder Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.MoveFolderRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "fail",
+                            "rename"
+                        ],
+                        "type": "string",
+                        "description": "Policy applied when the name is taken in the new parent",
+                        "name": "on_conflict",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the revision the folder must be at",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.MoveFolderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/folders/{id}/path": {
+            "get": {
+                "description": "Retrieve the ancestors of a folder ordered from the root, ending with the folder itself",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "folders"
+                ],
+                "summary": "Get folder path",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Folder ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.GetPathResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/folders/{id}/star": {
+            "put": {
+                "description": "Star a file, or a folder with PUT /folders/{id}/star, to find it in the starred items of its owner.\nStarring a starred item changes nothing. Items in the trash cannot be starred.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stars"
+                ],
+                "summary": "Star an item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File or folder ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.StarResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove the star of a file, or of a folder with DELETE /folders/{id}/star.\nUnstarring an item without a star changes nothing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stars"
+                ],
+                "summary": "Unstar an item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File or folder ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.StarResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/folders/{id}/tree": {
+            "get": {
+                "description": "Retrieve a folder with its subfolders nested up to the given depth.\nEvery node carries its direct subfolder and file counts, so deeper levels can be loaded lazily.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "folders"
+                ],
+                "summary": "Get folder tree",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Folder ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Levels below the folder to include (0-10)",
+                        "name": "depth",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.GetFolderTreeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/folders/{parentID}/subfolders": {
+            "get": {
+                "description": "Retrieve a page of the folders within a specific parent folder",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "folders"
+                ],
+                "summary": "Get folders by parent ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Parent Folder ID",
+                        "name": "parentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "name",
+                            "created_at",
+                            "updated_at"
+                        ],
+                        "type": "string",
+                        "default": "id",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ASC",
+                            "DESC"
+                        ],
+                        "type": "string",
+                        "default": "ASC",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size (1-1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned with the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.GetFoldersByParentIDResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/owners/{owner}/duplicates": {
+            "get": {
+                "description": "Group the files of an owner holding the same content, most reclaimable bytes first.\nFiles with a checksum are grouped by checksum, the other ones by name and size. Files in the trash are left out.\nCopies sharing a stored object count it once in the reclaimable bytes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Find duplicate files",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Owner ID",
+                        "name": "owner",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of groups returned",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.GetDuplicatesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/owners/{owner}/recent": {
+            "get": {
+                "description": "Get the files an owner opened last, or with kind=modified the files that changed last, most recent first.\nEach file is listed once, files in the trash are not listed.\nThe feed holds at most recent.max_per_owner files, the oldest opened files are forgotten.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recent"
+                ],
+                "summary": "Get recent files",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Owner ID",
+                        "name": "owner",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "opened",
+                            "modified"
+                        ],
+                        "type": "string",
+                        "default": "opened",
+                        "description": "Feed to get",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of files (1-1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.GetRecentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/owners/{owner}/root": {
+            "get": {
+                "description": "Retrieve the root folder of an owner, which holds all its top-level folders and files.\nThe root folder is created on first use.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "folders"
+                ],
+                "summary": "Get root folder",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Owner ID",
+                        "name": "owner",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.GetFolderByIDResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/owners/{owner}/root/content": {
+            "get": {
+                "description": "Get a page of the top-level files and folders of an owner, i.e. the content of its root folder.\nSubfolders always come first, each group is sorted by the requested field; folders sort as empty by size.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "folders"
+                ],
+                "summary": "Get root folder content",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Owner ID",
+                        "name": "owner",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "name",
+                            "size",
+                            "created_at",
+                            "updated_at"
+                        ],
+                        "type": "string",
+                        "default": "id",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ASC",
+                            "DESC"
+                        ],
+                        "type": "string",
+                        "default": "ASC",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size (1-1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned with the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.GetFolderContentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/owners/{owner}/starred": {
+            "get": {
+                "description": "Get a page of the folders and files an owner starred anywhere in its tree, most recently starred first.\nItems in the trash are not listed, they get their star back when restored.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stars"
+                ],
+                "summary": "Get starred items",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Owner ID",
+                        "name": "owner",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size (1-1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned with the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.GetStarredResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/owners/{owner}/usage": {
+            "get": {
+                "description": "Retrieve the bytes and files an owner uses against its quota. Files in the trash and kept versions count.\nA zero limit means unlimited. Changes that would exceed a limit fail with 507.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Get storage usage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Owner ID",
+                        "name": "owner",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.GetUsageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "description": "Search the folders and files of an owner whose name contains or resembles a text, most relevant first.\nThe type, size and tag filters only match files. Items in the trash are not searched.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Text searched in the names",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Owner ID",
+                        "name": "owner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "folder",
+                            "file"
+                        ],
+                        "type": "string",
+                        "description": "Only search folders or files",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Type of the files",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Smallest file size, inclusive",
+                        "name": "min_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Largest file size, inclusive",
+                        "name": "max_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the creation date range, inclusive (RFC 3339 or YYYY-MM-DD)",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the creation date range, exclusive (RFC 3339 or YYYY-MM-DD)",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the update date range, inclusive (RFC 3339 or YYYY-MM-DD)",
+                        "name": "updated_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the update date range, exclusive (RFC 3339 or YYYY-MM-DD)",
+                        "name": "updated_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tag the files must have, can be repeated",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size (1-1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned with the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.SearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "description": "Get the tags used by the files of an owner with the number of files having each, most used first.\nFiles in the trash are not counted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Get tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Owner ID",
+                        "name": "owner_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.GetTagsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{tag}/files": {
+            "get": {
+                "description": "Get a page of the files of an owner having a tag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Get files by tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag",
+                        "name": "tag",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Owner ID",
+                        "name": "owner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "name",
+                            "size",
+                            "created_at",
+                            "updated_at"
+                        ],
+                        "type": "string",
+                        "default": "id",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ASC",
+                            "DESC"
+                        ],
+                        "type": "string",
+                        "default": "ASC",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size (1-1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned with the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.GetFilesByTagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash": {
+            "get": {
+                "description": "Get a page of the folders and files an owner deleted, most recently deleted first.\nItems deleted together with a folder are not listed on their own.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Get trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Owner ID",
+                        "name": "owner_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size (1-1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned with the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.GetTrashResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/{id}": {
+            "delete": {
+                "description": "Delete a folder or file of the trash for good, a folder with its whole subtree.\nThe deleted files are listed for object cleanup.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Purge from trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "folder",
+                            "file"
+                        ],
+                        "type": "string",
+                        "description": "Item kind",
+                        "name": "kind",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.PurgeTrashItemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/{id}/restore": {
+            "post": {
+                "description": "Restore a folder or file to the folder it was deleted from, or to the owner's root folder if that one is gone.\nA folder is restored with the items deleted together with it. The item is renamed if its name has been taken.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Restore from trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "folder",
+                            "file"
+                        ],
+                        "type": "string",
+                        "description": "Item kind",
+                        "name": "kind",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.RestoreTrashItemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ErrorResponse"
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
+        "schemas.AuditChange": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "description": "Value after the change, absent for a deleted item"
+                },
+                "before": {
+                    "description": "Value before the change, absent for a created item"
+                }
+            }
+        },
+        "schemas.AuditRecordInfo": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "Action, create, update, move or delete",
+                    "type": "string"
+                },
+                "actor": {
+                    "description": "Actor who made the change, empty if the request named none",
+                    "type": "string"
+                },
+                "changes": {
+                    "description": "Changed fields of the item",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/schemas.AuditChange"
+                    }
+                },
+                "id": {
+                    "description": "ID of the record",
+                    "type": "string"
+                },
+                "item_id": {
+                    "description": "ID of the item",
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "Kind of the item, folder or file",
+                    "type": "string"
+                },
+                "owner_id": {
+                    "description": "ID of the owner of the item",
+                    "type": "string"
+                },
+                "request_id": {
+                    "description": "ID of the request that made the change",
+                    "type": "string"
+                },
+                "time": {
+                    "description": "Timestamp of the change",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.BulkDeleteRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "atomic": {
+                    "description": "Delete all items or none",
+                    "type": "boolean"
+                },
+                "items": {
+                    "description": "Items to delete",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.BulkItem"
+                    }
+                },
+                "recursive": {
+                    "description": "Delete folders with their content as well",
+                    "type": "boolean"
+                }
+            }
+        },
+        "schemas.BulkItem": {
+            "type": "object",
+            "required": [
+                "id",
+                "kind"
+            ],
+            "properties": {
+                "id": {
+                    "description": "ID of the item",
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "Kind of the item, folder or file",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.BulkItemResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "HTTP status code of the failure of a failed item",
+                    "type": "integer"
+                },
+                "error": {
+                    "description": "Failure of a failed item",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID of the item",
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "Kind of the item, folder or file",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "done, failed, rolled_back or skipped",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.BulkMoveRequest": {
+            "type": "object",
+            "required": [
+                "folder_id",
+                "items"
+            ],
+            "properties": {
+                "atomic": {
+                    "description": "Move all items or none",
+                    "type": "boolean"
+                },
+                "folder_id": {
+                    "description": "ID of the target folder",
+                    "type": "string"
+                },
+                "items": {
+                    "description": "Items to move",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.BulkItem"
+                    }
+                }
+            }
+        },
+        "schemas.BulkResponse": {
+            "type": "object",
+            "properties": {
+                "ok": {
+                    "description": "Indicates whether the operation succeeded for every item",
+                    "type": "boolean"
+                },
+                "results": {
+                    "description": "Outcome of every item",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.BulkItemResult"
+                    }
+                }
+            }
+        },
+        "schemas.BulkTagRequest": {
+            "type": "object",
+            "required": [
+                "items",
+                "tag"
+            ],
+            "properties": {
+                "atomic": {
+                    "description": "Change all items or none",
+                    "type": "boolean"
+                },
+                "items": {
+                    "description": "Items to tag or untag, only files can be tagged",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.BulkItem"
+                    }
+                },
+                "tag": {
+                    "description": "Tag to add or remove",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.ChangeInfo": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "Action, create, update, move or delete",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID of the item",
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "Kind of the item, folder or file",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name of the item after the change",
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "ID of the folder holding the item after the change, empty for a root folder",
+                    "type": "string"
+                },
+                "revision": {
+                    "description": "Revision of the item after the change",
+                    "type": "integer"
+                },
+                "seq": {
+                    "description": "Position of the change in the feed of the owner",
+                    "type": "integer"
+                },
+                "time": {
+                    "description": "Timestamp of the change",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CopyFileRequest": {
+            "type": "object",
+            "required": [
+                "folder_id"
+            ],
+            "properties": {
+                "folder_id": {
+                    "description": "ID of the target folder",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CopyFolderRequest": {
+            "type": "object",
+            "required": [
+                "parent_id"
+            ],
+            "properties": {
+                "parent_id": {
+                    "description": "ID of the parent of the copy",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CopyResponse": {
+            "type": "object",
+            "properties": {
+                "files": {
+                    "description": "IDs of the copied files mapped to the IDs of their copies",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "folders": {
+                    "description": "IDs of the copied folders mapped to the IDs of their copies",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "description": "ID of the copy of the requested item",
+                    "type": "string"
+                },
+                "ok": {
+                    "description": "Indicates whether the copy was successful",
+                    "type": "boolean"
+                }
+            }
+        },
         "schemas.CreateFileRequest": {
             "type": "object",
-            "required": [
-                "name"
-            ],
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "checksum": {
+                    "description": "Hex encoded checksum of the content, optional",
+                    "type": "string"
+                },
+                "checksum_algorithm": {
+                    "description": "Algorithm of the checksum: sha256 (default), sha1 or md5",
+                    "type": "string"
+                },
+                "folder_id": {
+                    "description": "ID of the parent folder, the owner's root folder if empty",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name of the file",
+                    "type": "string"
+                },
+                "owner_id": {
+                    "description": "ID of the owner",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "Path where the file is stored",
+                    "type": "string"
+                },
+                "size": {
+                    "description": "Size of the file",
+                    "type": "integer"
+                },
+                "tags": {
+                    "description": "Tags of the file, normalized on save",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "description": "Type of the file",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CreateFileResponse": {
+            "type": "object",
+            "properties": {
+                "deleted_files": {
+                    "description": "Stored objects of the versions dropped beyond the cap when overwriting",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.DeletedFileInfo"
+                    }
+                },
+                "id": {
+                    "description": "ID of the created file",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CreateFolderRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "description": "Name of the folder",
+                    "type": "string"
+                },
+                "owner_id": {
+                    "description": "ID of the owner",
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "ID of the parent folder, the owner's root folder if empty",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CreateFolderResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID of the created folder",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.DeleteFileResponse": {
+            "type": "object",
+            "properties": {
+                "ok": {
+                    "description": "Indicates whether the deletion was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "schemas.DeleteFolderResponse": {
+            "type": "object",
+            "properties": {
+                "ok": {
+                    "description": "Indicates whether the deletion was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "schemas.DeletedFileInfo": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID of the deleted file",
+                    "type": "string"
+                },
+                "object_path": {
+                    "description": "Path of the stored object to purge",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.DuplicateGroup": {
+            "type": "object",
+            "properties": {
+                "checksum": {
+                    "description": "Shared checksum, empty for a group matched by name and size",
+                    "type": "string"
+                },
+                "checksum_algorithm": {
+                    "description": "Algorithm of the shared checksum, empty for a group matched by name and size",
+                    "type": "string"
+                },
+                "files": {
+                    "description": "Files of the group",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.GetFileByIDResponse"
+                    }
+                },
+                "name": {
+                    "description": "Shared name of a group matched by name and size",
+                    "type": "string"
+                },
+                "reclaimable_bytes": {
+                    "description": "Bytes freed by keeping a single file of the group",
+                    "type": "integer"
+                },
+                "size": {
+                    "description": "Size of the content",
+                    "type": "integer"
+                }
+            }
+        },
+        "schemas.ErrorResponse": {
+            "description": "Represents a standard error response for the API",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Error code",
+                    "type": "integer"
+                },
+                "message": {
+                    "description": "Error message",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.FileTagsResponse": {
+            "type": "object",
+            "properties": {
+                "ok": {
+                    "description": "Indicates whether the change was successful",
+                    "type": "boolean"
+                },
+                "tags": {
+                    "description": "Tags of the file",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "schemas.FileVersionInfo": {
+            "type": "object",
+            "properties": {
+                "checksum": {
+                    "description": "Hex encoded checksum of the content, empty if unknown",
+                    "type": "string"
+                },
+                "checksum_algorithm": {
+                    "description": "Algorithm of the checksum, empty if unknown",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "Timestamp when the version was recorded",
+                    "type": "string"
+                },
+                "current": {
+                    "description": "Whether this is the current content of the file",
+                    "type": "boolean"
+                },
+                "path": {
+                    "description": "Path where the content is stored",
+                    "type": "string"
+                },
+                "size": {
+                    "description": "Size of the content",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "Type of the content",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Number of the version, increasing with every content change",
+                    "type": "integer"
+                }
+            }
+        },
+        "schemas.FolderTreeNode": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "description": "Subfolders loaded within the requested depth",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.FolderTreeNode"
+                    }
+                },
+                "file_count": {
+                    "description": "Number of files directly inside",
+                    "type": "integer"
+                },
+                "folder_count": {
+                    "description": "Number of direct subfolders",
+                    "type": "integer"
+                },
+                "has_children": {
+                    "description": "Whether the folder has subfolders to expand",
+                    "type": "boolean"
+                },
+                "id": {
+                    "description": "ID of the folder",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name of the folder",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.GetAuditResponse": {
+            "type": "object",
+            "properties": {
+                "length": {
+                    "description": "Number of records in the page",
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "description": "Cursor of the next page, empty on the last one",
+                    "type": "string"
+                },
+                "records": {
+                    "description": "Records in the page",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.AuditRecordInfo"
+                    }
+                }
+            }
+        },
+        "schemas.GetChangesResponse": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "description": "Changes returned",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.ChangeInfo"
+                    }
+                },
+                "has_more": {
+                    "description": "Whether more changes are already waiting after the cursor",
+                    "type": "boolean"
+                },
+                "length": {
+                    "description": "Number of changes returned",
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "description": "Cursor to ask for the following changes with",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.GetDuplicatesResponse": {
+            "type": "object",
+            "properties": {
+                "group_count": {
+                    "description": "Number of duplicate groups of the owner",
+                    "type": "integer"
+                },
+                "groups": {
+                    "description": "Largest groups, up to the limit",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.DuplicateGroup"
+                    }
+                },
+                "reclaimable_bytes": {
+                    "description": "Bytes freed by removing all the duplicates of the owner",
+                    "type": "integer"
+                }
+            }
+        },
+        "schemas.GetFileByIDResponse": {
+            "type": "object",
+            "properties": {
+                "checksum": {
+                    "description": "Hex encoded checksum of the content, empty if unknown",
+                    "type": "string"
+                },
+                "checksum_algorithm": {
+                    "description": "Algorithm of the checksum, empty if unknown",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "Timestamp when the file was created",
+                    "type": "string"
+                },
+                "folder_id": {
+                    "description": "ID of the parent folder",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID of the file",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name of the file",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "Path where the file is stored",
+                    "type": "string"
+                },
+                "size": {
+                    "description": "Size of the file",
+                    "type": "integer"
+                },
+                "starred": {
+                    "description": "Whether the owner starred the file",
+                    "type": "boolean"
+                },
+                "tags": {
+                    "description": "Tags associated with the file",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "description": "Type of the file",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "Timestamp when the file was last updated",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.GetFileVersionsResponse": {
+            "type": "object",
+            "properties": {
+                "length": {
+                    "description": "Number of versions kept",
+                    "type": "integer"
+                },
+                "versions": {
+                    "description": "Versions of the file",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.FileVersionInfo"
+                    }
+                }
+            }
+        },
+        "schemas.GetFilesByFolderIDResponse": {
+            "type": "object",
+            "properties": {
+                "files": {
+                    "description": "List of files",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.ShortFileInfo"
+                    }
+                },
+                "length": {
+                    "description": "Number of files in the page",
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "description": "Cursor of the next page, empty on the last one",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.GetFilesByTagResponse": {
+            "type": "object",
+            "properties": {
+                "files": {
+                    "description": "List of files",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.ShortFileInfo"
+                    }
+                },
+                "length": {
+                    "description": "Number of files in the page",
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "description": "Cursor of the next page, empty on the last one",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.GetFolderByIDResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Timestamp when the folder was created",
+                    "type": "string"
+                },
+                "file_count": {
+                    "description": "Number of files in the subtree",
+                    "type": "integer"
+                },
+                "folder_count": {
+                    "description": "Number of folders in the subtree, the folder itself excluded",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "ID of the folder",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name of the folder",
+                    "type": "string"
+                },
+                "owner_id": {
+                    "description": "ID of the owner",
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "ID of the parent folder, empty for a root folder",
+                    "type": "string"
+                },
+                "starred": {
+                    "description": "Whether the owner starred the folder",
+                    "type": "boolean"
+                },
+                "total_size": {
+                    "description": "Total size of the files in the subtree",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "description": "Timestamp when the folder was last updated",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.GetFolderContentResponse": {
+            "type": "object",
+            "properties": {
+                "files": {
+                    "description": "Files in the page",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.ShortFileInfo"
+                    }
+                },
+                "folders": {
+                    "description": "Subfolders in the page",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.ShortFolderInfo"
+                    }
+                },
+                "length": {
+                    "description": "Number of items in the page",
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "description": "Cursor of the next page, empty on the last one",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.GetFolderTreeResponse": {
+            "type": "object",
+            "properties": {
+                "tree": {
+                    "description": "The requested folder with its loaded subfolders",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.FolderTreeNode"
+                        }
+                    ]
+                }
+            }
+        },
+        "schemas.GetFoldersByParentIDResponse": {
+            "type": "object",
+            "properties": {
+                "folders": {
+                    "description": "List of folders",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.ShortFolderInfo"
+                    }
+                },
+                "length": {
+                    "description": "Number of folders in the page",
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "description": "Cursor of the next page, empty on the last one",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.GetPathResponse": {
+            "type": "object",
+            "properties": {
+                "length": {
+                    "description": "Number of folders in the path",
+                    "type": "integer"
+                },
+                "path": {
+                    "description": "Folders from the root down",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.PathItem"
+                    }
+                }
+            }
+        },
+        "schemas.GetRecentResponse": {
+            "type": "object",
+            "properties": {
+                "files": {
+                    "description": "Files in the feed",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.RecentFileInfo"
+                    }
+                },
+                "kind": {
+                    "description": "Feed returned, opened or modified",
+                    "type": "string"
+                },
+                "length": {
+                    "description": "Number of files in the feed",
+                    "type": "integer"
+                }
+            }
+        },
+        "schemas.GetStarredResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "description": "Items in the page",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.StarredItemInfo"
+                    }
+                },
+                "length": {
+                    "description": "Number of items in the page",
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "description": "Cursor of the next page, empty on the last one",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.GetTagsResponse": {
+            "type": "object",
+            "properties": {
+                "length": {
+                    "description": "Number of tags",
+                    "type": "integer"
+                },
+                "tags": {
+                    "description": "Tags with their usage counts",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.TagInfo"
+                    }
+                }
+            }
+        },
+        "schemas.GetTrashResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "description": "Items in the page",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.TrashItemInfo"
+                    }
+                },
+                "length": {
+                    "description": "Number of items in the page",
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "description": "Cursor of the next page, empty on the last one",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.GetUsageResponse": {
+            "type": "object",
+            "properties": {
+                "file_count": {
+                    "description": "Number of files, trash included",
+                    "type": "integer"
+                },
+                "max_bytes": {
+                    "description": "Allowed total size, zero means unlimited",
+                    "type": "integer"
+                },
+                "max_files": {
+                    "description": "Allowed number of files, zero means unlimited",
+                    "type": "integer"
+                },
+                "owner_id": {
+                    "description": "ID of the owner",
+                    "type": "string"
+                },
+                "used_bytes": {
+                    "description": "Total size of the stored objects, trash and kept versions included",
+                    "type": "integer"
+                }
+            }
+        },
+        "schemas.MoveFileRequest": {
+            "type": "object",
+            "required": [
+                "folder_id"
+            ],
+            "properties": {
+                "folder_id": {
+                    "description": "ID of the target folder",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.MoveFileResponse": {
+            "type": "object",
+            "properties": {
+                "ok": {
+                    "description": "Indicates whether the move was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "schemas.MoveFolderRequest": {
+            "type": "object",
+            "required": [
+                "parent_id"
+            ],
+            "properties": {
+                "parent_id": {
+                    "description": "ID of the new parent folder",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.MoveFolderResponse": {
+            "type": "object",
+            "properties": {
+                "ok": {
+                    "description": "Indicates whether the move was successful",
+                    "type": "boolean"
+                }
+            }
+        },
+        "schemas.PatchFileRequest": {
+            "type": "object",
+            "properties": {
+                "checksum": {
+                    "description": "Checksum of the new content, cleared if left out with a new path",
+                    "type": "string"
+                },
+                "checksum_algorithm": {
+                    "description": "Algorithm of the checksum of the new content, sha256 by default",
+                    "type": "string"
+                },
+                "folder_id": {
+                    "description": "ID of the folder to move the file to",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "New name of the file",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "Path where the new content is stored, given with its size",
+                    "type": "string"
+                },
+                "size": {
+                    "description": "Size of the new content",
+                    "type": "integer"
+                },
+                "tags": {
+                    "description": "New tags of the file, null removes all of them",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "description": "Type of the new content",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.PatchFileResponse": {
+            "type": "object",
             "properties": {
+                "checksum": {
+                    "description": "Hex encoded checksum of the content, empty if unknown",
+                    "type": "string"
+                },
+                "checksum_algorithm": {
+                    "description": "Algorithm of the checksum, empty if unknown",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "Timestamp when the file was created",
+                    "type": "string"
+                },
+                "deleted_files": {
+                    "description": "Stored objects of the versions dropped beyond the cap",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.DeletedFileInfo"
+                    }
+                },
                 "folder_id": {
                     "description": "ID of the parent folder",
                     "type": "string"
                 },
-                "name": {
-                    "description": "Name of the file",
+                "id": {
+                    "description": "ID of the file",
                     "type": "string"
                 },
-                "owner_id": {
-                    "description": "ID of the owner",
+                "name": {
+                    "description": "Name of the file",
                     "type": "string"
                 },
                 "path": {
@@ -549,232 +4126,358 @@ const docTemplate = `{
                     "description": "Size of the file",
                     "type": "integer"
                 },
+                "starred": {
+                    "description": "Whether the owner starred the file",
+                    "type": "boolean"
+                },
+                "tags": {
+                    "description": "Tags associated with the file",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "type": {
                     "description": "Type of the file",
                     "type": "string"
+                },
+                "updated_at": {
+                    "description": "Timestamp when the file was last updated",
+                    "type": "string"
                 }
             }
         },
-        "schemas.CreateFileResponse": {
+        "schemas.PatchFolderRequest": {
             "type": "object",
             "properties": {
-                "id": {
-                    "description": "ID of the created file",
+                "name": {
+                    "description": "New name of the folder",
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "ID of the folder to move the folder to",
                     "type": "string"
                 }
             }
         },
-        "schemas.CreateFolderRequest": {
+        "schemas.PathItem": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "name": {
-                    "description": "Name of the folder",
+                "id": {
+                    "description": "ID of the folder",
                     "type": "string"
                 },
-                "owner_id": {
-                    "description": "ID of the owner",
+                "name": {
+                    "description": "Name of the folder",
                     "type": "string"
+                }
+            }
+        },
+        "schemas.PurgeTrashItemResponse": {
+            "type": "object",
+            "properties": {
+                "deleted_files": {
+                    "description": "Files deleted for good",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.DeletedFileInfo"
+                    }
                 },
-                "parent_id": {
-                    "description": "ID of the parent folder",
-                    "type": "string"
+                "ok": {
+                    "description": "Indicates whether the purge was successful",
+                    "type": "boolean"
                 }
             }
         },
-        "schemas.CreateFolderResponse": {
+        "schemas.RecentFileInfo": {
             "type": "object",
             "properties": {
+                "at": {
+                    "description": "Timestamp when the file was last opened or changed",
+                    "type": "string"
+                },
+                "folder_id": {
+                    "description": "ID of the folder holding the file",
+                    "type": "string"
+                },
                 "id": {
-                    "description": "ID of the created folder",
+                    "description": "ID of the file",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name of the file",
+                    "type": "string"
+                },
+                "size": {
+                    "description": "Size of the file",
+                    "type": "integer"
+                },
+                "starred": {
+                    "description": "Whether the file is starred",
+                    "type": "boolean"
+                },
+                "type": {
+                    "description": "Type of the file",
                     "type": "string"
                 }
             }
         },
-        "schemas.DeleteFileResponse": {
+        "schemas.RecordAccessResponse": {
             "type": "object",
             "properties": {
                 "ok": {
-                    "description": "Indicates whether the deletion was successful",
+                    "description": "Indicates whether the access was recorded",
                     "type": "boolean"
                 }
             }
         },
-        "schemas.DeleteFolderResponse": {
+        "schemas.RestoreFileVersionResponse": {
             "type": "object",
             "properties": {
+                "deleted_files": {
+                    "description": "Stored objects of the versions dropped beyond the cap",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.DeletedFileInfo"
+                    }
+                },
                 "ok": {
-                    "description": "Indicates whether the deletion was successful",
+                    "description": "Indicates whether the restore was successful",
                     "type": "boolean"
+                },
+                "version": {
+                    "description": "Number of the version holding the restored content",
+                    "type": "integer"
                 }
             }
         },
-        "schemas.ErrorResponse": {
-            "description": "Represents a standard error response for the API",
+        "schemas.RestoreTrashItemResponse": {
             "type": "object",
             "properties": {
-                "code": {
-                    "description": "Error code",
-                    "type": "integer"
+                "name": {
+                    "description": "Name of the restored item, numbered if the original one was taken",
+                    "type": "string"
                 },
-                "message": {
-                    "description": "Error message",
+                "ok": {
+                    "description": "Indicates whether the restore was successful",
+                    "type": "boolean"
+                },
+                "parent_id": {
+                    "description": "ID of the folder the item was restored to",
                     "type": "string"
                 }
             }
         },
-        "schemas.GetFileByIDResponse": {
+        "schemas.SearchHitInfo": {
             "type": "object",
             "properties": {
                 "created_at": {
-                    "description": "Timestamp when the file was created",
+                    "description": "Timestamp when the item was created",
                     "type": "string"
                 },
-                "folder_id": {
-                    "description": "ID of the parent folder",
+                "id": {
+                    "description": "ID of the item",
                     "type": "string"
                 },
-                "id": {
-                    "description": "ID of the file",
+                "kind": {
+                    "description": "Kind of the item, folder or file",
                     "type": "string"
                 },
                 "name": {
-                    "description": "Name of the file",
+                    "description": "Name of the item",
                     "type": "string"
                 },
-                "path": {
-                    "description": "Path where the file is stored",
+                "parent_id": {
+                    "description": "ID of the folder containing the item",
                     "type": "string"
                 },
+                "path": {
+                    "description": "Folders containing the item from the root, ending with its parent",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.PathItem"
+                    }
+                },
+                "rank": {
+                    "description": "Relevance of the hit, higher is better",
+                    "type": "number"
+                },
                 "size": {
-                    "description": "Size of the file",
+                    "description": "Size of a file",
                     "type": "integer"
                 },
                 "tags": {
-                    "description": "Tags associated with the file",
+                    "description": "Tags of a file",
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
                 "type": {
-                    "description": "Type of the file",
+                    "description": "Type of a file",
                     "type": "string"
                 },
                 "updated_at": {
-                    "description": "Timestamp when the file was last updated",
+                    "description": "Timestamp when the item was last updated",
                     "type": "string"
                 }
             }
         },
-        "schemas.GetFilesByFolderIDResponse": {
+        "schemas.SearchResponse": {
             "type": "object",
             "properties": {
-                "files": {
-                    "description": "List of files",
+                "hits": {
+                    "description": "Folders and files matching the search",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/schemas.ShortFileInfo"
+                        "$ref": "#/definitions/schemas.SearchHitInfo"
                     }
                 },
                 "length": {
-                    "description": "Number of files",
+                    "description": "Number of hits in the page",
                     "type": "integer"
+                },
+                "next_cursor": {
+                    "description": "Cursor of the next page, empty on the last one",
+                    "type": "string"
                 }
             }
         },
-        "schemas.GetFolderByIDResponse": {
+        "schemas.ShortFileInfo": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "description": "Timestamp when the folder was created",
-                    "type": "string"
-                },
                 "id": {
-                    "description": "ID of the folder",
+                    "description": "ID of the file",
                     "type": "string"
                 },
                 "name": {
-                    "description": "Name of the folder",
-                    "type": "string"
-                },
-                "owner_id": {
-                    "description": "ID of the owner",
+                    "description": "Name of the file",
                     "type": "string"
                 },
-                "parent_id": {
-                    "description": "ID of the parent folder",
-                    "type": "string"
+                "starred": {
+                    "description": "Whether the owner starred the file",
+                    "type": "boolean"
                 },
-                "updated_at": {
-                    "description": "Timestamp when the folder was last updated",
+                "type": {
+                    "description": "Type of the file",
                     "type": "string"
                 }
             }
         },
-        "schemas.GetFolderContentResponse": {
+        "schemas.ShortFolderInfo": {
             "type": "object",
             "properties": {
-                "files": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schemas.ShortFileInfo"
-                    }
+                "file_count": {
+                    "description": "Number of files in the subtree",
+                    "type": "integer"
                 },
-                "folders": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schemas.ShortFolderInfo"
-                    }
+                "folder_count": {
+                    "description": "Number of folders in the subtree, the folder itself excluded",
+                    "type": "integer"
                 },
-                "length": {
+                "id": {
+                    "description": "ID of the folder",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name of the folder",
+                    "type": "string"
+                },
+                "starred": {
+                    "description": "Whether the owner starred the folder",
+                    "type": "boolean"
+                },
+                "total_size": {
+                    "description": "Total size of the files in the subtree",
                     "type": "integer"
                 }
             }
         },
-        "schemas.GetFoldersByParentIDResponse": {
+        "schemas.StarResponse": {
             "type": "object",
             "properties": {
-                "folders": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schemas.ShortFolderInfo"
-                    }
+                "ok": {
+                    "description": "Indicates whether the change was successful",
+                    "type": "boolean"
                 },
-                "length": {
-                    "type": "integer"
+                "starred": {
+                    "description": "Whether the item is now starred",
+                    "type": "boolean"
                 }
             }
         },
-        "schemas.ShortFileInfo": {
+        "schemas.StarredItemInfo": {
             "type": "object",
             "properties": {
                 "id": {
-                    "description": "ID of the file",
+                    "description": "ID of the item",
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "Kind of the item, folder or file",
                     "type": "string"
                 },
                 "name": {
-                    "description": "Name of the file",
+                    "description": "Name of the item",
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "ID of the folder holding the item, empty for a root folder",
+                    "type": "string"
+                },
+                "size": {
+                    "description": "Size of a file, total size of the subtree of a folder",
+                    "type": "integer"
+                },
+                "starred_at": {
+                    "description": "Timestamp when the item was starred",
                     "type": "string"
                 },
                 "type": {
-                    "description": "Type of the file",
+                    "description": "Type of a file",
                     "type": "string"
                 }
             }
         },
-        "schemas.ShortFolderInfo": {
+        "schemas.TagInfo": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Number of files of the owner having the tag",
+                    "type": "integer"
+                },
+                "tag": {
+                    "description": "Normalized tag",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.TrashItemInfo": {
             "type": "object",
             "properties": {
+                "deleted_at": {
+                    "description": "Timestamp when the item was moved to the trash",
+                    "type": "string"
+                },
                 "id": {
-                    "description": "ID of the folder",
+                    "description": "ID of the item",
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "Kind of the item, folder or file",
                     "type": "string"
                 },
                 "name": {
-                    "description": "Name of the folder",
+                    "description": "Name of the item",
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "ID of the folder the item was deleted from",
                     "type": "string"
+                },
+                "size": {
+                    "description": "Size of a file",
+                    "type": "integer"
                 }
             }
         },
@@ -796,12 +4499,38 @@ const docTemplate = `{
                 "name": {
                     "description": "New name of the file",
                     "type": "string"
+                },
+                "path": {
+                    "description": "Path where the new content is stored, the current content is kept if empty",
+                    "type": "string"
+                },
+                "size": {
+                    "description": "Size of the new content",
+                    "type": "integer"
+                },
+                "tags": {
+                    "description": "New tags of the file, the current tags are kept if omitted",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "description": "Type of the new content",
+                    "type": "string"
                 }
             }
         },
         "schemas.UpdateFileResponse": {
             "type": "object",
             "properties": {
+                "deleted_files": {
+                    "description": "Stored objects of the versions dropped beyond the cap",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.DeletedFileInfo"
+                    }
+                },
                 "ok": {
                     "description": "Indicates whether the update was successful",
                     "type": "boolean"
@@ -4,6 +4,7 @@ import (
 	"github.com/go-kit/log"
 	"github.com/ilyakaznacheev/cleanenv"
 	"sync"
+	"time"
 )
 
 // Config is the application configuration structure that is read from the config file.
@@ -14,7 +15,11 @@ type Config struct {
 		BindIP string `yaml:"bind_ip" env-default:"127.0.0.1"`
 		Port   string `yaml:"port" env-default:"8080"`
 	} `yaml:"listen"`
-	Storage StorageConfig `yaml:"storage"`
+	Storage  StorageConfig  `yaml:"storage"`
+	Trash    TrashConfig    `yaml:"trash"`
+	Versions VersionsConfig `yaml:"versions"`
+	Quota    QuotaConfig    `yaml:"quota"`
+	Recent   RecentConfig   `yaml:"recent"`
 }
 
 // StorageConfig is the database configuration structure that is read from the config file.
@@ -24,6 +29,36 @@ type StorageConfig struct {
 	Database string `json:"database" env-default:""`
 	User     string `json:"user" env-default:""`
 	Password string `json:"password"`
+	// AutoMigrate applies pending schema migrations when the service starts.
+	AutoMigrate bool `json:"auto_migrate" yaml:"auto_migrate" env-default:"false"`
+}
+
+// TrashConfig controls how long deleted folders and files are kept before they are purged.
+type TrashConfig struct {
+	// Retention is how long an item stays in the trash.
+	Retention time.Duration `yaml:"retention" env-default:"720h"`
+	// PurgeInterval is how often expired items are purged, zero disables the purge job.
+	PurgeInterval time.Duration `yaml:"purge_interval" env-default:"1h"`
+}
+
+// VersionsConfig controls the history kept for the content of files.
+type VersionsConfig struct {
+	// MaxPerFile is how many versions of a file are kept, zero keeps them all.
+	MaxPerFile int `yaml:"max_per_file" env-default:"10"`
+}
+
+// QuotaConfig holds the storage limits of the owners without an override in the owner_quota table.
+type QuotaConfig struct {
+	// DefaultMaxBytes is the total size of the stored objects of an owner, zero means unlimited.
+	DefaultMaxBytes int `yaml:"default_max_bytes" env-default:"0"`
+	// DefaultMaxFiles is the number of files of an owner, zero means unlimited.
+	DefaultMaxFiles int `yaml:"default_max_files" env-default:"0"`
+}
+
+// RecentConfig controls the history kept for the recent feeds of owners.
+type RecentConfig struct {
+	// MaxPerOwner is how many opened files are remembered per owner and the longest a recent feed gets, zero means unlimited.
+	MaxPerOwner int `yaml:"max_per_owner" env-default:"100"`
 }
 
 var instance *Config
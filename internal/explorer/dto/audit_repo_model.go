@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	modelerr "remy_explorer/internal/explorer/err"
+	"remy_explorer/internal/explorer/model"
+	"strconv"
+	"time"
+)
+
+// AuditSortOption is the only order of the audit log: newest record first.
+var AuditSortOption = &SortOption{Field: "id", Order: Descending}
+
+// AuditRepository is the interface that defines the methods that an audit log repository must implement.
+// Records are written in the transaction bound to ctx, so they are kept only if the change they describe is.
+type AuditRepository interface {
+	// AddRecords appends records to the audit log, stamped with the time of the transaction.
+	AddRecords(ctx context.Context, records ...*AuditRecordDTO) error
+	// GetRecords returns a page of the records matching filter in the order of AuditSortOption.
+	GetRecords(ctx context.Context, filter *model.AuditFilter, page *PageRequest) ([]*AuditRecordDTO, error)
+}
+
+// AuditRecordDTO is a row of the audit log.
+type AuditRecordDTO struct {
+	ID         int                          `json:"id"`
+	OccurredAt time.Time                    `json:"occurred_at"`
+	Actor      sql.NullString               `json:"actor"`
+	Action     string                       `json:"action"`
+	Kind       string                       `json:"item_kind"`
+	ItemID     int                          `json:"item_id"`
+	OwnerID    string                       `json:"owner_id"`
+	RequestID  sql.NullString               `json:"request_id"`
+	Changes    map[string]model.AuditChange `json:"changes"`
+}
+
+func (d AuditRecordDTO) ToDomain() *model.AuditRecord {
+	return &model.AuditRecord{
+		ID:        strconv.Itoa(d.ID),
+		Time:      d.OccurredAt,
+		Actor:     d.Actor.String,
+		Action:    d.Action,
+		Kind:      d.Kind,
+		ItemID:    strconv.Itoa(d.ItemID),
+		OwnerID:   d.OwnerID,
+		RequestID: d.RequestID.String,
+		Changes:   d.Changes,
+	}
+}
+
+// Cursor returns the position of the record in the audit log.
+func (d AuditRecordDTO) Cursor(sort *SortOption) Cursor {
+	return newCursor("", d.ID, sort, d.ID)
+}
+
+// AuditRecordToDTO converts an AuditRecord to an AuditRecordDTO.
+func AuditRecordToDTO(r *model.AuditRecord) (*AuditRecordDTO, error) {
+	itemID, err := strconv.Atoi(r.ItemID)
+	if err != nil {
+		return nil, &modelerr.InvalidArgument{Name: "item_id", Reason: fmt.Sprintf("%q is not an item ID", r.ItemID)}
+	}
+	return &AuditRecordDTO{
+		Actor:     sql.NullString{String: r.Actor, Valid: r.Actor != ""},
+		Action:    r.Action,
+		Kind:      r.Kind,
+		ItemID:    itemID,
+		OwnerID:   r.OwnerID,
+		RequestID: sql.NullString{String: r.RequestID, Valid: r.RequestID != ""},
+		Changes:   r.Changes,
+	}, nil
+}
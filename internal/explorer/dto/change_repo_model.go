@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"context"
+	"database/sql"
+	"remy_explorer/internal/explorer/model"
+	"strconv"
+	"time"
+)
+
+// ChangeSortOption is the only order of the change feed: oldest change first.
+var ChangeSortOption = &SortOption{Field: "seq", Order: Ascending}
+
+// ChangeRepository is the interface that defines the methods that a change feed repository must implement.
+// The feed is written by database triggers on every change of a folder or file, whoever makes it.
+type ChangeRepository interface {
+	// GetChanges returns a page of the changes of an owner in the order of ChangeSortOption.
+	GetChanges(ctx context.Context, ownerID string, page *PageRequest) ([]*ChangeDTO, error)
+}
+
+// ChangeDTO is a row of the change feed.
+type ChangeDTO struct {
+	Seq        int            `json:"seq"`
+	Action     string         `json:"action"`
+	Kind       string         `json:"item_kind"`
+	ItemID     int            `json:"item_id"`
+	ParentID   sql.NullString `json:"parent_id"`
+	Name       string         `json:"name"`
+	Revision   int            `json:"revision"`
+	OccurredAt time.Time      `json:"occurred_at"`
+}
+
+func (d ChangeDTO) ToDomain() *model.Change {
+	return &model.Change{
+		Seq:      d.Seq,
+		Action:   d.Action,
+		Kind:     d.Kind,
+		ItemID:   strconv.Itoa(d.ItemID),
+		ParentID: d.ParentID.String,
+		Name:     d.Name,
+		Revision: d.Revision,
+		Time:     d.OccurredAt,
+	}
+}
+
+// Cursor returns the position of the change in the feed.
+func (d ChangeDTO) Cursor(sort *SortOption) Cursor {
+	return newCursor("", d.Seq, sort, d.Seq)
+}
@@ -13,65 +13,202 @@ import (
 type FileRepository interface {
 	CreateFile(ctx context.Context, file *FileDTO) (*string, error)
 	GetFileByID(ctx context.Context, id string) (*FileDTO, error)
-	GetFilesByFolderID(ctx context.Context, folderID string) ([]*FileDTO, error)
+	GetFileByName(ctx context.Context, folderID, name string) (*FileDTO, error)
+	// GetFilesByFolderID returns a page of the files of a folder in the order given by the page sort option.
+	GetFilesByFolderID(ctx context.Context, folderID string, page *PageRequest) ([]*FileDTO, error)
 	UpdateFile(ctx context.Context, file *FileDTO) error
-	DeleteFile(ctx context.Context, id string) error
-	GetFilesByFolderIdSorted(ctx context.Context, folderID string, sortOption *SortOption) ([]*FileDTO, error)
+	// PatchFile sets the given fields of a file to their values in file, leaving the other fields unchanged.
+	PatchFile(ctx context.Context, file *FileDTO, fields []FileField) error
+	// UpdateFileContent replaces the content of a file, recording it as a new version, and returns the version number.
+	UpdateFileContent(ctx context.Context, file *FileDTO) (int, error)
+	// GetFileVersions returns the versions of a file, latest first.
+	GetFileVersions(ctx context.Context, fileID string) ([]*FileVersionDTO, error)
+	GetFileVersion(ctx context.Context, fileID string, version int) (*FileVersionDTO, error)
+	// PruneFileVersions deletes all but the latest keep versions of a file
+	// and returns the stored objects no remaining version refers to.
+	PruneFileVersions(ctx context.Context, fileID string, keep int) ([]*DeletedFileDTO, error)
+	MoveFile(ctx context.Context, id, folderID, name string) error
+	// GetFileRevision locks a file until the end of the transaction and returns its revision.
+	GetFileRevision(ctx context.Context, id string) (int, error)
+	// CopyFile copies a file into a folder under the given name. The copy starts a new history with the current content.
+	CopyFile(ctx context.Context, id, folderID, name string) (*string, error)
+	// TrashFile moves a file to the trash, hiding it from every lookup and listing.
+	TrashFile(ctx context.Context, id string) error
+	// AddFileTag adds a tag to a file unless it already has it and returns the tags of the file.
+	AddFileTag(ctx context.Context, id, tag string) ([]string, error)
+	// RemoveFileTag removes a tag from a file and returns the remaining tags of the file.
+	RemoveFileTag(ctx context.Context, id, tag string) ([]string, error)
+	// GetTags returns the tags used by the files of an owner with the number of files having each, most used first.
+	GetTags(ctx context.Context, ownerID string) ([]*TagUsageDTO, error)
+	// GetFilesByTag returns a page of the files of an owner having a tag in the order given by the page sort option.
+	GetFilesByTag(ctx context.Context, ownerID, tag string, page *PageRequest) ([]*FileDTO, error)
+	// GetDuplicates returns up to limit duplicate groups of an owner, most reclaimable bytes first.
+	GetDuplicates(ctx context.Context, ownerID string, limit int) (*DuplicatesDTO, error)
 }
 
-//TODO Можно добавить лимит и оффсет для пагинации файлов
+// FileField names a field of a file that FileRepository.PatchFile can set.
+type FileField string
+
+const (
+	FileName     FileField = "name"
+	FileFolderID FileField = "folder_id"
+	FileTags     FileField = "tags"
+)
 
 // FileDTO is the data transfer object for the File entity in the database.
 type FileDTO struct {
-	ID         int              `json:"id"`
-	OwnerID    string           `json:"owner_id"`
-	Name       string           `json:"name"`
-	FolderID   int              `json:"folder_id"`
-	ObjectPath sql.NullString   `json:"object_path"`
-	Size       int              `json:"size"`
-	Type       sql.NullString   `json:"type"`
-	CreatedAt  time.Time        `json:"created_at"`
-	UpdatedAt  time.Time        `json:"updated_at"`
-	Tags       []sql.NullString `json:"tags"`
+	ID         int            `json:"id"`
+	OwnerID    string         `json:"owner_id"`
+	Name       string         `json:"name"`
+	FolderID   int            `json:"folder_id"`
+	ObjectPath sql.NullString `json:"object_path"`
+	Size       int            `json:"size"`
+	Type       sql.NullString `json:"type"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	Tags       []string       `json:"tags"`
+	Revision   int            `json:"revision"`
+	// Checksum of the content, NULL if unknown
+	ChecksumAlgorithm sql.NullString `json:"checksum_algorithm"`
+	Checksum          sql.NullString `json:"checksum"`
+	Starred           bool           `json:"starred"`
 }
 
 func (d FileDTO) ToDomain() *model.File {
-	tags := make([]string, 0)
-	for _, tag := range d.Tags {
-		tags = append(tags, tag.String)
-	}
+	tags := make([]string, len(d.Tags))
+	copy(tags, d.Tags)
 	return &model.File{
-		ID:         strconv.Itoa(d.ID),
-		OwnerID:    d.OwnerID,
-		Name:       d.Name,
-		FolderID:   strconv.Itoa(d.FolderID),
-		ObjectPath: d.ObjectPath.String,
-		Size:       d.Size,
-		Type:       d.Type.String,
-		CreatedAt:  d.CreatedAt,
-		UpdatedAt:  d.UpdatedAt,
-		Tags:       tags,
+		ID:                strconv.Itoa(d.ID),
+		OwnerID:           d.OwnerID,
+		Name:              d.Name,
+		FolderID:          strconv.Itoa(d.FolderID),
+		ObjectPath:        d.ObjectPath.String,
+		Size:              d.Size,
+		Type:              d.Type.String,
+		CreatedAt:         d.CreatedAt,
+		UpdatedAt:         d.UpdatedAt,
+		Tags:              tags,
+		Revision:          d.Revision,
+		ChecksumAlgorithm: d.ChecksumAlgorithm.String,
+		Checksum:          d.Checksum.String,
+		Starred:           d.Starred,
 	}
 }
 
+// Cursor returns the position of the file in a file listing sorted by sort.
+func (d FileDTO) Cursor(sort *SortOption) Cursor {
+	var key any
+	switch sort.Field {
+	case "name":
+		key = d.Name
+	case "size":
+		key = d.Size
+	case "created_at":
+		key = d.CreatedAt
+	case "updated_at":
+		key = d.UpdatedAt
+	}
+	return newCursor(model.KindFile, d.ID, sort, key)
+}
+
 // FileToDTO converts a File to a FileDTO.
 func FileToDTO(f *model.File) FileDTO {
-	tags := make([]sql.NullString, 0)
-	for _, tag := range f.Tags {
-		tags = append(tags, sql.NullString{String: tag, Valid: true})
-	}
 	id, _ := strconv.Atoi(f.ID)         //TODO: rewrite
 	f_id, _ := strconv.Atoi(f.FolderID) //TODO: rewrite
 	return FileDTO{
-		ID:         id,
-		OwnerID:    f.OwnerID,
-		Name:       f.Name,
-		FolderID:   f_id,
-		ObjectPath: sql.NullString{String: f.ObjectPath, Valid: true},
-		Size:       f.Size,
-		Type:       sql.NullString{String: f.Type, Valid: true},
-		CreatedAt:  f.CreatedAt,
-		UpdatedAt:  f.UpdatedAt,
-		Tags:       tags,
+		ID:                id,
+		OwnerID:           f.OwnerID,
+		Name:              f.Name,
+		FolderID:          f_id,
+		ObjectPath:        sql.NullString{String: f.ObjectPath, Valid: true},
+		Size:              f.Size,
+		Type:              sql.NullString{String: f.Type, Valid: true},
+		CreatedAt:         f.CreatedAt,
+		UpdatedAt:         f.UpdatedAt,
+		Tags:              f.Tags,
+		Revision:          f.Revision,
+		ChecksumAlgorithm: sql.NullString{String: f.ChecksumAlgorithm, Valid: f.Checksum != ""},
+		Checksum:          sql.NullString{String: f.Checksum, Valid: f.Checksum != ""},
+	}
+}
+
+// FileVersionDTO is the data transfer object for a row of the file history.
+type FileVersionDTO struct {
+	FileID     int       `json:"file_id"`
+	Version    int       `json:"version"`
+	ObjectPath string    `json:"object_path"`
+	Size       int       `json:"size"`
+	Type       string    `json:"type"`
+	CreatedAt  time.Time `json:"created_at"`
+	Current    bool      `json:"current"`
+	// Checksum of the content, NULL if unknown
+	ChecksumAlgorithm sql.NullString `json:"checksum_algorithm"`
+	Checksum          sql.NullString `json:"checksum"`
+}
+
+func (d FileVersionDTO) ToDomain() *model.FileVersion {
+	return &model.FileVersion{
+		FileID:            strconv.Itoa(d.FileID),
+		Version:           d.Version,
+		ObjectPath:        d.ObjectPath,
+		Size:              d.Size,
+		Type:              d.Type,
+		CreatedAt:         d.CreatedAt,
+		Current:           d.Current,
+		ChecksumAlgorithm: d.ChecksumAlgorithm.String,
+		Checksum:          d.Checksum.String,
+	}
+}
+
+// DuplicatesDTO holds the largest duplicate groups of an owner with the totals over all its groups.
+type DuplicatesDTO struct {
+	Groups           []*DuplicateGroupDTO
+	GroupCount       int
+	ReclaimableBytes int
+}
+
+// DuplicateGroupDTO is a group of files of an owner with the same checksum or, without one, the same name and size.
+type DuplicateGroupDTO struct {
+	ChecksumAlgorithm sql.NullString
+	Checksum          sql.NullString
+	Name              sql.NullString // NULL for a group matched by checksum
+	Size              int
+	ReclaimableBytes  int
+	Files             []*FileDTO
+}
+
+func (d DuplicatesDTO) ToDomain() *model.Duplicates {
+	groups := make([]*model.DuplicateGroup, len(d.Groups))
+	for i, g := range d.Groups {
+		files := make([]*model.File, len(g.Files))
+		for j, f := range g.Files {
+			files[j] = f.ToDomain()
+		}
+		groups[i] = &model.DuplicateGroup{
+			ChecksumAlgorithm: g.ChecksumAlgorithm.String,
+			Checksum:          g.Checksum.String,
+			Name:              g.Name.String,
+			Size:              g.Size,
+			ReclaimableBytes:  g.ReclaimableBytes,
+			Files:             files,
+		}
+	}
+	return &model.Duplicates{
+		Groups:           groups,
+		GroupCount:       d.GroupCount,
+		ReclaimableBytes: d.ReclaimableBytes,
+	}
+}
+
+// TagUsageDTO is the data transfer object for a tag with the number of files having it.
+type TagUsageDTO struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+func (d TagUsageDTO) ToDomain() *model.TagUsage {
+	return &model.TagUsage{
+		Tag:   d.Tag,
+		Count: d.Count,
 	}
 }
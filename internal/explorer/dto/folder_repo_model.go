@@ -10,14 +10,50 @@ import (
 	"time"
 )
 
+// FolderRepository is the interface that defines the methods that a folder repository must implement.
 type FolderRepository interface {
 	CreateFolder(ctx context.Context, folder *FolderDTO) (*string, error)
 	GetFolderByID(ctx context.Context, id string) (*FolderDTO, error)
-	GetFoldersByParentID(ctx context.Context, parentID string) ([]*FolderDTO, error)
+	GetFolderByName(ctx context.Context, parentID, name string) (*FolderDTO, error)
+	// EnsureRootFolder returns the root folder of an owner, creating it if the owner has none yet.
+	EnsureRootFolder(ctx context.Context, ownerID string) (*FolderDTO, error)
+	// GetFoldersByParentID returns a page of the subfolders of a folder in the order given by the page sort option.
+	GetFoldersByParentID(ctx context.Context, parentID string, page *PageRequest) ([]*FolderDTO, error)
+	// GetFolderContent lists the subfolders followed by the files of a folder, each in the order given by the page sort option.
+	GetFolderContent(ctx context.Context, folderID string, page *PageRequest) ([]*ContentItemDTO, error)
+	// GetFolderPath returns the chain of folders from the root down to the folder itself.
+	GetFolderPath(ctx context.Context, id string) ([]*FolderDTO, error)
+	// GetFolderTree returns the folder and its descendants up to depth levels below it, parents before children.
+	GetFolderTree(ctx context.Context, id string, depth int) ([]*FolderTreeNodeDTO, error)
 	UpdateFolder(ctx context.Context, folder *FolderDTO) error
-	DeleteFolder(ctx context.Context, id string) error
+	// PatchFolder sets the given fields of a folder to their values in folder, leaving the other fields unchanged.
+	PatchFolder(ctx context.Context, folder *FolderDTO, fields []FolderField) error
+	MoveFolder(ctx context.Context, id, parentID, name string) error
+	// GetFolderRevision locks a folder until the end of the transaction and returns its revision.
+	GetFolderRevision(ctx context.Context, id string) (int, error)
+	// IsInSubtree reports whether folder id is rootID itself or one of its descendants.
+	IsInSubtree(ctx context.Context, rootID, id string) (bool, error)
+	// LockTree serializes structural changes of an owner's folder tree until the end of the transaction.
+	LockTree(ctx context.Context, ownerID string) error
+	// LockFolder serializes name allocation inside a folder until the end of the transaction.
+	LockFolder(ctx context.Context, id string) error
+	// TrashFolder moves an empty folder to the trash, hiding it from every lookup and listing.
+	TrashFolder(ctx context.Context, id string) error
+	// TrashFolderRecursive moves a folder with its whole subtree to the trash.
+	TrashFolderRecursive(ctx context.Context, id string) error
+	// CopyFolder copies a folder under parentID with the given name, together with the subfolders and files below it
+	// that are not in the trash, and returns the folders followed by the files copied.
+	CopyFolder(ctx context.Context, id, parentID, name string) ([]*CopiedItemDTO, error)
 }
 
+// FolderField names a field of a folder that FolderRepository.PatchFolder can set.
+type FolderField string
+
+const (
+	FolderName     FolderField = "name"
+	FolderParentID FolderField = "parent_id"
+)
+
 // FolderDTO is the data transfer object for the Folder entity in the database.
 type FolderDTO struct {
 	ID        int            `json:"id"`
@@ -26,27 +62,149 @@ type FolderDTO struct {
 	ParentID  sql.NullString `json:"parent_id"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
+	// Subtree totals maintained by the database triggers
+	TotalSize   int  `json:"total_size"`
+	FileCount   int  `json:"file_count"`
+	FolderCount int  `json:"folder_count"`
+	Revision    int  `json:"revision"`
+	Starred     bool `json:"starred"`
 }
 
 func (m *FolderDTO) ToDomain() *model.Folder {
 	return &model.Folder{
-		ID:        strconv.Itoa(m.ID),
-		OwnerID:   m.OwnerID,
-		Name:      m.Name,
-		ParentID:  m.ParentID.String,
-		CreatedAt: m.CreatedAt,
-		UpdatedAt: m.UpdatedAt,
+		ID:          strconv.Itoa(m.ID),
+		OwnerID:     m.OwnerID,
+		Name:        m.Name,
+		ParentID:    m.ParentID.String,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+		TotalSize:   m.TotalSize,
+		FileCount:   m.FileCount,
+		FolderCount: m.FolderCount,
+		Revision:    m.Revision,
+		Starred:     m.Starred,
 	}
 }
 
+// Cursor returns the position of the folder in a folder listing sorted by sort.
+func (m *FolderDTO) Cursor(sort *SortOption) Cursor {
+	var key any
+	switch sort.Field {
+	case "name":
+		key = m.Name
+	case "created_at":
+		key = m.CreatedAt
+	case "updated_at":
+		key = m.UpdatedAt
+	}
+	return newCursor(model.KindFolder, m.ID, sort, key)
+}
+
 func FolderToDTO(f *model.Folder) *FolderDTO {
 	id, _ := strconv.Atoi(f.ID) //TODO: rewrite
 	return &FolderDTO{
 		ID:        id,
 		OwnerID:   f.OwnerID,
 		Name:      f.Name,
-		ParentID:  sql.NullString{String: f.ParentID, Valid: true},
+		ParentID:  sql.NullString{String: f.ParentID, Valid: f.ParentID != ""},
 		CreatedAt: f.CreatedAt,
 		UpdatedAt: f.UpdatedAt,
+		Revision:  f.Revision,
+	}
+}
+
+// ContentItemDTO is a row of a folder content listing, either a subfolder or a file.
+type ContentItemDTO struct {
+	Kind      string         `json:"kind"`
+	ID        int            `json:"id"`
+	Name      string         `json:"name"`
+	Type      sql.NullString `json:"type"`
+	Size      int            `json:"size"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	// Subtree totals of a folder, zero for a file
+	TotalSize   int  `json:"total_size"`
+	FileCount   int  `json:"file_count"`
+	FolderCount int  `json:"folder_count"`
+	Starred     bool `json:"starred"`
+}
+
+func (d ContentItemDTO) ToDomain() *model.ContentItem {
+	return &model.ContentItem{
+		Kind:        d.Kind,
+		ID:          strconv.Itoa(d.ID),
+		Name:        d.Name,
+		Type:        d.Type.String,
+		Size:        d.Size,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+		TotalSize:   d.TotalSize,
+		FileCount:   d.FileCount,
+		FolderCount: d.FolderCount,
+		Starred:     d.Starred,
+	}
+}
+
+// Cursor returns the position of the item in a folder content listing sorted by sort.
+func (d ContentItemDTO) Cursor(sort *SortOption) Cursor {
+	var key any
+	switch sort.Field {
+	case "name":
+		key = d.Name
+	case "size":
+		key = d.Size
+	case "created_at":
+		key = d.CreatedAt
+	case "updated_at":
+		key = d.UpdatedAt
+	}
+	return newCursor(d.Kind, d.ID, sort, key)
+}
+
+// FolderTreeNodeDTO is a folder row of a subtree query annotated with its depth and direct content counters.
+type FolderTreeNodeDTO struct {
+	ID          int            `json:"id"`
+	Name        string         `json:"name"`
+	ParentID    sql.NullString `json:"parent_id"`
+	Depth       int            `json:"depth"`
+	FolderCount int            `json:"folder_count"`
+	FileCount   int            `json:"file_count"`
+}
+
+func (d FolderTreeNodeDTO) ToDomain() *model.FolderNode {
+	return &model.FolderNode{
+		ID:          strconv.Itoa(d.ID),
+		Name:        d.Name,
+		FolderCount: d.FolderCount,
+		FileCount:   d.FileCount,
+		Children:    make([]*model.FolderNode, 0),
+	}
+}
+
+// DeletedFileDTO identifies a stored object of a file row deleted from the database for good.
+type DeletedFileDTO struct {
+	ID         int    `json:"id"`
+	ObjectPath string `json:"object_path"`
+}
+
+func (d DeletedFileDTO) ToDomain() *model.DeletedFile {
+	return &model.DeletedFile{
+		ID:         strconv.Itoa(d.ID),
+		ObjectPath: d.ObjectPath,
+	}
+}
+
+// CopiedItemDTO maps an item of a copied subtree to its copy.
+type CopiedItemDTO struct {
+	Kind     string `json:"kind"`
+	SourceID int    `json:"source_id"`
+	ID       int    `json:"id"`
+}
+
+func (d CopiedItemDTO) ToDomain() *model.CopiedItem {
+	return &model.CopiedItem{
+		Kind:     d.Kind,
+		SourceID: strconv.Itoa(d.SourceID),
+		ID:       strconv.Itoa(d.ID),
 	}
 }
@@ -0,0 +1,110 @@
+package dto
+
+// Keyset pagination: a page starts right after the last item of the previous one,
+// identified by an opaque cursor, so concurrent inserts never shift or duplicate items.
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	modelerr "remy_explorer/internal/explorer/err"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultPageLimit is the page size used when the client does not ask for one.
+	DefaultPageLimit = 50
+	// MaxPageLimit is the largest page size a client may ask for.
+	MaxPageLimit = 1000
+)
+
+// sortValueLayout formats timestamps in cursors with the microsecond precision of PostgreSQL.
+const sortValueLayout = "2006-01-02 15:04:05.999999"
+
+// Cursor is the position of the last item of a page.
+type Cursor struct {
+	Kind  string `json:"k,omitempty"` // Item kind, for listings mixing folders and files
+	Sort  string `json:"s,omitempty"` // Sort option the cursor was issued for
+	Value string `json:"v,omitempty"` // Value of the sort field of the item
+	ID    int    `json:"i"`           // ID of the item
+}
+
+// newCursor creates the cursor of an item whose sort field holds key.
+func newCursor(kind string, id int, sort *SortOption, key any) Cursor {
+	c := Cursor{Kind: kind, Sort: sort.String(), ID: id}
+	switch v := key.(type) {
+	case string:
+		c.Value = v
+	case int:
+		c.Value = strconv.Itoa(v)
+	case float64:
+		// The shortest representation reads back as the same float8
+		c.Value = strconv.FormatFloat(v, 'g', -1, 64)
+	case time.Time:
+		c.Value = v.Format(sortValueLayout)
+	}
+	return c
+}
+
+// Encode returns the opaque string representation of the cursor handed out to clients.
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor parses a cursor previously returned by Encode.
+func DecodeCursor(s string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, &modelerr.InvalidArgument{Name: "cursor", Reason: "malformed cursor"}
+	}
+	var c Cursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, &modelerr.InvalidArgument{Name: "cursor", Reason: "malformed cursor"}
+	}
+	return &c, nil
+}
+
+// PageRequest describes which page of a listing to load and in which order.
+type PageRequest struct {
+	Limit int
+	Sort  *SortOption
+	After *Cursor // nil for the first page
+}
+
+// NewPageRequest creates a new PageRequest by validating the limit and decoding the cursor.
+// A zero limit selects DefaultPageLimit and an empty cursor selects the first page.
+// The cursor must have been issued for the same sort option.
+func NewPageRequest(limit int, cursor string, sort *SortOption) (*PageRequest, error) {
+	if limit == 0 {
+		limit = DefaultPageLimit
+	}
+	if limit < 0 || limit > MaxPageLimit {
+		return nil, &modelerr.InvalidArgument{Name: "limit", Reason: fmt.Sprintf("must be between 1 and %d", MaxPageLimit)}
+	}
+	page := &PageRequest{Limit: limit, Sort: sort}
+	if cursor != "" {
+		c, err := DecodeCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		if c.Sort != sort.String() {
+			return nil, &modelerr.InvalidArgument{Name: "cursor", Reason: "cursor was issued for another sort order"}
+		}
+		page.After = c
+	}
+	return page, nil
+}
+
+// Fetch returns how many rows a repository should load: one more than the limit, to tell whether a next page exists.
+func (p *PageRequest) Fetch() int {
+	return p.Limit + 1
+}
+
+// TrimPage cuts rows loaded with Fetch down to the page limit and reports whether a next page exists.
+func TrimPage[T any](p *PageRequest, rows []T) ([]T, bool) {
+	if len(rows) > p.Limit {
+		return rows[:p.Limit], true
+	}
+	return rows, false
+}
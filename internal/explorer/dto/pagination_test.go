@@ -0,0 +1,90 @@
+package dto
+
+import (
+	"errors"
+	modelerr "remy_explorer/internal/explorer/err"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCursorEncodeDecode(t *testing.T) {
+	sort := &SortOption{Field: "updated_at", Order: Descending}
+	at := time.Date(2024, 3, 1, 12, 30, 45, 123456000, time.UTC)
+	tests := []struct {
+		kind string
+		id   int
+		key  any
+		want string
+	}{
+		{"", 7, "report.pdf", "report.pdf"},
+		{"file", 42, 1024, "1024"},
+		{"folder", 3, 0.25, "0.25"},
+		{"", 9, at, "2024-03-01 12:30:45.123456"},
+	}
+	for _, tt := range tests {
+		c := newCursor(tt.kind, tt.id, sort, tt.key)
+		if c.Value != tt.want {
+			t.Errorf("newCursor(%v).Value = %q, want %q", tt.key, c.Value, tt.want)
+		}
+		decoded, err := DecodeCursor(c.Encode())
+		if err != nil {
+			t.Fatalf("DecodeCursor() error = %v", err)
+		}
+		if *decoded != c {
+			t.Errorf("DecodeCursor(Encode(%+v)) = %+v", c, *decoded)
+		}
+	}
+}
+
+func TestDecodeCursorMalformed(t *testing.T) {
+	for _, s := range []string{"not base64!", "bm90IGpzb24"} {
+		_, err := DecodeCursor(s)
+		var invalid *modelerr.InvalidArgument
+		if !errors.As(err, &invalid) || invalid.Name != "cursor" {
+			t.Errorf("DecodeCursor(%q) error = %v, want InvalidArgument on cursor", s, err)
+		}
+	}
+}
+
+func TestNewPageRequest(t *testing.T) {
+	byName := &SortOption{Field: "name", Order: Ascending}
+	page, err := NewPageRequest(0, "", byName)
+	if err != nil || page.Limit != DefaultPageLimit || page.After != nil || page.Fetch() != DefaultPageLimit+1 {
+		t.Errorf("NewPageRequest(0, \"\") = %+v, %v, want the first page of the default size", page, err)
+	}
+	for _, limit := range []int{-1, MaxPageLimit + 1} {
+		if _, err := NewPageRequest(limit, "", byName); err == nil {
+			t.Errorf("NewPageRequest(%d) returned no error", limit)
+		}
+	}
+
+	cursor := newCursor("", 5, byName, "b").Encode()
+	page, err = NewPageRequest(10, cursor, byName)
+	if err != nil || page.After == nil || page.After.ID != 5 || page.After.Value != "b" {
+		t.Errorf("NewPageRequest(10, cursor) = %+v, %v, want the page after item 5", page, err)
+	}
+	if _, err := NewPageRequest(10, cursor, &SortOption{Field: "name", Order: Descending}); err == nil {
+		t.Error("NewPageRequest() accepted a cursor issued for another sort order")
+	}
+}
+
+func TestTrimPage(t *testing.T) {
+	page := &PageRequest{Limit: 3}
+	tests := []struct {
+		rows     []int
+		want     []int
+		wantMore bool
+	}{
+		{nil, nil, false},
+		{[]int{1, 2}, []int{1, 2}, false},
+		{[]int{1, 2, 3}, []int{1, 2, 3}, false},
+		{[]int{1, 2, 3, 4}, []int{1, 2, 3}, true},
+	}
+	for _, tt := range tests {
+		got, more := TrimPage(page, tt.rows)
+		if !slices.Equal(got, tt.want) || more != tt.wantMore {
+			t.Errorf("TrimPage(%v) = %v, %v, want %v, %v", tt.rows, got, more, tt.want, tt.wantMore)
+		}
+	}
+}
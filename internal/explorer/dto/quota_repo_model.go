@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"context"
+	"database/sql"
+	"remy_explorer/internal/explorer/model"
+)
+
+// QuotaRepository is the interface that defines the methods that a quota repository must implement.
+type QuotaRepository interface {
+	// LockQuota serializes the changes of an owner's usage until the end of the transaction,
+	// so concurrent uploads cannot both pass the quota check.
+	LockQuota(ctx context.Context, ownerID string) error
+	// GetUsage returns the storage used by an owner with its quota override, if any.
+	GetUsage(ctx context.Context, ownerID string) (*UsageDTO, error)
+}
+
+// UsageDTO is the storage used by an owner and its quota override. A NULL limit falls back to the default one.
+type UsageDTO struct {
+	OwnerID   string        `json:"owner_id"`
+	UsedBytes int           `json:"used_bytes"`
+	FileCount int           `json:"file_count"`
+	MaxBytes  sql.NullInt64 `json:"max_bytes"`
+	MaxFiles  sql.NullInt64 `json:"max_files"`
+}
+
+// ToDomain converts the usage, filling in the limits the owner has no override for from defaults.
+func (d UsageDTO) ToDomain(defaults model.Quota) *model.Usage {
+	quota := defaults
+	if d.MaxBytes.Valid {
+		quota.MaxBytes = int(d.MaxBytes.Int64)
+	}
+	if d.MaxFiles.Valid {
+		quota.MaxFiles = int(d.MaxFiles.Int64)
+	}
+	return &model.Usage{
+		OwnerID:   d.OwnerID,
+		UsedBytes: d.UsedBytes,
+		FileCount: d.FileCount,
+		Quota:     quota,
+	}
+}
@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"context"
+	"remy_explorer/internal/explorer/model"
+	"strconv"
+	"time"
+)
+
+// RecentRepository is the interface that defines the methods that a recent feed repository must implement.
+// Files in the trash are not listed.
+type RecentRepository interface {
+	// RecordAccess records that a file outside the trash was opened now.
+	// It keeps one entry per file and drops the oldest entries of the owner beyond keep, zero keeps them all.
+	RecordAccess(ctx context.Context, fileID string, keep int) error
+	// GetOpened returns the last limit files an owner opened, most recently opened first.
+	GetOpened(ctx context.Context, ownerID string, limit int) ([]*RecentFileDTO, error)
+	// GetModified returns the last limit files of an owner that changed, most recently changed first.
+	GetModified(ctx context.Context, ownerID string, limit int) ([]*RecentFileDTO, error)
+}
+
+// RecentFileDTO is a row of a recent feed.
+type RecentFileDTO struct {
+	ID       int       `json:"id"`
+	OwnerID  string    `json:"owner_id"`
+	Name     string    `json:"name"`
+	FolderID int       `json:"folder_id"`
+	Type     string    `json:"type"`
+	Size     int       `json:"size"`
+	Starred  bool      `json:"starred"`
+	At       time.Time `json:"at"`
+}
+
+func (d RecentFileDTO) ToDomain() *model.RecentFile {
+	return &model.RecentFile{
+		ID:       strconv.Itoa(d.ID),
+		OwnerID:  d.OwnerID,
+		Name:     d.Name,
+		FolderID: strconv.Itoa(d.FolderID),
+		Type:     d.Type,
+		Size:     d.Size,
+		Starred:  d.Starred,
+		At:       d.At,
+	}
+}
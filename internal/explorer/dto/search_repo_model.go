@@ -0,0 +1,95 @@
+package dto
+
+import (
+	"context"
+	"remy_explorer/internal/explorer/model"
+	"strconv"
+	"time"
+)
+
+// SearchSortOption is the only order of search results: most relevant first.
+var SearchSortOption = &SortOption{Field: "rank", Order: Descending}
+
+// SearchRepository is the interface that defines the methods that a search repository must implement.
+type SearchRepository interface {
+	// Search returns a page of the folders and files outside the trash matching filter in the order of SearchSortOption.
+	// Root folders are never returned.
+	Search(ctx context.Context, filter *SearchFilter, page *PageRequest) ([]*SearchHitDTO, error)
+}
+
+// SearchFilter is a normalized model.SearchQuery: Text is lower-cased and Tags are normalized.
+type SearchFilter struct {
+	OwnerID       string
+	Text          string
+	Kind          string
+	Type          string
+	MinSize       *int
+	MaxSize       *int
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	UpdatedAfter  *time.Time
+	UpdatedBefore *time.Time
+	Tags          []string
+}
+
+// FilesOnly reports whether the filter uses a criterion only files can match.
+func (f *SearchFilter) FilesOnly() bool {
+	return f.Kind == model.KindFile || f.Type != "" || f.MinSize != nil || f.MaxSize != nil || len(f.Tags) > 0
+}
+
+// FoldersOnly reports whether the filter only selects folders.
+func (f *SearchFilter) FoldersOnly() bool {
+	return f.Kind == model.KindFolder
+}
+
+// PathItemDTO is a folder of the breadcrumb path of a search hit.
+type PathItemDTO struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	ParentID *int   `json:"parent_id"`
+}
+
+// SearchHitDTO is a row of the search results, either a folder or a file.
+type SearchHitDTO struct {
+	Kind      string        `json:"kind"`
+	ID        int           `json:"id"`
+	Name      string        `json:"name"`
+	ParentID  int           `json:"parent_id"`
+	Type      string        `json:"type"`
+	Size      int           `json:"size"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+	Tags      []string      `json:"tags"`
+	Rank      float64       `json:"rank"`
+	Path      []PathItemDTO `json:"path"`
+}
+
+func (d SearchHitDTO) ToDomain() *model.SearchHit {
+	tags := make([]string, len(d.Tags))
+	copy(tags, d.Tags)
+	path := make([]*model.Folder, len(d.Path))
+	for i, p := range d.Path {
+		path[i] = &model.Folder{ID: strconv.Itoa(p.ID), Name: p.Name}
+		if p.ParentID != nil {
+			path[i].ParentID = strconv.Itoa(*p.ParentID)
+		}
+	}
+	return &model.SearchHit{
+		Kind:      d.Kind,
+		ID:        strconv.Itoa(d.ID),
+		Name:      d.Name,
+		ParentID:  strconv.Itoa(d.ParentID),
+		Type:      d.Type,
+		Size:      d.Size,
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+		Tags:      tags,
+		Rank:      d.Rank,
+		Path:      path,
+	}
+}
+
+// Cursor returns the position of the hit in the search results.
+func (d SearchHitDTO) Cursor(sort *SortOption) Cursor {
+	return newCursor(d.Kind, d.ID, sort, d.Rank)
+}
@@ -2,7 +2,10 @@ package dto
 
 // this abstraction of the sort option is used to define the order in which the results should be sorted
 // it helps to avoid using string literals in the SQL query and to avoid SQL injection
-import "fmt"
+import (
+	modelerr "remy_explorer/internal/explorer/err"
+	"strings"
+)
 
 // SortOrder represents the order in which the results should be sorted.
 type SortOrder string
@@ -12,24 +15,53 @@ const (
 	Descending SortOrder = "DESC"
 )
 
+// Fields listings can be sorted by. Items with equal values are ordered by id.
+var (
+	fileSortFields = map[string]bool{
+		"id": true, "name": true, "size": true, "created_at": true, "updated_at": true,
+	}
+	folderSortFields = map[string]bool{
+		"id": true, "name": true, "created_at": true, "updated_at": true,
+	}
+)
+
 // SortOption represents the sorting options for the results.
 type SortOption struct {
 	Field string
 	Order SortOrder
 }
 
-// NewSortOption creates a new SortOption instance by validating the field and order.
+// NewSortOption creates a new SortOption instance for file listings by validating the field and order.
+// An empty field sorts by id and an empty order sorts ascending.
 func NewSortOption(field, order string) (*SortOption, error) {
-	validFields := map[string]bool{
-		"id": true, "name": true, "size": true, "created_at": true, "updated_at": true,
+	return newSortOption(fileSortFields, field, order)
+}
+
+// NewFolderSortOption creates a new SortOption instance for folder listings, which cannot be sorted by size.
+func NewFolderSortOption(field, order string) (*SortOption, error) {
+	return newSortOption(folderSortFields, field, order)
+}
+
+func newSortOption(validFields map[string]bool, field, order string) (*SortOption, error) {
+	if field == "" {
+		field = "id"
 	}
 	if _, ok := validFields[field]; !ok {
-		return nil, fmt.Errorf("invalid sort field")
+		return nil, &modelerr.InvalidArgument{Name: "sort", Reason: "invalid sort field"}
 	}
 
-	if order != "ASC" && order != "DESC" {
-		return nil, fmt.Errorf("invalid sort order")
+	order = strings.ToUpper(order)
+	if order == "" {
+		order = string(Ascending)
+	}
+	if order != string(Ascending) && order != string(Descending) {
+		return nil, &modelerr.InvalidArgument{Name: "order", Reason: "invalid sort order"}
 	}
 
 	return &SortOption{Field: field, Order: SortOrder(order)}, nil
 }
+
+// String returns the field and order, e.g. "name:ASC".
+func (o *SortOption) String() string {
+	return o.Field + ":" + string(o.Order)
+}
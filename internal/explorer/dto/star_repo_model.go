@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"context"
+	"database/sql"
+	"remy_explorer/internal/explorer/model"
+	"strconv"
+	"time"
+)
+
+// StarSortOption is the only order of the starred listing: most recently starred first.
+var StarSortOption = &SortOption{Field: "starred_at", Order: Descending}
+
+// StarRepository is the interface that defines the methods that a star repository must implement.
+// Folders and files in the trash cannot be starred or unstarred and are not listed, but keep their star.
+type StarRepository interface {
+	// SetStarred stars or unstars a folder or file. Starring a starred item keeps the time it was first starred.
+	SetStarred(ctx context.Context, kind, id string, starred bool) error
+	// GetStarred returns a page of the starred items of an owner in the order of StarSortOption.
+	GetStarred(ctx context.Context, ownerID string, page *PageRequest) ([]*StarredItemDTO, error)
+}
+
+// StarredItemDTO is a row of the starred listing, either a folder or a file.
+type StarredItemDTO struct {
+	Kind      string         `json:"kind"`
+	ID        int            `json:"id"`
+	OwnerID   string         `json:"owner_id"`
+	Name      string         `json:"name"`
+	ParentID  sql.NullString `json:"parent_id"`
+	Type      sql.NullString `json:"type"`
+	Size      int            `json:"size"`
+	StarredAt time.Time      `json:"starred_at"`
+}
+
+func (d StarredItemDTO) ToDomain() *model.StarredItem {
+	return &model.StarredItem{
+		Kind:      d.Kind,
+		ID:        strconv.Itoa(d.ID),
+		OwnerID:   d.OwnerID,
+		Name:      d.Name,
+		ParentID:  d.ParentID.String,
+		Type:      d.Type.String,
+		Size:      d.Size,
+		StarredAt: d.StarredAt,
+	}
+}
+
+// Cursor returns the position of the item in the starred listing.
+func (d StarredItemDTO) Cursor(sort *SortOption) Cursor {
+	return newCursor(d.Kind, d.ID, sort, d.StarredAt)
+}
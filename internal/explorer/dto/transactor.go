@@ -0,0 +1,12 @@
+package dto
+
+import "context"
+
+// Transactor runs a unit of work spanning several repository calls in one database transaction.
+type Transactor interface {
+	// WithTx calls fn with a context bound to a transaction.
+	// Repository calls made with that context join the transaction.
+	// The transaction is committed if fn returns nil and rolled back otherwise.
+	// A nested WithTx call joins the already running transaction.
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
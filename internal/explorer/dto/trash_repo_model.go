@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"context"
+	"remy_explorer/internal/explorer/model"
+	"strconv"
+	"time"
+)
+
+// TrashSortOption is the only order of the trash listing: most recently deleted first.
+var TrashSortOption = &SortOption{Field: "deleted_at", Order: Descending}
+
+// TrashRepository is the interface that defines the methods that a trash repository must implement.
+// Items in the trash keep their original parent, and all items deleted together share the same deletion time.
+type TrashRepository interface {
+	// GetTrash returns a page of the items an owner deleted in the order of TrashSortOption.
+	// Items deleted together with their folder are not listed on their own.
+	GetTrash(ctx context.Context, ownerID string, page *PageRequest) ([]*TrashItemDTO, error)
+	// GetTrashItem returns a folder or file of the trash, listed or not.
+	GetTrashItem(ctx context.Context, kind, id string) (*TrashItemDTO, error)
+	// RestoreFile takes a file out of the trash into a folder under the given name.
+	RestoreFile(ctx context.Context, id, folderID, name string) error
+	// RestoreFolder takes a folder out of the trash under a parent with the given name,
+	// together with the items deleted with it.
+	RestoreFolder(ctx context.Context, id, parentID, name string) error
+	// PurgeFile deletes a file of the trash for good.
+	PurgeFile(ctx context.Context, id string) ([]*DeletedFileDTO, error)
+	// PurgeFolder deletes a folder of the trash with its whole subtree for good.
+	PurgeFolder(ctx context.Context, id string) ([]*DeletedFileDTO, error)
+	// PurgeExpired deletes for good all items that have been in the trash for longer than retention.
+	PurgeExpired(ctx context.Context, retention time.Duration) ([]*DeletedFileDTO, error)
+}
+
+// TrashItemDTO is a row of the trash listing, either a folder or a file.
+type TrashItemDTO struct {
+	Kind      string    `json:"kind"`
+	ID        int       `json:"id"`
+	OwnerID   string    `json:"owner_id"`
+	Name      string    `json:"name"`
+	ParentID  int       `json:"parent_id"`
+	Size      int       `json:"size"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+func (d TrashItemDTO) ToDomain() *model.TrashItem {
+	return &model.TrashItem{
+		Kind:      d.Kind,
+		ID:        strconv.Itoa(d.ID),
+		OwnerID:   d.OwnerID,
+		Name:      d.Name,
+		ParentID:  strconv.Itoa(d.ParentID),
+		Size:      d.Size,
+		DeletedAt: d.DeletedAt,
+	}
+}
+
+// Cursor returns the position of the item in the trash listing.
+func (d TrashItemDTO) Cursor(sort *SortOption) Cursor {
+	return newCursor(d.Kind, d.ID, sort, d.DeletedAt)
+}
@@ -11,10 +11,82 @@ func (e *NotFound) Error() string {
 	return fmt.Sprintf("Resource with ID %s not found", e.ID)
 }
 
+// DuplicateError describes an attempt to put an item into a folder that already holds an item with the same name.
 type DuplicateError struct {
-	str string
+	Name     string
+	FolderID string
 }
 
 func (e *DuplicateError) Error() string {
-	return fmt.Sprintf("Resource %s duplicated", e.str)
+	return fmt.Sprintf("Resource %s duplicated in folder %s", e.Name, e.FolderID)
+}
+
+// NotEmpty describes an error returned when a folder still has content and cannot be deleted.
+type NotEmpty struct {
+	ID string
+}
+
+func (e *NotEmpty) Error() string {
+	return fmt.Sprintf("Folder with ID %s is not empty", e.ID)
+}
+
+// InvalidArgument describes an error caused by a malformed request parameter.
+type InvalidArgument struct {
+	Name   string
+	Reason string
+}
+
+func (e *InvalidArgument) Error() string {
+	return fmt.Sprintf("Invalid argument %s: %s", e.Name, e.Reason)
+}
+
+// MoveCycle describes an attempt to move a folder into itself or one of its descendants.
+type MoveCycle struct {
+	ID       string
+	TargetID string
+}
+
+func (e *MoveCycle) Error() string {
+	return fmt.Sprintf("Folder with ID %s cannot be moved into its own subtree folder %s", e.ID, e.TargetID)
+}
+
+// InvalidMove describes a move or a copy whose destination is not acceptable, e.g. missing or owned by someone else.
+type InvalidMove struct {
+	ID       string
+	TargetID string
+	Reason   string
+	Op       string // "copy" for a copy, a move otherwise
+}
+
+func (e *InvalidMove) Error() string {
+	op := e.Op
+	if op == "" {
+		op = "move"
+	}
+	return fmt.Sprintf("Cannot %s %s into folder %s: %s", op, e.ID, e.TargetID, e.Reason)
+}
+
+// PreconditionFailed describes a change of an item that was made with an outdated revision of it,
+// meaning someone else changed the item in the meantime.
+type PreconditionFailed struct {
+	ID       string
+	Expected int
+	Current  int
+}
+
+func (e *PreconditionFailed) Error() string {
+	return fmt.Sprintf("Resource with ID %s is at revision %d, not %d", e.ID, e.Current, e.Expected)
+}
+
+// QuotaExceeded describes a change that would take an owner's storage over its quota.
+type QuotaExceeded struct {
+	OwnerID  string
+	Resource string // "bytes" or "files"
+	Used     int
+	Adding   int
+	Limit    int
+}
+
+func (e *QuotaExceeded) Error() string {
+	return fmt.Sprintf("Quota of owner %s exceeded: %d %s used, %d more requested, %d allowed", e.OwnerID, e.Used, e.Resource, e.Adding, e.Limit)
 }
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"remy_explorer/internal/explorer/handler/http/schemas"
+	"remy_explorer/internal/explorer/model"
+	"remy_explorer/internal/explorer/service/audit"
+)
+
+// makeGetAuditEndpoint creates an endpoint for listing the audit log
+//
+//	@Summary		Get audit log
+//	@Description	Get a page of the changes made to folders and files, newest first: every create, update, move and delete
+//	@Description	with the fields it changed, the actor named by the X-Actor header and the ID of the request (X-Request-ID).
+//	@Tags			audit
+//	@Accept			json
+//	@Produce		json
+//	@Param			kind	query		string	false	"Kind of the item"	Enums(folder, file)
+//	@Param			item_id	query		string	false	"ID of the item, requires the kind"
+//	@Param			actor	query		string	false	"Actor who made the changes"
+//	@Param			from	query		string	false	"Start of the time range, inclusive (RFC 3339 or YYYY-MM-DD)"
+//	@Param			to		query		string	false	"End of the time range, exclusive (RFC 3339 or YYYY-MM-DD)"
+//	@Param			limit	query		int		false	"Page size (1-1000)"	default(50)
+//	@Param			cursor	query		string	false	"Cursor returned with the previous page"
+//	@Success		200		{object}	schemas.GetAuditResponse
+//	@Failure		400		{object}	schemas.ErrorResponse
+//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Router			/audit [get]
+func makeGetAuditEndpoint(logger log.Logger, s audit.AuditService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeGetAuditEndpoint", "request", request)
+		req, ok := request.(schemas.GetAuditRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		filter := &model.AuditFilter{
+			Kind:   req.Kind,
+			ItemID: req.ItemID,
+			Actor:  req.Actor,
+			From:   req.From,
+			To:     req.To,
+		}
+		records, next, err := s.GetRecords(ctx, filter, model.ListOptions{
+			Limit:  req.Limit,
+			Cursor: req.Cursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		infos := make([]schemas.AuditRecordInfo, len(records))
+		for i, r := range records {
+			changes := make(map[string]schemas.AuditChange, len(r.Changes))
+			for name, c := range r.Changes {
+				changes[name] = schemas.AuditChange{Before: c.Before, After: c.After}
+			}
+			infos[i] = schemas.AuditRecordInfo{
+				ID:        r.ID,
+				Time:      r.Time.String(),
+				Actor:     r.Actor,
+				Action:    r.Action,
+				Kind:      r.Kind,
+				ItemID:    r.ItemID,
+				OwnerID:   r.OwnerID,
+				RequestID: r.RequestID,
+				Changes:   changes,
+			}
+		}
+		return schemas.GetAuditResponse{
+			Length:     len(infos),
+			Records:    infos,
+			NextCursor: next,
+		}, nil
+	}
+}
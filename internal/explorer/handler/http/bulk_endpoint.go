@@ -0,0 +1,158 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"remy_explorer/internal/explorer/handler/http/schemas"
+	"remy_explorer/internal/explorer/model"
+	"remy_explorer/internal/explorer/service/bulk"
+)
+
+// makeBulkMoveEndpoint creates an endpoint for moving several items at once
+//
+//	@Summary		Move items
+//	@Description	Move folders and files into a folder of the same owner. The outcome of every item is reported.
+//	@Description	With atomic set the items are moved in one transaction: the first failure rolls back the items already moved
+//	@Description	and skips the remaining ones. Otherwise every item is moved on its own.
+//	@Tags			bulk
+//	@Accept			json
+//	@Produce		json
+//	@Param			body		body		schemas.BulkMoveRequest	true	"Bulk Move Request"
+//	@Param			on_conflict	query		string					false	"Policy applied when a name is taken in the target folder"	Enums(fail, rename, overwrite)
+//	@Success		200			{object}	schemas.BulkResponse
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Router			/bulk/move [post]
+func makeBulkMoveEndpoint(logger log.Logger, s bulk.BulkService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeBulkMoveEndpoint", "request", request)
+		req, ok := request.(schemas.BulkMoveRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		policy, err := model.ParseConflictPolicy(req.OnConflict)
+		if err != nil {
+			return nil, err
+		}
+		results, err := s.Move(ctx, itemRefs(req.Items), req.FolderID, policy, req.Atomic)
+		if err != nil {
+			return nil, err
+		}
+		return bulkResponse(results), nil
+	}
+}
+
+// makeBulkDeleteEndpoint creates an endpoint for deleting several items at once
+//
+//	@Summary		Delete items
+//	@Description	Move folders and files to the trash. A folder with content is only deleted with recursive set.
+//	@Description	The outcome of every item is reported. With atomic set the items are deleted in one transaction:
+//	@Description	the first failure rolls back the items already deleted and skips the remaining ones.
+//	@Tags			bulk
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		schemas.BulkDeleteRequest	true	"Bulk Delete Request"
+//	@Success		200		{object}	schemas.BulkResponse
+//	@Failure		400		{object}	schemas.ErrorResponse
+//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Router			/bulk/delete [post]
+func makeBulkDeleteEndpoint(logger log.Logger, s bulk.BulkService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeBulkDeleteEndpoint", "request", request)
+		req, ok := request.(schemas.BulkDeleteRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		results, err := s.Delete(ctx, itemRefs(req.Items), req.Recursive, req.Atomic)
+		if err != nil {
+			return nil, err
+		}
+		return bulkResponse(results), nil
+	}
+}
+
+// makeBulkAddTagEndpoint creates an endpoint for tagging several files at once
+//
+//	@Summary		Tag items
+//	@Description	Add a tag to files. Folders cannot be tagged and fail. The outcome of every item is reported.
+//	@Description	With atomic set the files are tagged in one transaction: the first failure rolls back the files already tagged
+//	@Description	and skips the remaining ones.
+//	@Tags			bulk
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		schemas.BulkTagRequest	true	"Bulk Tag Request"
+//	@Success		200		{object}	schemas.BulkResponse
+//	@Failure		400		{object}	schemas.ErrorResponse
+//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Router			/bulk/tag [post]
+func makeBulkAddTagEndpoint(logger log.Logger, s bulk.BulkService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeBulkAddTagEndpoint", "request", request)
+		req, ok := request.(schemas.BulkTagRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		results, err := s.AddTag(ctx, itemRefs(req.Items), req.Tag, req.Atomic)
+		if err != nil {
+			return nil, err
+		}
+		return bulkResponse(results), nil
+	}
+}
+
+// makeBulkRemoveTagEndpoint creates an endpoint for untagging several files at once
+//
+//	@Summary		Untag items
+//	@Description	Remove a tag from files. Folders cannot be tagged and fail. The outcome of every item is reported.
+//	@Description	With atomic set the files are untagged in one transaction: the first failure rolls back the files already untagged
+//	@Description	and skips the remaining ones.
+//	@Tags			bulk
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		schemas.BulkTagRequest	true	"Bulk Untag Request"
+//	@Success		200		{object}	schemas.BulkResponse
+//	@Failure		400		{object}	schemas.ErrorResponse
+//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Router			/bulk/untag [post]
+func makeBulkRemoveTagEndpoint(logger log.Logger, s bulk.BulkService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeBulkRemoveTagEndpoint", "request", request)
+		req, ok := request.(schemas.BulkTagRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		results, err := s.RemoveTag(ctx, itemRefs(req.Items), req.Tag, req.Atomic)
+		if err != nil {
+			return nil, err
+		}
+		return bulkResponse(results), nil
+	}
+}
+
+// itemRefs converts the items of a bulk request.
+func itemRefs(items []schemas.BulkItem) []model.ItemRef {
+	refs := make([]model.ItemRef, len(items))
+	for i, item := range items {
+		refs[i] = model.ItemRef{Kind: item.Kind, ID: item.ID}
+	}
+	return refs
+}
+
+// bulkResponse reports the outcome of every item, with the HTTP status code a failure would get on its own.
+func bulkResponse(results []*model.BulkResult) schemas.BulkResponse {
+	resp := schemas.BulkResponse{Ok: true, Results: make([]schemas.BulkItemResult, len(results))}
+	for i, r := range results {
+		resp.Results[i] = schemas.BulkItemResult{Kind: r.Kind, ID: r.ID, Status: r.Status}
+		if r.Err != nil {
+			resp.Results[i].Code = statusCode(r.Err)
+			resp.Results[i].Error = r.Err.Error()
+		}
+		if r.Status != model.BulkDone {
+			resp.Ok = false
+		}
+	}
+	return resp
+}
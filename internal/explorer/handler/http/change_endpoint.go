@@ -0,0 +1,66 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"remy_explorer/internal/explorer/handler/http/schemas"
+	"remy_explorer/internal/explorer/model"
+	"remy_explorer/internal/explorer/service/change"
+)
+
+// makeGetChangesEndpoint creates an endpoint for reading the change feed of an owner
+//
+//	@Summary		Get changes
+//	@Description	Get the creates, updates, moves and deletes of the folders and files of an owner after a cursor, oldest first.
+//	@Description	Without a cursor the feed starts with the creation of every item, parents before children.
+//	@Description	Moving an item to the trash deletes it and restoring it creates it again; a folder moved to the trash
+//	@Description	with its subtree deletes every item of the subtree. Keep next_cursor to ask for the following changes,
+//	@Description	right away while has_more is true, later otherwise.
+//	@Tags			changes
+//	@Accept			json
+//	@Produce		json
+//	@Param			owner_id	query		string	true	"Owner ID"
+//	@Param			limit		query		int		false	"Maximum number of changes (1-1000)"	default(50)
+//	@Param			cursor		query		string	false	"Cursor returned with the previous changes"
+//	@Success		200			{object}	schemas.GetChangesResponse
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Router			/changes [get]
+func makeGetChangesEndpoint(logger log.Logger, s change.ChangeService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeGetChangesEndpoint", "request", request)
+		req, ok := request.(schemas.GetChangesRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		changes, next, more, err := s.GetChanges(ctx, req.OwnerID, model.ListOptions{
+			Limit:  req.Limit,
+			Cursor: req.Cursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		infos := make([]schemas.ChangeInfo, len(changes))
+		for i, c := range changes {
+			infos[i] = schemas.ChangeInfo{
+				Seq:      c.Seq,
+				Action:   c.Action,
+				Kind:     c.Kind,
+				ID:       c.ItemID,
+				ParentID: c.ParentID,
+				Name:     c.Name,
+				Revision: c.Revision,
+				Time:     c.Time.String(),
+			}
+		}
+		return schemas.GetChangesResponse{
+			Length:     len(infos),
+			Changes:    infos,
+			NextCursor: next,
+			HasMore:    more,
+		}, nil
+	}
+}
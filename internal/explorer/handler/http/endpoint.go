@@ -3,8 +3,15 @@ package http
 import (
 	"github.com/go-kit/kit/endpoint"
 	"github.com/go-kit/log"
+	"remy_explorer/internal/explorer/service/audit"
+	"remy_explorer/internal/explorer/service/bulk"
+	"remy_explorer/internal/explorer/service/change"
 	"remy_explorer/internal/explorer/service/file"
 	"remy_explorer/internal/explorer/service/folder"
+	"remy_explorer/internal/explorer/service/recent"
+	"remy_explorer/internal/explorer/service/search"
+	"remy_explorer/internal/explorer/service/star"
+	"remy_explorer/internal/explorer/service/trash"
 )
 
 // Endpoints holds all Go kit endpoints for file operations
@@ -13,31 +20,123 @@ type Endpoints struct {
 	CreateFile         endpoint.Endpoint
 	GetFileByID        endpoint.Endpoint
 	GetFilesByParentID endpoint.Endpoint
+	GetFilePath        endpoint.Endpoint
+	MoveFile           endpoint.Endpoint
+	CopyFile           endpoint.Endpoint
 	UpdateFile         endpoint.Endpoint
+	PatchFile          endpoint.Endpoint
 	DeleteFile         endpoint.Endpoint
+	GetFileVersions    endpoint.Endpoint
+	RestoreFileVersion endpoint.Endpoint
+	GetUsage           endpoint.Endpoint
+	GetDuplicates      endpoint.Endpoint
 	//Folder endpoints
 	CreateFolder         endpoint.Endpoint
 	GetFolderByID        endpoint.Endpoint
 	GetFoldersByParentID endpoint.Endpoint
+	GetFolderPath        endpoint.Endpoint
+	GetFolderTree        endpoint.Endpoint
 	UpdateFolder         endpoint.Endpoint
+	PatchFolder          endpoint.Endpoint
+	MoveFolder           endpoint.Endpoint
+	CopyFolder           endpoint.Endpoint
 	DeleteFolder         endpoint.Endpoint
 	GetFolderContent     endpoint.Endpoint
+	GetRootFolder        endpoint.Endpoint
+	GetRootContent       endpoint.Endpoint
+	// Trash endpoints
+	GetTrash         endpoint.Endpoint
+	RestoreTrashItem endpoint.Endpoint
+	PurgeTrashItem   endpoint.Endpoint
+	// Tag endpoints
+	AddFileTag    endpoint.Endpoint
+	RemoveFileTag endpoint.Endpoint
+	GetTags       endpoint.Endpoint
+	GetFilesByTag endpoint.Endpoint
+	// Search endpoints
+	Search endpoint.Endpoint
+	// Bulk endpoints
+	BulkMove      endpoint.Endpoint
+	BulkDelete    endpoint.Endpoint
+	BulkAddTag    endpoint.Endpoint
+	BulkRemoveTag endpoint.Endpoint
+
+	// Star endpoints
+	Star       endpoint.Endpoint
+	Unstar     endpoint.Endpoint
+	GetStarred endpoint.Endpoint
+
+	// Recent endpoints
+	RecordAccess endpoint.Endpoint
+	GetRecent    endpoint.Endpoint
+
+	// Audit endpoints
+	GetAudit endpoint.Endpoint
+
+	// Change feed endpoints
+	GetChanges endpoint.Endpoint
 }
 
 // MakeEndpoints initializes all Go kit endpoints for file operations
-func MakeEndpoints(logger log.Logger, fileS file.FileService, folderS folder.FolderService) Endpoints {
+func MakeEndpoints(logger log.Logger, fileS file.FileService, folderS folder.FolderService, trashS trash.TrashService, searchS search.SearchService, bulkS bulk.BulkService, starS star.StarService, recentS recent.RecentService, auditS audit.AuditService, changeS change.ChangeService) Endpoints {
 	return Endpoints{
 		CreateFile:         makeCreateFileEndpoint(logger, fileS),
-		GetFileByID:        makeGetFileByIDEndpoint(logger, fileS),
+		GetFileByID:        makeGetFileByIDEndpoint(logger, fileS, recentS),
 		GetFilesByParentID: makeGetFilesByParentIDEndpoint(logger, fileS),
+		GetFilePath:        makeGetFilePathEndpoint(logger, fileS, folderS),
+		MoveFile:           makeMoveFileEndpoint(logger, fileS),
+		CopyFile:           makeCopyFileEndpoint(logger, fileS),
 		UpdateFile:         makeUpdateFileEndpoint(logger, fileS),
+		PatchFile:          makePatchFileEndpoint(logger, fileS),
 		DeleteFile:         makeDeleteFileEndpoint(logger, fileS),
+		GetFileVersions:    makeGetFileVersionsEndpoint(logger, fileS),
+		RestoreFileVersion: makeRestoreFileVersionEndpoint(logger, fileS),
+		GetUsage:           makeGetUsageEndpoint(logger, fileS),
+		GetDuplicates:      makeGetDuplicatesEndpoint(logger, fileS),
 		// Folder endpoints
 		CreateFolder:         makeCreateFolderEndpoint(logger, folderS),
 		GetFolderByID:        makeGetFolderByIDEndpoint(logger, folderS),
 		GetFoldersByParentID: makeGetFoldersByParentIDEndpoint(logger, folderS),
+		GetFolderPath:        makeGetFolderPathEndpoint(logger, folderS),
+		GetFolderTree:        makeGetFolderTreeEndpoint(logger, folderS),
 		UpdateFolder:         makeUpdateFolderEndpoint(logger, folderS),
+		PatchFolder:          makePatchFolderEndpoint(logger, folderS),
+		MoveFolder:           makeMoveFolderEndpoint(logger, folderS),
+		CopyFolder:           makeCopyFolderEndpoint(logger, folderS),
 		DeleteFolder:         makeDeleteFolderEndpoint(logger, folderS),
-		GetFolderContent:     makeGetFolderContentEndpoint(logger, folderS, fileS),
+		GetFolderContent:     makeGetFolderContentEndpoint(logger, folderS),
+		GetRootFolder:        makeGetRootFolderEndpoint(logger, folderS),
+		GetRootContent:       makeGetRootContentEndpoint(logger, folderS),
+		// Trash endpoints
+		GetTrash:         makeGetTrashEndpoint(logger, trashS),
+		RestoreTrashItem: makeRestoreTrashItemEndpoint(logger, trashS),
+		PurgeTrashItem:   makePurgeTrashItemEndpoint(logger, trashS),
+		// Tag endpoints
+		AddFileTag:    makeAddFileTagEndpoint(logger, fileS),
+		RemoveFileTag: makeRemoveFileTagEndpoint(logger, fileS),
+		GetTags:       makeGetTagsEndpoint(logger, fileS),
+		GetFilesByTag: makeGetFilesByTagEndpoint(logger, fileS),
+		// Search endpoints
+		Search: makeSearchEndpoint(logger, searchS),
+		// Bulk endpoints
+		BulkMove:      makeBulkMoveEndpoint(logger, bulkS),
+		BulkDelete:    makeBulkDeleteEndpoint(logger, bulkS),
+		BulkAddTag:    makeBulkAddTagEndpoint(logger, bulkS),
+		BulkRemoveTag: makeBulkRemoveTagEndpoint(logger, bulkS),
+
+		// Star endpoints
+		Star:       makeStarEndpoint(logger, starS),
+		Unstar:     makeUnstarEndpoint(logger, starS),
+		GetStarred: makeGetStarredEndpoint(logger, starS),
+
+		// Recent endpoints
+		RecordAccess: makeRecordAccessEndpoint(logger, recentS),
+		GetRecent:    makeGetRecentEndpoint(logger, recentS),
+
+		// Audit endpoints
+		GetAudit: makeGetAuditEndpoint(logger, auditS),
+
+		// Change feed endpoints
+		GetChanges: makeGetChangesEndpoint(logger, changeS),
 	}
 }
@@ -10,6 +10,8 @@ import (
 	schemas "remy_explorer/internal/explorer/handler/http/schemas"
 	"remy_explorer/internal/explorer/model"
 	"remy_explorer/internal/explorer/service/file"
+	"remy_explorer/internal/explorer/service/folder"
+	"remy_explorer/internal/explorer/service/recent"
 )
 
 // makeCreateFileEndpoint creates an endpoint for creating a file
@@ -19,10 +21,13 @@ import (
 //	@Tags			files
 //	@Accept			json
 //	@Produce		json
-//	@Param			body	body		schemas.CreateFileRequest	true	"Create File Request"
-//	@Success		200		{object}	schemas.CreateFileResponse
-//	@Failure		400		{object}	schemas.ErrorResponse
-//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Param			body		body		schemas.CreateFileRequest	true	"Create File Request"
+//	@Param			on_conflict	query		string						false	"Policy applied when the name is taken in the folder"	Enums(fail, rename, overwrite)
+//	@Success		200			{object}	schemas.CreateFileResponse
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		409			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Failure		507			{object}	schemas.ErrorResponse
 //	@Router			/files [post]
 func makeCreateFileEndpoint(logger log.Logger, s file.FileService) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
@@ -32,31 +37,42 @@ func makeCreateFileEndpoint(logger log.Logger, s file.FileService) endpoint.Endp
 			return nil, errors.New("invalid request type")
 		}
 		f := model.File{
-			Name:       req.Name,
-			FolderID:   req.FolderID,
-			OwnerID:    req.OwnerID,
-			Size:       req.Size,
-			Type:       req.Type,
-			ObjectPath: req.Path,
+			Name:              req.Name,
+			FolderID:          req.FolderID,
+			OwnerID:           req.OwnerID,
+			Size:              req.Size,
+			Type:              req.Type,
+			ObjectPath:        req.Path,
+			Tags:              req.Tags,
+			ChecksumAlgorithm: req.ChecksumAlgorithm,
+			Checksum:          req.Checksum,
+		}
+		policy, err := model.ParseConflictPolicy(req.OnConflict)
+		if err != nil {
+			return nil, err
 		}
-		id, err := s.CreateFile(ctx, &f)
-		return schemas.CreateFileResponse{ID: *id}, err
+		id, pruned, err := s.CreateFile(ctx, &f, policy)
+		if err != nil {
+			return nil, err
+		}
+		return schemas.CreateFileResponse{ID: *id, DeletedFiles: makeDeletedFilesResponse(pruned)}, nil
 	}
 }
 
 // makeGetFileByIDEndpoint creates an endpoint for getting a file by ID
 //
 //	@Summary		Get file by ID
-//	@Description	Retrieve a file's details by its ID
+//	@Description	Retrieve a file's details by its ID. The file is recorded as opened in the recent feed of its owner.
 //	@Tags			files
 //	@Accept			json
 //	@Produce		json
 //	@Param			id	path		string	true	"File ID"
 //	@Success		200	{object}	schemas.GetFileByIDResponse
+//	@Header			200	{string}	ETag	"Entity tag of the revision of the file, to send in If-Match"
 //	@Failure		404	{object}	schemas.ErrorResponse
 //	@Failure		500	{object}	schemas.ErrorResponse
 //	@Router			/files/{id} [get]
-func makeGetFileByIDEndpoint(logger log.Logger, s file.FileService) endpoint.Endpoint {
+func makeGetFileByIDEndpoint(logger log.Logger, s file.FileService, recentS recent.RecentService) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(schemas.GetFileByIDRequest)
 		if !ok {
@@ -72,31 +88,29 @@ func makeGetFileByIDEndpoint(logger log.Logger, s file.FileService) endpoint.End
 			level.Error(logger).Log("err", err, "msg", "failed to retrieve file")
 			return nil, err
 		}
-
-		response := schemas.GetFileByIDResponse{
-			ID:        f.ID,
-			Name:      f.Name,
-			FolderID:  f.FolderID,
-			Size:      f.Size,
-			Type:      f.Type,
-			Path:      f.ObjectPath,
-			CreatedAt: f.CreatedAt.String(),
-			UpdatedAt: f.UpdatedAt.String(),
-			Tags:      f.Tags,
+		// The file was read, a failure to record the access must not fail the request
+		if err := recentS.RecordAccess(ctx, f.ID); err != nil {
+			level.Error(logger).Log("err", err, "msg", "failed to record file access")
 		}
-		return response, nil
+
+		return makeFileResponse(f), nil
 	}
 }
 
 // makeGetFilesByParentIDEndpoint creates an endpoint for getting files by folder ID
 //
 //	@Summary		Get files by folder ID
-//	@Description	Retrieve a list of files in a specific folder
+//	@Description	Retrieve a page of the files in a specific folder
 //	@Tags			files
 //	@Accept			json
 //	@Produce		json
 //	@Param			folderID	path		string	true	"Folder ID"
+//	@Param			sort		query		string	false	"Field to sort by"	Enums(id, name, size, created_at, updated_at)	default(id)
+//	@Param			order		query		string	false	"Sort order"		Enums(ASC, DESC)								default(ASC)
+//	@Param			limit		query		int		false	"Page size (1-1000)"	default(50)
+//	@Param			cursor		query		string	false	"Cursor returned with the previous page"
 //	@Success		200			{object}	schemas.GetFilesByFolderIDResponse
+//	@Failure		400			{object}	schemas.ErrorResponse
 //	@Failure		404			{object}	schemas.ErrorResponse
 //	@Failure		500			{object}	schemas.ErrorResponse
 //	@Router			/folders/{folderID}/files [get]
@@ -107,35 +121,225 @@ func makeGetFilesByParentIDEndpoint(logger log.Logger, s file.FileService) endpo
 		if !ok {
 			return nil, errors.New("invalid request type")
 		}
-		files, err := s.GetFilesByFolderID(ctx, req.FolderID)
+		files, next, err := s.GetFilesByFolderID(ctx, req.FolderID, model.ListOptions{
+			Sort:   req.Sort,
+			Order:  req.Order,
+			Limit:  req.Limit,
+			Cursor: req.Cursor,
+		})
 		length := len(files) // Used two times
 		shortFiles := make([]schemas.ShortFileInfo, length)
 		for i, f := range files {
 			shortFiles[i] = schemas.ShortFileInfo{
-				ID:   f.ID,
-				Name: f.Name,
-				Type: f.Type,
+				ID:      f.ID,
+				Name:    f.Name,
+				Type:    f.Type,
+				Starred: f.Starred,
 			}
 		}
 		return schemas.GetFilesByFolderIDResponse{
-			Length: length,
-			Files:  shortFiles,
+			Length:     length,
+			Files:      shortFiles,
+			NextCursor: next,
 		}, err
 	}
 }
 
+// makeMoveFileEndpoint creates an endpoint for moving a file into another folder
+//
+//	@Summary		Move a file
+//	@Description	Move a file into another folder of the same owner.
+//	@Tags			files
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string					true	"File ID"
+//	@Param			body		body		schemas.MoveFileRequest	true	"Move File Request"
+//	@Param			on_conflict	query		string					false	"Policy applied when the name is taken in the target folder"	Enums(fail, rename, overwrite)
+//	@Param			If-Match	header		string					false	"ETag of the revision the file must be at"
+//	@Success		200			{object}	schemas.MoveFileResponse
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		404			{object}	schemas.ErrorResponse
+//	@Failure		409			{object}	schemas.ErrorResponse
+//	@Failure		412			{object}	schemas.ErrorResponse
+//	@Failure		422			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Router			/files/{id}/move [post]
+func makeMoveFileEndpoint(logger log.Logger, s file.FileService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeMoveFileEndpoint", "request", request)
+		req, ok := request.(schemas.MoveFileRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		policy, err := model.ParseConflictPolicy(req.OnConflict)
+		if err != nil {
+			return nil, err
+		}
+		err = s.MoveFile(ctx, req.ID, req.FolderID, policy, req.Revision)
+		return schemas.MoveFileResponse{Ok: err == nil}, err
+	}
+}
+
+// makeCopyFileEndpoint creates an endpoint for copying a file into a folder
+//
+//	@Summary		Copy a file
+//	@Description	Copy a file into a folder of the same owner, which may be its own folder.
+//	@Description	The copy gets the tags and the current content of the file and starts a new version history.
+//	@Description	With overwrite into its own folder the file is not replaced, the copy gets a numbered name instead.
+//	@Tags			files
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string					true	"File ID"
+//	@Param			body		body		schemas.CopyFileRequest	true	"Copy File Request"
+//	@Param			on_conflict	query		string					false	"Policy applied when the name is taken in the target folder"	Enums(fail, rename, overwrite)
+//	@Success		200			{object}	schemas.CopyResponse
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		404			{object}	schemas.ErrorResponse
+//	@Failure		409			{object}	schemas.ErrorResponse
+//	@Failure		422			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Failure		507			{object}	schemas.ErrorResponse
+//	@Router			/files/{id}/copy [post]
+func makeCopyFileEndpoint(logger log.Logger, s file.FileService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeCopyFileEndpoint", "request", request)
+		req, ok := request.(schemas.CopyFileRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		policy, err := model.ParseConflictPolicy(req.OnConflict)
+		if err != nil {
+			return nil, err
+		}
+		id, err := s.CopyFile(ctx, req.ID, req.FolderID, policy)
+		if err != nil {
+			return nil, err
+		}
+		return schemas.CopyResponse{
+			Ok:      true,
+			ID:      *id,
+			Folders: map[string]string{},
+			Files:   map[string]string{req.ID: *id},
+		}, nil
+	}
+}
+
+// makeGetFilePathEndpoint creates an endpoint for getting the breadcrumb path of a file
+//
+//	@Summary		Get file path
+//	@Description	Retrieve the folders containing a file ordered from the root, ending with its parent folder
+//	@Tags			files
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"File ID"
+//	@Success		200	{object}	schemas.GetPathResponse
+//	@Failure		404	{object}	schemas.ErrorResponse
+//	@Failure		500	{object}	schemas.ErrorResponse
+//	@Router			/files/{id}/path [get]
+func makeGetFilePathEndpoint(logger log.Logger, s file.FileService, s2 folder.FolderService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeGetFilePathEndpoint", "request", request)
+		req, ok := request.(schemas.GetFilePathRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		f, err := s.GetFileByID(ctx, req.ID)
+		if err != nil {
+			return nil, err
+		}
+		path, err := s2.GetFolderPath(ctx, f.FolderID)
+		if err != nil {
+			return nil, err
+		}
+		return makePathResponse(path), nil
+	}
+}
+
+// makeFileResponse converts a file to its representation in responses, with the entity tag of its revision.
+func makeFileResponse(f *model.File) schemas.GetFileByIDResponse {
+	return schemas.GetFileByIDResponse{
+		ID:                f.ID,
+		Name:              f.Name,
+		FolderID:          f.FolderID,
+		Size:              f.Size,
+		Type:              f.Type,
+		Path:              f.ObjectPath,
+		CreatedAt:         f.CreatedAt.String(),
+		UpdatedAt:         f.UpdatedAt.String(),
+		Tags:              f.Tags,
+		ETag:              etag(f.Revision),
+		ChecksumAlgorithm: f.ChecksumAlgorithm,
+		Checksum:          f.Checksum,
+		Starred:           f.Starred,
+	}
+}
+
+// makePatchFileEndpoint creates an endpoint for a partial update of a file
+//
+//	@Summary		Patch a file
+//	@Description	Apply a JSON Merge Patch to a file: only the members present in the body are changed.
+//	@Description	A new folder_id moves the file and must pass the same checks as a move.
+//	@Description	A new path, given with its size, is recorded as the next version of the file. Returns the updated file.
+//	@Tags			files
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string					true	"File ID"
+//	@Param			body		body		schemas.PatchFileRequest	true	"Members of the file to change"
+//	@Param			If-Match	header		string					false	"ETag of the revision the file must be at"
+//	@Success		200			{object}	schemas.PatchFileResponse
+//	@Header			200			{string}	ETag	"Entity tag of the new revision of the file"
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		404			{object}	schemas.ErrorResponse
+//	@Failure		409			{object}	schemas.ErrorResponse
+//	@Failure		412			{object}	schemas.ErrorResponse
+//	@Failure		422			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Failure		507			{object}	schemas.ErrorResponse
+//	@Router			/files/{id} [patch]
+func makePatchFileEndpoint(logger log.Logger, s file.FileService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(schemas.PatchFileRequest)
+		if !ok {
+			level.Error(logger).Log("msg", "invalid request type")
+			return nil, errors.New("invalid request type")
+		}
+		f, pruned, err := s.PatchFile(ctx, &model.FilePatch{
+			ID:                req.ID,
+			Name:              req.Name,
+			FolderID:          req.FolderID,
+			ObjectPath:        req.Path,
+			Size:              req.Size,
+			Type:              req.Type,
+			Tags:              req.Tags,
+			Revision:          req.Revision,
+			ChecksumAlgorithm: req.ChecksumAlgorithm,
+			Checksum:          req.Checksum,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return schemas.PatchFileResponse{GetFileByIDResponse: makeFileResponse(f), DeletedFiles: makeDeletedFilesResponse(pruned)}, nil
+	}
+}
+
 // makeUpdateFileEndpoint creates an endpoint for updating a file
 //
 //	@Summary		Update a file
-//	@Description	Update the details of an existing file
+//	@Description	Update the details of an existing file. A new content is recorded as the next version of the file.
+//	@Description	A new folder_id moves the file and must pass the same checks as a move, an empty one keeps the current folder.
+//	@Description	The stored objects of the versions dropped beyond the cap are listed for object cleanup.
 //	@Tags			files
 //	@Accept			json
 //	@Produce		json
-//	@Param			body	body		schemas.UpdateFileRequest	true	"Update File Request"
-//	@Success		200		{object}	schemas.UpdateFileResponse
-//	@Failure		400		{object}	schemas.ErrorResponse
-//	@Failure		404		{object}	schemas.ErrorResponse
-//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Param			body		body		schemas.UpdateFileRequest	true	"Update File Request"
+//	@Param			If-Match	header		string						false	"ETag of the revision the file must be at"
+//	@Success		200			{object}	schemas.UpdateFileResponse
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		404			{object}	schemas.ErrorResponse
+//	@Failure		412			{object}	schemas.ErrorResponse
+//	@Failure		422			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Failure		507			{object}	schemas.ErrorResponse
 //	@Router			/files [put]
 func makeUpdateFileEndpoint(logger log.Logger, s file.FileService) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
@@ -145,26 +349,192 @@ func makeUpdateFileEndpoint(logger log.Logger, s file.FileService) endpoint.Endp
 			return nil, errors.New("invalid request type")
 		}
 		f := model.File{
-			ID:       req.ID,
-			Name:     req.Name,
-			FolderID: req.FolderID,
+			ID:         req.ID,
+			Name:       req.Name,
+			FolderID:   req.FolderID,
+			Type:       req.Type,
+			ObjectPath: req.Path,
+			Size:       req.Size,
+			Tags:       req.Tags,
+			Revision:   req.Revision,
+		}
+		pruned, err := s.UpdateFile(ctx, &f)
+		if err != nil {
+			return schemas.UpdateFileResponse{Ok: false}, err
 		}
-		ok, err := s.UpdateFile(ctx, &f)
-		return schemas.UpdateFileResponse{Ok: ok}, err
+		return schemas.UpdateFileResponse{Ok: true, DeletedFiles: makeDeletedFilesResponse(pruned)}, nil
 	}
 }
 
-// makeDeleteFileEndpoint creates an endpoint for deleting a file
+// makeGetFileVersionsEndpoint creates an endpoint for getting the version history of a file
 //
-//	@Summary		Delete a file
-//	@Description	Delete a file by its ID
+//	@Summary		Get file versions
+//	@Description	Retrieve the kept versions of a file content, latest first
 //	@Tags			files
 //	@Accept			json
 //	@Produce		json
 //	@Param			id	path		string	true	"File ID"
-//	@Success		200	{object}	schemas.DeleteFileResponse
+//	@Success		200	{object}	schemas.GetFileVersionsResponse
 //	@Failure		404	{object}	schemas.ErrorResponse
 //	@Failure		500	{object}	schemas.ErrorResponse
+//	@Router			/files/{id}/versions [get]
+func makeGetFileVersionsEndpoint(logger log.Logger, s file.FileService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeGetFileVersionsEndpoint", "request", request)
+		req, ok := request.(schemas.GetFileVersionsRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		versions, err := s.GetFileVersions(ctx, req.ID)
+		if err != nil {
+			return nil, err
+		}
+		infos := make([]schemas.FileVersionInfo, len(versions))
+		for i, v := range versions {
+			infos[i] = schemas.FileVersionInfo{
+				Version:           v.Version,
+				Type:              v.Type,
+				Size:              v.Size,
+				Path:              v.ObjectPath,
+				CreatedAt:         v.CreatedAt.String(),
+				Current:           v.Current,
+				ChecksumAlgorithm: v.ChecksumAlgorithm,
+				Checksum:          v.Checksum,
+			}
+		}
+		return schemas.GetFileVersionsResponse{Length: len(infos), Versions: infos}, nil
+	}
+}
+
+// makeRestoreFileVersionEndpoint creates an endpoint for restoring a past version of a file
+//
+//	@Summary		Restore a file version
+//	@Description	Make the content of a past version current again. The restored content is recorded as a new version.
+//	@Description	The stored objects of the versions dropped beyond the cap are listed for object cleanup.
+//	@Tags			files
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"File ID"
+//	@Param			v			path		int		true	"Version number"
+//	@Param			If-Match	header		string	false	"ETag of the revision the file must be at"
+//	@Success		200			{object}	schemas.RestoreFileVersionResponse
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		404			{object}	schemas.ErrorResponse
+//	@Failure		412			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Router			/files/{id}/versions/{v}/restore [post]
+func makeRestoreFileVersionEndpoint(logger log.Logger, s file.FileService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeRestoreFileVersionEndpoint", "request", request)
+		req, ok := request.(schemas.RestoreFileVersionRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		version, pruned, err := s.RestoreFileVersion(ctx, req.ID, req.Version, req.Revision)
+		if err != nil {
+			return nil, err
+		}
+		return schemas.RestoreFileVersionResponse{Ok: true, Version: version, DeletedFiles: makeDeletedFilesResponse(pruned)}, nil
+	}
+}
+
+// makeGetUsageEndpoint creates an endpoint for getting the storage usage of an owner
+//
+//	@Summary		Get storage usage
+//	@Description	Retrieve the bytes and files an owner uses against its quota. Files in the trash and kept versions count.
+//	@Description	A zero limit means unlimited. Changes that would exceed a limit fail with 507.
+//	@Tags			files
+//	@Accept			json
+//	@Produce		json
+//	@Param			owner	path		string	true	"Owner ID"
+//	@Success		200		{object}	schemas.GetUsageResponse
+//	@Failure		400		{object}	schemas.ErrorResponse
+//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Router			/owners/{owner}/usage [get]
+func makeGetUsageEndpoint(logger log.Logger, s file.FileService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeGetUsageEndpoint", "request", request)
+		req, ok := request.(schemas.GetUsageRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		usage, err := s.GetUsage(ctx, req.OwnerID)
+		if err != nil {
+			return nil, err
+		}
+		return schemas.GetUsageResponse{
+			OwnerID:   usage.OwnerID,
+			UsedBytes: usage.UsedBytes,
+			MaxBytes:  usage.MaxBytes,
+			FileCount: usage.FileCount,
+			MaxFiles:  usage.MaxFiles,
+		}, nil
+	}
+}
+
+// makeGetDuplicatesEndpoint creates an endpoint for finding the duplicate files of an owner
+//
+//	@Summary		Find duplicate files
+//	@Description	Group the files of an owner holding the same content, most reclaimable bytes first.
+//	@Description	Files with a checksum are grouped by checksum, the other ones by name and size. Files in the trash are left out.
+//	@Description	Copies sharing a stored object count it once in the reclaimable bytes.
+//	@Tags			files
+//	@Accept			json
+//	@Produce		json
+//	@Param			owner	path		string	true	"Owner ID"
+//	@Param			limit	query		int		false	"Maximum number of groups returned"
+//	@Success		200		{object}	schemas.GetDuplicatesResponse
+//	@Failure		400		{object}	schemas.ErrorResponse
+//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Router			/owners/{owner}/duplicates [get]
+func makeGetDuplicatesEndpoint(logger log.Logger, s file.FileService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeGetDuplicatesEndpoint", "request", request)
+		req, ok := request.(schemas.GetDuplicatesRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		dups, err := s.GetDuplicates(ctx, req.OwnerID, req.Limit)
+		if err != nil {
+			return nil, err
+		}
+		groups := make([]schemas.DuplicateGroup, len(dups.Groups))
+		for i, g := range dups.Groups {
+			files := make([]schemas.GetFileByIDResponse, len(g.Files))
+			for j, f := range g.Files {
+				files[j] = makeFileResponse(f)
+			}
+			groups[i] = schemas.DuplicateGroup{
+				ChecksumAlgorithm: g.ChecksumAlgorithm,
+				Checksum:          g.Checksum,
+				Name:              g.Name,
+				Size:              g.Size,
+				ReclaimableBytes:  g.ReclaimableBytes,
+				Files:             files,
+			}
+		}
+		return schemas.GetDuplicatesResponse{
+			GroupCount:       dups.GroupCount,
+			ReclaimableBytes: dups.ReclaimableBytes,
+			Groups:           groups,
+		}, nil
+	}
+}
+
+// makeDeleteFileEndpoint creates an endpoint for deleting a file
+//
+//	@Summary		Delete a file
+//	@Description	Move a file to the trash by its ID
+//	@Tags			files
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"File ID"
+//	@Param			If-Match	header		string	false	"ETag of the revision the file must be at"
+//	@Success		200			{object}	schemas.DeleteFileResponse
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		404			{object}	schemas.ErrorResponse
+//	@Failure		412			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
 //	@Router			/files/{id} [delete]
 func makeDeleteFileEndpoint(logger log.Logger, s file.FileService) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
@@ -173,7 +543,7 @@ func makeDeleteFileEndpoint(logger log.Logger, s file.FileService) endpoint.Endp
 		if !ok {
 			return nil, errors.New("invalid request type")
 		}
-		ok, err := s.DeleteFile(ctx, req.ID)
+		ok, err := s.DeleteFile(ctx, req.ID, req.Revision)
 		return schemas.DeleteFileResponse{Ok: ok}, err
 	}
 }
@@ -9,7 +9,6 @@ import (
 	modelerr "remy_explorer/internal/explorer/err"
 	"remy_explorer/internal/explorer/handler/http/schemas"
 	"remy_explorer/internal/explorer/model"
-	"remy_explorer/internal/explorer/service/file"
 	"remy_explorer/internal/explorer/service/folder"
 )
 
@@ -20,10 +19,12 @@ import (
 //	@Tags			folders
 //	@Accept			json
 //	@Produce		json
-//	@Param			body	body		schemas.CreateFolderRequest	true	"Create Folder Request"
-//	@Success		200		{object}	schemas.CreateFolderResponse
-//	@Failure		400		{object}	schemas.ErrorResponse
-//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Param			body		body		schemas.CreateFolderRequest	true	"Create Folder Request"
+//	@Param			on_conflict	query		string						false	"Policy applied when the name is taken in the parent folder"	Enums(fail, rename)
+//	@Success		200			{object}	schemas.CreateFolderResponse
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		409			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
 //	@Router			/folders [post]
 func makeCreateFolderEndpoint(logger log.Logger, s folder.FolderService) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
@@ -37,8 +38,15 @@ func makeCreateFolderEndpoint(logger log.Logger, s folder.FolderService) endpoin
 			OwnerID:  req.OwnerID,
 			ParentID: req.ParentID,
 		}
-		id, err := s.CreateFolder(ctx, &f)
-		return schemas.CreateFolderResponse{ID: *id}, err
+		policy, err := model.ParseConflictPolicy(req.OnConflict)
+		if err != nil {
+			return nil, err
+		}
+		id, err := s.CreateFolder(ctx, &f, policy)
+		if err != nil {
+			return nil, err
+		}
+		return schemas.CreateFolderResponse{ID: *id}, nil
 	}
 }
 
@@ -51,6 +59,7 @@ func makeCreateFolderEndpoint(logger log.Logger, s folder.FolderService) endpoin
 //	@Produce		json
 //	@Param			id	path		string	true	"Folder ID"
 //	@Success		200	{object}	schemas.GetFolderByIDResponse
+//	@Header			200	{string}	ETag	"Entity tag of the revision of the folder, to send in If-Match"
 //	@Failure		404	{object}	schemas.ErrorResponse
 //	@Failure		500	{object}	schemas.ErrorResponse
 //	@Router			/folders/{id} [get]
@@ -70,26 +79,24 @@ func makeGetFolderByIDEndpoint(logger log.Logger, s folder.FolderService) endpoi
 			level.Error(logger).Log("err", err, "msg", "failed to retrieve file")
 			return nil, err
 		}
-		return schemas.GetFolderByIDResponse{
-			ID:        f.ID,
-			OwnerID:   f.OwnerID,
-			Name:      f.Name,
-			ParentID:  f.ParentID,
-			CreatedAt: f.CreatedAt.String(),
-			UpdatedAt: f.UpdatedAt.String(),
-		}, err
+		return makeFolderResponse(f), nil
 	}
 }
 
 // makeGetFoldersByParentIDEndpoint creates an endpoint for getting folders by parent ID
 //
 //	@Summary		Get folders by parent ID
-//	@Description	Retrieve a list of folders within a specific parent folder
+//	@Description	Retrieve a page of the folders within a specific parent folder
 //	@Tags			folders
 //	@Accept			json
 //	@Produce		json
 //	@Param			parentID	path		string	true	"Parent Folder ID"
+//	@Param			sort		query		string	false	"Field to sort by"	Enums(id, name, created_at, updated_at)	default(id)
+//	@Param			order		query		string	false	"Sort order"		Enums(ASC, DESC)						default(ASC)
+//	@Param			limit		query		int		false	"Page size (1-1000)"	default(50)
+//	@Param			cursor		query		string	false	"Cursor returned with the previous page"
 //	@Success		200			{object}	schemas.GetFoldersByParentIDResponse
+//	@Failure		400			{object}	schemas.ErrorResponse
 //	@Failure		404			{object}	schemas.ErrorResponse
 //	@Failure		500			{object}	schemas.ErrorResponse
 //	@Router			/folders/{parentID}/subfolders [get]
@@ -101,7 +108,12 @@ func makeGetFoldersByParentIDEndpoint(logger log.Logger, s folder.FolderService)
 			return nil, errors.New("invalid request type")
 		}
 
-		folders, err := s.GetFoldersByParentID(ctx, req.ParentID)
+		folders, next, err := s.GetFoldersByParentID(ctx, req.ParentID, model.ListOptions{
+			Sort:   req.Sort,
+			Order:  req.Order,
+			Limit:  req.Limit,
+			Cursor: req.Cursor,
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -109,29 +121,126 @@ func makeGetFoldersByParentIDEndpoint(logger log.Logger, s folder.FolderService)
 		res := make([]schemas.ShortFolderInfo, 0, length)
 		for _, f := range folders {
 			res = append(res, schemas.ShortFolderInfo{
-				ID:   f.ID,
-				Name: f.Name,
+				ID:          f.ID,
+				Name:        f.Name,
+				TotalSize:   f.TotalSize,
+				FileCount:   f.FileCount,
+				FolderCount: f.FolderCount,
+				Starred:     f.Starred,
 			})
 		}
 		return schemas.GetFoldersByParentIDResponse{
-			Length:  length,
-			Folders: res,
+			Length:     length,
+			Folders:    res,
+			NextCursor: next,
 		}, nil
 	}
 }
 
-// makeUpdateFolderEndpoint creates an endpoint for updating a folder
+// makeGetFolderPathEndpoint creates an endpoint for getting the breadcrumb path of a folder
 //
-//	@Summary		Update a folder
-//	@Description	Update the details of an existing folder
+//	@Summary		Get folder path
+//	@Description	Retrieve the ancestors of a folder ordered from the root, ending with the folder itself
 //	@Tags			folders
 //	@Accept			json
 //	@Produce		json
-//	@Param			body	body		schemas.UpdateFolderRequest	true	"Update Folder Request"
-//	@Success		200		{object}	schemas.UpdateFolderResponse
+//	@Param			id	path		string	true	"Folder ID"
+//	@Success		200	{object}	schemas.GetPathResponse
+//	@Failure		404	{object}	schemas.ErrorResponse
+//	@Failure		500	{object}	schemas.ErrorResponse
+//	@Router			/folders/{id}/path [get]
+func makeGetFolderPathEndpoint(logger log.Logger, s folder.FolderService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeGetFolderPathEndpoint", "request", request)
+		req, ok := request.(schemas.GetFolderPathRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		path, err := s.GetFolderPath(ctx, req.ID)
+		if err != nil {
+			return nil, err
+		}
+		return makePathResponse(path), nil
+	}
+}
+
+// makePathResponse converts a chain of folders into a breadcrumb response.
+func makePathResponse(path []*model.Folder) schemas.GetPathResponse {
+	items := make([]schemas.PathItem, 0, len(path))
+	for _, f := range path {
+		items = append(items, schemas.PathItem{
+			ID:   f.ID,
+			Name: f.Name,
+		})
+	}
+	return schemas.GetPathResponse{
+		Length: len(items),
+		Path:   items,
+	}
+}
+
+// makeGetFolderTreeEndpoint creates an endpoint for getting a folder subtree
+//
+//	@Summary		Get folder tree
+//	@Description	Retrieve a folder with its subfolders nested up to the given depth.
+//	@Description	Every node carries its direct subfolder and file counts, so deeper levels can be loaded lazily.
+//	@Tags			folders
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Folder ID"
+//	@Param			depth	query		int		false	"Levels below the folder to include (0-10)"	default(1)
+//	@Success		200		{object}	schemas.GetFolderTreeResponse
 //	@Failure		400		{object}	schemas.ErrorResponse
 //	@Failure		404		{object}	schemas.ErrorResponse
 //	@Failure		500		{object}	schemas.ErrorResponse
+//	@Router			/folders/{id}/tree [get]
+func makeGetFolderTreeEndpoint(logger log.Logger, s folder.FolderService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeGetFolderTreeEndpoint", "request", request)
+		req, ok := request.(schemas.GetFolderTreeRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		root, err := s.GetFolderTree(ctx, req.ID, req.Depth)
+		if err != nil {
+			return nil, err
+		}
+		return schemas.GetFolderTreeResponse{Tree: makeFolderTreeNode(root)}, nil
+	}
+}
+
+// makeFolderTreeNode converts a folder tree into its response representation.
+func makeFolderTreeNode(n *model.FolderNode) schemas.FolderTreeNode {
+	children := make([]schemas.FolderTreeNode, 0, len(n.Children))
+	for _, c := range n.Children {
+		children = append(children, makeFolderTreeNode(c))
+	}
+	return schemas.FolderTreeNode{
+		ID:          n.ID,
+		Name:        n.Name,
+		HasChildren: n.HasChildren(),
+		FolderCount: n.FolderCount,
+		FileCount:   n.FileCount,
+		Children:    children,
+	}
+}
+
+// makeUpdateFolderEndpoint creates an endpoint for updating a folder
+//
+//	@Summary		Update a folder
+//	@Description	Update the details of an existing folder
+//	@Tags			folders
+//	@Accept			json
+//	@Produce		json
+//	@Param			body		body		schemas.UpdateFolderRequest	true	"Update Folder Request"
+//	@Param			If-Match	header		string						false	"ETag of the revision the folder must be at"
+//	@Success		200			{object}	schemas.UpdateFolderResponse
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		404			{object}	schemas.ErrorResponse
+//	@Failure		409			{object}	schemas.ErrorResponse
+//	@Failure		412			{object}	schemas.ErrorResponse
+//	@Failure		422			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
 //	@Router			/folders [put]
 func makeUpdateFolderEndpoint(logger log.Logger, s folder.FolderService) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
@@ -144,23 +253,172 @@ func makeUpdateFolderEndpoint(logger log.Logger, s folder.FolderService) endpoin
 			ID:       req.ID,
 			Name:     req.Name,
 			ParentID: req.ParentID,
+			Revision: req.Revision,
 		}
 		err := s.UpdateFolder(ctx, &f)
 		return schemas.UpdateFolderResponse{Ok: err == nil}, err
 	}
 }
 
+// makePatchFolderEndpoint creates an endpoint for a partial update of a folder
+//
+//	@Summary		Patch a folder
+//	@Description	Apply a JSON Merge Patch to a folder: only the members present in the body are changed.
+//	@Description	A new parent_id moves the folder and must pass the same checks as a move. Returns the updated folder.
+//	@Tags			folders
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string						true	"Folder ID"
+//	@Param			body		body		schemas.PatchFolderRequest	true	"Members of the folder to change"
+//	@Param			If-Match	header		string						false	"ETag of the revision the folder must be at"
+//	@Success		200			{object}	schemas.GetFolderByIDResponse
+//	@Header			200			{string}	ETag	"Entity tag of the new revision of the folder"
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		404			{object}	schemas.ErrorResponse
+//	@Failure		409			{object}	schemas.ErrorResponse
+//	@Failure		412			{object}	schemas.ErrorResponse
+//	@Failure		422			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Router			/folders/{id} [patch]
+func makePatchFolderEndpoint(logger log.Logger, s folder.FolderService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering  makePatchFolderEndpoint", "request", request)
+		req, ok := request.(schemas.PatchFolderRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		f, err := s.PatchFolder(ctx, &model.FolderPatch{
+			ID:       req.ID,
+			Name:     req.Name,
+			ParentID: req.ParentID,
+			Revision: req.Revision,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return makeFolderResponse(f), nil
+	}
+}
+
+// makeFolderResponse converts a folder to its representation in responses, with the entity tag of its revision.
+func makeFolderResponse(f *model.Folder) schemas.GetFolderByIDResponse {
+	return schemas.GetFolderByIDResponse{
+		ID:          f.ID,
+		OwnerID:     f.OwnerID,
+		Name:        f.Name,
+		ParentID:    f.ParentID,
+		CreatedAt:   f.CreatedAt.String(),
+		UpdatedAt:   f.UpdatedAt.String(),
+		TotalSize:   f.TotalSize,
+		FileCount:   f.FileCount,
+		FolderCount: f.FolderCount,
+		ETag:        etag(f.Revision),
+		Starred:     f.Starred,
+	}
+}
+
+// makeMoveFolderEndpoint creates an endpoint for moving a folder
+//
+//	@Summary		Move a folder
+//	@Description	Move a folder under a new parent of the same owner.
+//	@Description	A folder cannot be moved into itself or one of its descendants.
+//	@Tags			folders
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string						true	"Folder ID"
+//	@Param			body		body		schemas.MoveFolderRequest	true	"Move Folder Request"
+//	@Param			on_conflict	query		string						false	"Policy applied when the name is taken in the new parent"	Enums(fail, rename)
+//	@Param			If-Match	header		string						false	"ETag of the revision the folder must be at"
+//	@Success		200			{object}	schemas.MoveFolderResponse
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		404			{object}	schemas.ErrorResponse
+//	@Failure		409			{object}	schemas.ErrorResponse
+//	@Failure		412			{object}	schemas.ErrorResponse
+//	@Failure		422			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Router			/folders/{id}/move [post]
+func makeMoveFolderEndpoint(logger log.Logger, s folder.FolderService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering  makeMoveFolderEndpoint", "request", request)
+		req, ok := request.(schemas.MoveFolderRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		policy, err := model.ParseConflictPolicy(req.OnConflict)
+		if err != nil {
+			return nil, err
+		}
+		err = s.MoveFolder(ctx, req.ID, req.ParentID, policy, req.Revision)
+		return schemas.MoveFolderResponse{Ok: err == nil}, err
+	}
+}
+
+// makeCopyFolderEndpoint creates an endpoint for copying a folder with its subtree
+//
+//	@Summary		Copy a folder
+//	@Description	Copy a folder with all the subfolders and files below it under a parent of the same owner, in one transaction.
+//	@Description	Items in the trash are not copied. The copied files share the stored objects of the original ones.
+//	@Description	The response maps the ID of every copied item to the ID of its copy.
+//	@Tags			folders
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string						true	"Folder ID"
+//	@Param			body		body		schemas.CopyFolderRequest	true	"Copy Folder Request"
+//	@Param			on_conflict	query		string						false	"Policy applied when the name is taken in the parent"	Enums(fail, rename)
+//	@Success		200			{object}	schemas.CopyResponse
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		404			{object}	schemas.ErrorResponse
+//	@Failure		409			{object}	schemas.ErrorResponse
+//	@Failure		422			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Failure		507			{object}	schemas.ErrorResponse
+//	@Router			/folders/{id}/copy [post]
+func makeCopyFolderEndpoint(logger log.Logger, s folder.FolderService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeCopyFolderEndpoint", "request", request)
+		req, ok := request.(schemas.CopyFolderRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		policy, err := model.ParseConflictPolicy(req.OnConflict)
+		if err != nil {
+			return nil, err
+		}
+		items, err := s.CopyFolder(ctx, req.ID, req.ParentID, policy)
+		if err != nil {
+			return nil, err
+		}
+		resp := schemas.CopyResponse{Ok: true, Folders: map[string]string{}, Files: map[string]string{}}
+		for _, item := range items {
+			if item.Kind == model.KindFile {
+				resp.Files[item.SourceID] = item.ID
+			} else {
+				resp.Folders[item.SourceID] = item.ID
+			}
+		}
+		resp.ID = resp.Folders[req.ID]
+		return resp, nil
+	}
+}
+
 // makeDeleteFolderEndpoint creates an endpoint for deleting a folder
 //
 //	@Summary		Delete a folder
-//	@Description	Delete a folder by its ID
+//	@Description	Move a folder to the trash by its ID. A folder with content can only be deleted recursively,
+//	@Description	which moves the whole subtree to the trash, to be restored or purged as a whole.
+//	@Description	The root folder of an owner cannot be deleted.
 //	@Tags			folders
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		string	true	"Folder ID"
-//	@Success		200	{object}	schemas.DeleteFolderResponse
-//	@Failure		404	{object}	schemas.ErrorResponse
-//	@Failure		500	{object}	schemas.ErrorResponse
+//	@Param			id			path		string	true	"Folder ID"
+//	@Param			recursive	query		bool	false	"Delete subfolders and files as well"
+//	@Param			If-Match	header		string	false	"ETag of the revision the folder must be at"
+//	@Success		200			{object}	schemas.DeleteFolderResponse
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		404			{object}	schemas.ErrorResponse
+//	@Failure		409			{object}	schemas.ErrorResponse
+//	@Failure		412			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
 //	@Router			/folders/{id} [delete]
 func makeDeleteFolderEndpoint(logger log.Logger, s folder.FolderService) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
@@ -169,24 +427,35 @@ func makeDeleteFolderEndpoint(logger log.Logger, s folder.FolderService) endpoin
 		if !ok {
 			return nil, errors.New("invalid request type")
 		}
-		err := s.DeleteFolder(ctx, req.ID)
+		var err error
+		if req.Recursive {
+			err = s.DeleteFolderRecursive(ctx, req.ID, req.Revision)
+		} else {
+			err = s.DeleteFolder(ctx, req.ID, req.Revision)
+		}
 		return schemas.DeleteFolderResponse{Ok: err == nil}, err
 	}
 }
 
-// makeGetFolderContent creates an endpoint for deleting a folder
+// makeGetFolderContent creates an endpoint for getting the content of a folder
 //
 //	@Summary		Get folder content
-//	@Description	Get files and folders inside folder
+//	@Description	Get a page of the files and folders inside a folder.
+//	@Description	Subfolders always come first, each group is sorted by the requested field; folders sort as empty by size.
 //	@Tags			folders
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		string	true	"Folder ID"
-//	@Success		200	{object}	schemas.GetFolderContentResponse
-//	@Failure		404	{object}	schemas.ErrorResponse
-//	@Failure		500	{object}	schemas.ErrorResponse
+//	@Param			id		path		string	true	"Folder ID"
+//	@Param			sort	query		string	false	"Field to sort by"	Enums(id, name, size, created_at, updated_at)	default(id)
+//	@Param			order	query		string	false	"Sort order"		Enums(ASC, DESC)								default(ASC)
+//	@Param			limit	query		int		false	"Page size (1-1000)"	default(50)
+//	@Param			cursor	query		string	false	"Cursor returned with the previous page"
+//	@Success		200		{object}	schemas.GetFolderContentResponse
+//	@Failure		400		{object}	schemas.ErrorResponse
+//	@Failure		404		{object}	schemas.ErrorResponse
+//	@Failure		500		{object}	schemas.ErrorResponse
 //	@Router			/folders/{id}/content [get]
-func makeGetFolderContentEndpoint(logger log.Logger, s folder.FolderService, s2 file.FileService) endpoint.Endpoint {
+func makeGetFolderContentEndpoint(logger log.Logger, s folder.FolderService) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		level.Info(logger).Log("msg", "entering makeGetFolderContentEndpoint", "request", request)
 		req, ok := request.(schemas.GetFolderContentRequest)
@@ -194,34 +463,115 @@ func makeGetFolderContentEndpoint(logger log.Logger, s folder.FolderService, s2
 			return nil, errors.New("invalid request type")
 		}
 
-		folderModels, err := s.GetFoldersByParentID(ctx, req.FolderID)
-		fileModels, err := s2.GetFilesByFolderID(ctx, req.FolderID)
+		items, next, err := s.GetFolderContent(ctx, req.FolderID, model.ListOptions{
+			Sort:   req.Sort,
+			Order:  req.Order,
+			Limit:  req.Limit,
+			Cursor: req.Cursor,
+		})
 		if err != nil {
 			return nil, err
 		}
-		folderLength := len(folderModels)
-		folders := make([]schemas.ShortFolderInfo, 0, folderLength)
-		for _, f := range folderModels {
-			folders = append(folders, schemas.ShortFolderInfo{
-				ID:   f.ID,
-				Name: f.Name,
-			})
+		return makeFolderContentResponse(items, next), nil
+	}
+}
+
+// makeGetRootFolderEndpoint creates an endpoint for getting the root folder of an owner
+//
+//	@Summary		Get root folder
+//	@Description	Retrieve the root folder of an owner, which holds all its top-level folders and files.
+//	@Description	The root folder is created on first use.
+//	@Tags			folders
+//	@Accept			json
+//	@Produce		json
+//	@Param			owner	path		string	true	"Owner ID"
+//	@Success		200		{object}	schemas.GetFolderByIDResponse
+//	@Failure		400		{object}	schemas.ErrorResponse
+//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Router			/owners/{owner}/root [get]
+func makeGetRootFolderEndpoint(logger log.Logger, s folder.FolderService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeGetRootFolderEndpoint", "request", request)
+		req, ok := request.(schemas.GetRootFolderRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		f, err := s.GetRootFolder(ctx, req.OwnerID)
+		if err != nil {
+			return nil, err
 		}
+		return makeFolderResponse(f), nil
+	}
+}
 
-		fileLength := len(fileModels)
-		files := make([]schemas.ShortFileInfo, 0, fileLength)
-		for _, f := range fileModels {
-			files = append(files, schemas.ShortFileInfo{
-				ID:   f.ID,
-				Name: f.Name,
-				Type: f.Type,
-			})
+// makeGetRootContentEndpoint creates an endpoint for listing the top-level items of an owner
+//
+//	@Summary		Get root folder content
+//	@Description	Get a page of the top-level files and folders of an owner, i.e. the content of its root folder.
+//	@Description	Subfolders always come first, each group is sorted by the requested field; folders sort as empty by size.
+//	@Tags			folders
+//	@Accept			json
+//	@Produce		json
+//	@Param			owner	path		string	true	"Owner ID"
+//	@Param			sort	query		string	false	"Field to sort by"	Enums(id, name, size, created_at, updated_at)	default(id)
+//	@Param			order	query		string	false	"Sort order"		Enums(ASC, DESC)								default(ASC)
+//	@Param			limit	query		int		false	"Page size (1-1000)"	default(50)
+//	@Param			cursor	query		string	false	"Cursor returned with the previous page"
+//	@Success		200		{object}	schemas.GetFolderContentResponse
+//	@Failure		400		{object}	schemas.ErrorResponse
+//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Router			/owners/{owner}/root/content [get]
+func makeGetRootContentEndpoint(logger log.Logger, s folder.FolderService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeGetRootContentEndpoint", "request", request)
+		req, ok := request.(schemas.GetRootContentRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		root, err := s.GetRootFolder(ctx, req.OwnerID)
+		if err != nil {
+			return nil, err
 		}
+		items, next, err := s.GetFolderContent(ctx, root.ID, model.ListOptions{
+			Sort:   req.Sort,
+			Order:  req.Order,
+			Limit:  req.Limit,
+			Cursor: req.Cursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return makeFolderContentResponse(items, next), nil
+	}
+}
 
-		return schemas.GetFolderContentResponse{
-			Length:  folderLength + fileLength,
-			Folders: folders,
-			Files:   files,
-		}, nil
+// makeFolderContentResponse splits a content page into its folders and files.
+func makeFolderContentResponse(items []*model.ContentItem, next string) schemas.GetFolderContentResponse {
+	folders := make([]schemas.ShortFolderInfo, 0)
+	files := make([]schemas.ShortFileInfo, 0)
+	for _, c := range items {
+		if c.Kind == model.KindFolder {
+			folders = append(folders, schemas.ShortFolderInfo{
+				ID:          c.ID,
+				Name:        c.Name,
+				TotalSize:   c.TotalSize,
+				FileCount:   c.FileCount,
+				FolderCount: c.FolderCount,
+				Starred:     c.Starred,
+			})
+			continue
+		}
+		files = append(files, schemas.ShortFileInfo{
+			ID:      c.ID,
+			Name:    c.Name,
+			Type:    c.Type,
+			Starred: c.Starred,
+		})
+	}
+	return schemas.GetFolderContentResponse{
+		Length:     len(items),
+		Folders:    folders,
+		Files:      files,
+		NextCursor: next,
 	}
 }
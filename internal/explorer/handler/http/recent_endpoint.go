@@ -0,0 +1,91 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"remy_explorer/internal/explorer/handler/http/schemas"
+	"remy_explorer/internal/explorer/model"
+	"remy_explorer/internal/explorer/service/recent"
+)
+
+// makeRecordAccessEndpoint creates an endpoint for recording that a file was opened
+//
+//	@Summary		Record a file access
+//	@Description	Record that a file was opened without reading it, for clients that open files through a cached copy.
+//	@Description	GET /files/{id} records the access as well.
+//	@Tags			recent
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"File ID"
+//	@Success		200	{object}	schemas.RecordAccessResponse
+//	@Failure		400	{object}	schemas.ErrorResponse
+//	@Failure		404	{object}	schemas.ErrorResponse
+//	@Failure		500	{object}	schemas.ErrorResponse
+//	@Router			/files/{id}/access [post]
+func makeRecordAccessEndpoint(logger log.Logger, s recent.RecentService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeRecordAccessEndpoint", "request", request)
+		req, ok := request.(schemas.RecordAccessRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		if err := s.RecordAccess(ctx, req.ID); err != nil {
+			return nil, err
+		}
+		return schemas.RecordAccessResponse{Ok: true}, nil
+	}
+}
+
+// makeGetRecentEndpoint creates an endpoint for getting the recent feed of an owner
+//
+//	@Summary		Get recent files
+//	@Description	Get the files an owner opened last, or with kind=modified the files that changed last, most recent first.
+//	@Description	Each file is listed once, files in the trash are not listed.
+//	@Description	The feed holds at most recent.max_per_owner files, the oldest opened files are forgotten.
+//	@Tags			recent
+//	@Accept			json
+//	@Produce		json
+//	@Param			owner	path		string	true	"Owner ID"
+//	@Param			kind	query		string	false	"Feed to get"	Enums(opened, modified)	default(opened)
+//	@Param			limit	query		int		false	"Maximum number of files (1-1000)"	default(50)
+//	@Success		200		{object}	schemas.GetRecentResponse
+//	@Failure		400		{object}	schemas.ErrorResponse
+//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Router			/owners/{owner}/recent [get]
+func makeGetRecentEndpoint(logger log.Logger, s recent.RecentService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeGetRecentEndpoint", "request", request)
+		req, ok := request.(schemas.GetRecentRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		kind := req.Kind
+		if kind == "" {
+			kind = model.RecentOpened
+		}
+		files, err := s.GetRecent(ctx, req.OwnerID, kind, req.Limit)
+		if err != nil {
+			return nil, err
+		}
+		infos := make([]schemas.RecentFileInfo, len(files))
+		for i, f := range files {
+			infos[i] = schemas.RecentFileInfo{
+				ID:       f.ID,
+				Name:     f.Name,
+				FolderID: f.FolderID,
+				Type:     f.Type,
+				Size:     f.Size,
+				Starred:  f.Starred,
+				At:       f.At.String(),
+			}
+		}
+		return schemas.GetRecentResponse{
+			Kind:   kind,
+			Length: len(infos),
+			Files:  infos,
+		}, nil
+	}
+}
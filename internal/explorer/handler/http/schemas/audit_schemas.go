@@ -0,0 +1,40 @@
+package schemas
+
+import "time"
+
+// GetAuditRequest represents the request to list the audit log
+type GetAuditRequest struct {
+	Kind   string     `json:"kind"`    // Kind of the item, folder or file
+	ItemID string     `json:"item_id"` // ID of the item, requires the kind
+	Actor  string     `json:"actor"`   // Actor who made the changes
+	From   *time.Time `json:"from"`    // Start of the time range of the changes, inclusive
+	To     *time.Time `json:"to"`      // End of the time range of the changes, exclusive
+	Limit  int        `json:"limit"`   // Page size
+	Cursor string     `json:"cursor"`  // Cursor returned with the previous page
+}
+
+// AuditChange represents the value of a field of an item before and after a change
+type AuditChange struct {
+	Before interface{} `json:"before,omitempty"` // Value before the change, absent for a created item
+	After  interface{} `json:"after,omitempty"`  // Value after the change, absent for a deleted item
+}
+
+// AuditRecordInfo represents a change of a folder or file
+type AuditRecordInfo struct {
+	ID        string                 `json:"id"`         // ID of the record
+	Time      string                 `json:"time"`       // Timestamp of the change
+	Actor     string                 `json:"actor"`      // Actor who made the change, empty if the request named none
+	Action    string                 `json:"action"`     // Action, create, update, move or delete
+	Kind      string                 `json:"kind"`       // Kind of the item, folder or file
+	ItemID    string                 `json:"item_id"`    // ID of the item
+	OwnerID   string                 `json:"owner_id"`   // ID of the owner of the item
+	RequestID string                 `json:"request_id"` // ID of the request that made the change
+	Changes   map[string]AuditChange `json:"changes"`    // Changed fields of the item
+}
+
+// GetAuditResponse represents a page of the audit log, newest record first
+type GetAuditResponse struct {
+	Length     int               `json:"length"`      // Number of records in the page
+	Records    []AuditRecordInfo `json:"records"`     // Records in the page
+	NextCursor string            `json:"next_cursor"` // Cursor of the next page, empty on the last one
+}
@@ -0,0 +1,44 @@
+package schemas
+
+// BulkItem identifies a folder or a file of a bulk operation
+type BulkItem struct {
+	Kind string `json:"kind" validate:"required"` // Kind of the item, folder or file
+	ID   string `json:"id" validate:"required"`   // ID of the item
+}
+
+// BulkMoveRequest represents the request to move several items into a folder
+type BulkMoveRequest struct {
+	Items      []BulkItem `json:"items" validate:"required"`     // Items to move
+	FolderID   string     `json:"folder_id" validate:"required"` // ID of the target folder
+	Atomic     bool       `json:"atomic"`                        // Move all items or none
+	OnConflict string     `json:"-"`                             // Policy applied when a name is taken: fail, rename or overwrite
+}
+
+// BulkDeleteRequest represents the request to move several items to the trash
+type BulkDeleteRequest struct {
+	Items     []BulkItem `json:"items" validate:"required"` // Items to delete
+	Recursive bool       `json:"recursive"`                 // Delete folders with their content as well
+	Atomic    bool       `json:"atomic"`                    // Delete all items or none
+}
+
+// BulkTagRequest represents the request to add a tag to several files or remove it
+type BulkTagRequest struct {
+	Items  []BulkItem `json:"items" validate:"required"` // Items to tag or untag, only files can be tagged
+	Tag    string     `json:"tag" validate:"required"`   // Tag to add or remove
+	Atomic bool       `json:"atomic"`                    // Change all items or none
+}
+
+// BulkItemResult represents the outcome of a bulk operation for one item
+type BulkItemResult struct {
+	Kind   string `json:"kind"`            // Kind of the item, folder or file
+	ID     string `json:"id"`              // ID of the item
+	Status string `json:"status"`          // done, failed, rolled_back or skipped
+	Code   int    `json:"code,omitempty"`  // HTTP status code of the failure of a failed item
+	Error  string `json:"error,omitempty"` // Failure of a failed item
+}
+
+// BulkResponse represents the outcome of a bulk operation, item by item in the requested order
+type BulkResponse struct {
+	Ok      bool             `json:"ok"`      // Indicates whether the operation succeeded for every item
+	Results []BulkItemResult `json:"results"` // Outcome of every item
+}
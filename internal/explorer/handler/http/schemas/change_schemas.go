@@ -0,0 +1,28 @@
+package schemas
+
+// GetChangesRequest represents the request to read the change feed of an owner
+type GetChangesRequest struct {
+	OwnerID string `json:"owner_id" validate:"required"` // ID of the owner
+	Limit   int    `json:"limit"`                        // Maximum number of changes
+	Cursor  string `json:"cursor"`                       // Cursor returned with the previous changes, empty to start from the beginning
+}
+
+// ChangeInfo represents a change of a folder or file
+type ChangeInfo struct {
+	Seq      int    `json:"seq"`       // Position of the change in the feed of the owner
+	Action   string `json:"action"`    // Action, create, update, move or delete
+	Kind     string `json:"kind"`      // Kind of the item, folder or file
+	ID       string `json:"id"`        // ID of the item
+	ParentID string `json:"parent_id"` // ID of the folder holding the item after the change, empty for a root folder
+	Name     string `json:"name"`      // Name of the item after the change
+	Revision int    `json:"revision"`  // Revision of the item after the change
+	Time     string `json:"time"`      // Timestamp of the change
+}
+
+// GetChangesResponse represents the changes of an owner after a cursor, oldest first
+type GetChangesResponse struct {
+	Length     int          `json:"length"`      // Number of changes returned
+	Changes    []ChangeInfo `json:"changes"`     // Changes returned
+	NextCursor string       `json:"next_cursor"` // Cursor to ask for the following changes with
+	HasMore    bool         `json:"has_more"`    // Whether more changes are already waiting after the cursor
+}
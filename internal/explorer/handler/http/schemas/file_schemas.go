@@ -1,18 +1,25 @@
 package schemas
 
+import "net/http"
+
 // CreateFileRequest represents the request to create a new file
 type CreateFileRequest struct {
-	Name     string `json:"name" validate:"required"` // Name of the file
-	Type     string `json:"type"`                     // Type of the file
-	FolderID string `json:"folder_id"`                // ID of the parent folder
-	OwnerID  string `json:"owner_id"`                 // ID of the owner
-	Path     string `json:"path"`                     // Path where the file is stored
-	Size     int    `json:"size"`                     // Size of the file
+	Name              string   `json:"name" validate:"required"` // Name of the file
+	Type              string   `json:"type"`                     // Type of the file
+	FolderID          string   `json:"folder_id"`                // ID of the parent folder, the owner's root folder if empty
+	OwnerID           string   `json:"owner_id"`                 // ID of the owner
+	Path              string   `json:"path"`                     // Path where the file is stored
+	Size              int      `json:"size"`                     // Size of the file
+	Tags              []string `json:"tags"`                     // Tags of the file, normalized on save
+	OnConflict        string   `json:"-"`                        // Policy applied when the name is taken: fail, rename or overwrite
+	ChecksumAlgorithm string   `json:"checksum_algorithm"`       // Algorithm of the checksum: sha256 (default), sha1 or md5
+	Checksum          string   `json:"checksum"`                 // Hex encoded checksum of the content, optional
 }
 
 // CreateFileResponse represents the response after creating a new file
 type CreateFileResponse struct {
-	ID string `json:"id"` // ID of the created file
+	ID           string            `json:"id"`            // ID of the created file
+	DeletedFiles []DeletedFileInfo `json:"deleted_files"` // Stored objects of the versions dropped beyond the cap when overwriting
 }
 
 // GetFileByIDRequest represents the request to get a file by its ID
@@ -22,53 +29,202 @@ type GetFileByIDRequest struct {
 
 // GetFileByIDResponse represents the response with the details of a file
 type GetFileByIDResponse struct {
-	ID        string   `json:"id"`         // ID of the file
-	Name      string   `json:"name"`       // Name of the file
-	Type      string   `json:"type"`       // Type of the file
-	Size      int      `json:"size"`       // Size of the file
-	FolderID  string   `json:"folder_id"`  // ID of the parent folder
-	Path      string   `json:"path"`       // Path where the file is stored
-	CreatedAt string   `json:"created_at"` // Timestamp when the file was created
-	UpdatedAt string   `json:"updated_at"` // Timestamp when the file was last updated
-	Tags      []string `json:"tags"`       // Tags associated with the file
+	ID                string   `json:"id"`                 // ID of the file
+	Name              string   `json:"name"`               // Name of the file
+	Type              string   `json:"type"`               // Type of the file
+	Size              int      `json:"size"`               // Size of the file
+	FolderID          string   `json:"folder_id"`          // ID of the parent folder
+	Path              string   `json:"path"`               // Path where the file is stored
+	CreatedAt         string   `json:"created_at"`         // Timestamp when the file was created
+	UpdatedAt         string   `json:"updated_at"`         // Timestamp when the file was last updated
+	Tags              []string `json:"tags"`               // Tags associated with the file
+	ETag              string   `json:"-"`                  // Entity tag of the revision of the file, sent in the ETag header
+	ChecksumAlgorithm string   `json:"checksum_algorithm"` // Algorithm of the checksum, empty if unknown
+	Checksum          string   `json:"checksum"`           // Hex encoded checksum of the content, empty if unknown
+	Starred           bool     `json:"starred"`            // Whether the owner starred the file
+}
+
+// Headers returns the ETag header of the file.
+func (r GetFileByIDResponse) Headers() http.Header {
+	return http.Header{"ETag": {r.ETag}}
+}
+
+// PatchFileResponse represents the response with the details of a patched file
+type PatchFileResponse struct {
+	GetFileByIDResponse
+	DeletedFiles []DeletedFileInfo `json:"deleted_files"` // Stored objects of the versions dropped beyond the cap
 }
 
 // GetFilesByFolderIDRequest represents the request to get files by folder ID
 type GetFilesByFolderIDRequest struct {
 	FolderID string `json:"folder_id" validate:"required"` // ID of the parent folder
+	Sort     string `json:"sort"`                          // Field to sort by
+	Order    string `json:"order"`                         // Sort order, ASC or DESC
+	Limit    int    `json:"limit"`                         // Page size
+	Cursor   string `json:"cursor"`                        // Cursor returned with the previous page
 }
 
 // ShortFileInfo represents a short version of file information
 type ShortFileInfo struct {
-	ID   string `json:"id"`   // ID of the file
-	Name string `json:"name"` // Name of the file
-	Type string `json:"type"` // Type of the file
+	ID      string `json:"id"`      // ID of the file
+	Name    string `json:"name"`    // Name of the file
+	Type    string `json:"type"`    // Type of the file
+	Starred bool   `json:"starred"` // Whether the owner starred the file
 }
 
 // GetFilesByFolderIDResponse represents the response with the list of files in a folder
 type GetFilesByFolderIDResponse struct {
-	Length int             `json:"length"` // Number of files
-	Files  []ShortFileInfo `json:"files"`  // List of files
+	Length     int             `json:"length"`      // Number of files in the page
+	Files      []ShortFileInfo `json:"files"`       // List of files
+	NextCursor string          `json:"next_cursor"` // Cursor of the next page, empty on the last one
+}
+
+// GetFilePathRequest represents the request to get the breadcrumb path of a file
+type GetFilePathRequest struct {
+	ID string `json:"id" validate:"required"` // ID of the file
+}
+
+// MoveFileRequest represents the request to move a file into another folder
+type MoveFileRequest struct {
+	ID         string `json:"-"`                             // ID of the file to move
+	FolderID   string `json:"folder_id" validate:"required"` // ID of the target folder
+	OnConflict string `json:"-"`                             // Policy applied when the name is taken: fail, rename or overwrite
+	Revision   int    `json:"-"`                             // Revision the file must be at, from If-Match, zero for any
+}
+
+// MoveFileResponse represents the response after moving a file
+type MoveFileResponse struct {
+	Ok bool `json:"ok"` // Indicates whether the move was successful
+}
+
+// CopyFileRequest represents the request to copy a file into a folder
+type CopyFileRequest struct {
+	ID         string `json:"-"`                             // ID of the file to copy
+	FolderID   string `json:"folder_id" validate:"required"` // ID of the target folder
+	OnConflict string `json:"-"`                             // Policy applied when the name is taken: fail, rename or overwrite
+}
+
+// CopyResponse represents the response after copying a file or a folder subtree
+type CopyResponse struct {
+	Ok      bool              `json:"ok"`      // Indicates whether the copy was successful
+	ID      string            `json:"id"`      // ID of the copy of the requested item
+	Folders map[string]string `json:"folders"` // IDs of the copied folders mapped to the IDs of their copies
+	Files   map[string]string `json:"files"`   // IDs of the copied files mapped to the IDs of their copies
 }
 
 // UpdateFileRequest represents the request to update a file
 type UpdateFileRequest struct {
-	ID       string `json:"id" validate:"required"`   // ID of the file to update
-	Name     string `json:"name" validate:"required"` // New name of the file
-	FolderID string `json:"folder_id"`                // New parent folder ID
+	ID       string   `json:"id" validate:"required"`   // ID of the file to update
+	Name     string   `json:"name" validate:"required"` // New name of the file
+	FolderID string   `json:"folder_id"`                // New parent folder ID
+	Type     string   `json:"type"`                     // Type of the new content
+	Path     string   `json:"path"`                     // Path where the new content is stored, the current content is kept if empty
+	Size     int      `json:"size"`                     // Size of the new content
+	Tags     []string `json:"tags"`                     // New tags of the file, the current tags are kept if omitted
+	Revision int      `json:"-"`                        // Revision the file must be at, from If-Match, zero for any
+}
+
+// PatchFileRequest represents a JSON Merge Patch of a file, a member left out keeps its current value
+type PatchFileRequest struct {
+	ID                string    `json:"-"`                  // ID of the file to patch
+	Name              *string   `json:"name"`               // New name of the file
+	FolderID          *string   `json:"folder_id"`          // ID of the folder to move the file to
+	Path              *string   `json:"path"`               // Path where the new content is stored, given with its size
+	Size              *int      `json:"size"`               // Size of the new content
+	Type              *string   `json:"type"`               // Type of the new content
+	Tags              *[]string `json:"tags"`               // New tags of the file, null removes all of them
+	Revision          int       `json:"-"`                  // Revision the file must be at, from If-Match, zero for any
+	ChecksumAlgorithm *string   `json:"checksum_algorithm"` // Algorithm of the checksum of the new content, sha256 by default
+	Checksum          *string   `json:"checksum"`           // Checksum of the new content, cleared if left out with a new path
 }
 
 // UpdateFileResponse represents the response after updating a file
 type UpdateFileResponse struct {
-	Ok bool `json:"ok"` // Indicates whether the update was successful
+	Ok           bool              `json:"ok"`            // Indicates whether the update was successful
+	DeletedFiles []DeletedFileInfo `json:"deleted_files"` // Stored objects of the versions dropped beyond the cap
 }
 
 // DeleteFileRequest represents the request to delete a file
 type DeleteFileRequest struct {
-	ID string `json:"id" validate:"required"` // ID of the file to delete
+	ID       string `json:"id" validate:"required"` // ID of the file to delete
+	Revision int    `json:"-"`                      // Revision the file must be at, from If-Match, zero for any
 }
 
 // DeleteFileResponse represents the response after deleting a file
 type DeleteFileResponse struct {
 	Ok bool `json:"ok"` // Indicates whether the deletion was successful
 }
+
+// GetFileVersionsRequest represents the request to get the version history of a file
+type GetFileVersionsRequest struct {
+	ID string `json:"id" validate:"required"` // ID of the file
+}
+
+// FileVersionInfo represents a version of a file content
+type FileVersionInfo struct {
+	Version           int    `json:"version"`            // Number of the version, increasing with every content change
+	Type              string `json:"type"`               // Type of the content
+	Size              int    `json:"size"`               // Size of the content
+	Path              string `json:"path"`               // Path where the content is stored
+	CreatedAt         string `json:"created_at"`         // Timestamp when the version was recorded
+	Current           bool   `json:"current"`            // Whether this is the current content of the file
+	ChecksumAlgorithm string `json:"checksum_algorithm"` // Algorithm of the checksum, empty if unknown
+	Checksum          string `json:"checksum"`           // Hex encoded checksum of the content, empty if unknown
+}
+
+// GetFileVersionsResponse represents the version history of a file, latest first
+type GetFileVersionsResponse struct {
+	Length   int               `json:"length"`   // Number of versions kept
+	Versions []FileVersionInfo `json:"versions"` // Versions of the file
+}
+
+// RestoreFileVersionRequest represents the request to restore a past version of a file
+type RestoreFileVersionRequest struct {
+	ID       string `json:"id" validate:"required"`      // ID of the file
+	Version  int    `json:"version" validate:"required"` // Number of the version to restore
+	Revision int    `json:"-"`                           // Revision the file must be at, from If-Match, zero for any
+}
+
+// RestoreFileVersionResponse represents the response after restoring a version of a file
+type RestoreFileVersionResponse struct {
+	Ok           bool              `json:"ok"`            // Indicates whether the restore was successful
+	Version      int               `json:"version"`       // Number of the version holding the restored content
+	DeletedFiles []DeletedFileInfo `json:"deleted_files"` // Stored objects of the versions dropped beyond the cap
+}
+
+// GetUsageRequest represents the request to get the storage usage of an owner
+type GetUsageRequest struct {
+	OwnerID string `json:"owner_id" validate:"required"` // ID of the owner
+}
+
+// GetUsageResponse represents the storage an owner uses against its quota
+type GetUsageResponse struct {
+	OwnerID   string `json:"owner_id"`   // ID of the owner
+	UsedBytes int    `json:"used_bytes"` // Total size of the stored objects, trash and kept versions included
+	MaxBytes  int    `json:"max_bytes"`  // Allowed total size, zero means unlimited
+	FileCount int    `json:"file_count"` // Number of files, trash included
+	MaxFiles  int    `json:"max_files"`  // Allowed number of files, zero means unlimited
+}
+
+// GetDuplicatesRequest represents the request to find the duplicate files of an owner
+type GetDuplicatesRequest struct {
+	OwnerID string `json:"owner_id" validate:"required"` // ID of the owner
+	Limit   int    `json:"limit"`                        // Maximum number of groups returned
+}
+
+// DuplicateGroup represents files of an owner holding the same content
+type DuplicateGroup struct {
+	ChecksumAlgorithm string                `json:"checksum_algorithm"` // Algorithm of the shared checksum, empty for a group matched by name and size
+	Checksum          string                `json:"checksum"`           // Shared checksum, empty for a group matched by name and size
+	Name              string                `json:"name"`               // Shared name of a group matched by name and size
+	Size              int                   `json:"size"`               // Size of the content
+	ReclaimableBytes  int                   `json:"reclaimable_bytes"`  // Bytes freed by keeping a single file of the group
+	Files             []GetFileByIDResponse `json:"files"`              // Files of the group
+}
+
+// GetDuplicatesResponse represents the duplicate files of an owner, most reclaimable bytes first
+type GetDuplicatesResponse struct {
+	GroupCount       int              `json:"group_count"`       // Number of duplicate groups of the owner
+	ReclaimableBytes int              `json:"reclaimable_bytes"` // Bytes freed by removing all the duplicates of the owner
+	Groups           []DuplicateGroup `json:"groups"`            // Largest groups, up to the limit
+}
@@ -1,10 +1,13 @@
 package schemas
 
+import "net/http"
+
 // CreateFolderRequest represents the request to create a new folder
 type CreateFolderRequest struct {
-	Name     string `json:"name" validate:"required"` // Name of the folder
-	ParentID string `json:"parent_id"`                // ID of the parent folder
-	OwnerID  string `json:"owner_id"`                 // ID of the owner
+	Name       string `json:"name" validate:"required"` // Name of the folder
+	ParentID   string `json:"parent_id"`                // ID of the parent folder, the owner's root folder if empty
+	OwnerID    string `json:"owner_id"`                 // ID of the owner
+	OnConflict string `json:"-"`                        // Policy applied when the name is taken: fail or rename
 }
 
 // CreateFolderResponse represents the response after creating a new folder
@@ -19,28 +22,85 @@ type GetFolderByIDRequest struct {
 
 // GetFolderByIDResponse represents the response with the details of a folder
 type GetFolderByIDResponse struct {
-	ID        string `json:"id"`         // ID of the folder
-	OwnerID   string `json:"owner_id"`   // ID of the owner
-	Name      string `json:"name"`       // Name of the folder
-	ParentID  string `json:"parent_id"`  // ID of the parent folder
-	CreatedAt string `json:"created_at"` // Timestamp when the folder was created
-	UpdatedAt string `json:"updated_at"` // Timestamp when the folder was last updated
+	ID          string `json:"id"`           // ID of the folder
+	OwnerID     string `json:"owner_id"`     // ID of the owner
+	Name        string `json:"name"`         // Name of the folder
+	ParentID    string `json:"parent_id"`    // ID of the parent folder, empty for a root folder
+	CreatedAt   string `json:"created_at"`   // Timestamp when the folder was created
+	UpdatedAt   string `json:"updated_at"`   // Timestamp when the folder was last updated
+	TotalSize   int    `json:"total_size"`   // Total size of the files in the subtree
+	FileCount   int    `json:"file_count"`   // Number of files in the subtree
+	FolderCount int    `json:"folder_count"` // Number of folders in the subtree, the folder itself excluded
+	ETag        string `json:"-"`            // Entity tag of the revision of the folder, sent in the ETag header
+	Starred     bool   `json:"starred"`      // Whether the owner starred the folder
+}
+
+// Headers returns the ETag header of the folder.
+func (r GetFolderByIDResponse) Headers() http.Header {
+	return http.Header{"ETag": {r.ETag}}
 }
 
 // GetFoldersByParentIDRequest represents the request to get folders by parent ID
 type GetFoldersByParentIDRequest struct {
 	ParentID string `json:"parent_id" validate:"required"` // ID of the parent folder
+	Sort     string `json:"sort"`                          // Field to sort by
+	Order    string `json:"order"`                         // Sort order, ASC or DESC
+	Limit    int    `json:"limit"`                         // Page size
+	Cursor   string `json:"cursor"`                        // Cursor returned with the previous page
 }
 
 type ShortFolderInfo struct {
-	ID   string `json:"id"`   // ID of the folder
-	Name string `json:"name"` // Name of the folder
+	ID          string `json:"id"`           // ID of the folder
+	Name        string `json:"name"`         // Name of the folder
+	TotalSize   int    `json:"total_size"`   // Total size of the files in the subtree
+	FileCount   int    `json:"file_count"`   // Number of files in the subtree
+	FolderCount int    `json:"folder_count"` // Number of folders in the subtree, the folder itself excluded
+	Starred     bool   `json:"starred"`      // Whether the owner starred the folder
 }
 
 // GetFoldersByParentIDResponse represents the response with the list of folders within a specific parent folder
 type GetFoldersByParentIDResponse struct {
-	Length  int               `json:"length"`
-	Folders []ShortFolderInfo `json:"folders"`
+	Length     int               `json:"length"`      // Number of folders in the page
+	Folders    []ShortFolderInfo `json:"folders"`     // List of folders
+	NextCursor string            `json:"next_cursor"` // Cursor of the next page, empty on the last one
+}
+
+// GetFolderPathRequest represents the request to get the breadcrumb path of a folder
+type GetFolderPathRequest struct {
+	ID string `json:"id" validate:"required"` // ID of the folder
+}
+
+// PathItem represents a single folder of a breadcrumb path
+type PathItem struct {
+	ID   string `json:"id"`   // ID of the folder
+	Name string `json:"name"` // Name of the folder
+}
+
+// GetPathResponse represents the breadcrumb path of a file or folder ordered from the root
+type GetPathResponse struct {
+	Length int        `json:"length"` // Number of folders in the path
+	Path   []PathItem `json:"path"`   // Folders from the root down
+}
+
+// GetFolderTreeRequest represents the request to get a folder subtree
+type GetFolderTreeRequest struct {
+	ID    string `json:"id" validate:"required"` // ID of the root folder
+	Depth int    `json:"depth"`                  // Number of levels below the root to include
+}
+
+// FolderTreeNode represents a folder of a tree with its direct content counters
+type FolderTreeNode struct {
+	ID          string           `json:"id"`           // ID of the folder
+	Name        string           `json:"name"`         // Name of the folder
+	HasChildren bool             `json:"has_children"` // Whether the folder has subfolders to expand
+	FolderCount int              `json:"folder_count"` // Number of direct subfolders
+	FileCount   int              `json:"file_count"`   // Number of files directly inside
+	Children    []FolderTreeNode `json:"children"`     // Subfolders loaded within the requested depth
+}
+
+// GetFolderTreeResponse represents the response with a nested folder subtree
+type GetFolderTreeResponse struct {
+	Tree FolderTreeNode `json:"tree"` // The requested folder with its loaded subfolders
 }
 
 // UpdateFolderRequest represents the request to update a folder
@@ -48,6 +108,15 @@ type UpdateFolderRequest struct {
 	ID       string `json:"id" validate:"required"`   // ID of the folder to update
 	Name     string `json:"name" validate:"required"` // New name of the folder
 	ParentID string `json:"parent_id"`                // New parent folder ID
+	Revision int    `json:"-"`                        // Revision the folder must be at, from If-Match, zero for any
+}
+
+// PatchFolderRequest represents a JSON Merge Patch of a folder, a member left out keeps its current value
+type PatchFolderRequest struct {
+	ID       string  `json:"-"`         // ID of the folder to patch
+	Name     *string `json:"name"`      // New name of the folder
+	ParentID *string `json:"parent_id"` // ID of the folder to move the folder to
+	Revision int     `json:"-"`         // Revision the folder must be at, from If-Match, zero for any
 }
 
 // UpdateFolderResponse represents the response after updating a folder
@@ -55,9 +124,31 @@ type UpdateFolderResponse struct {
 	Ok bool `json:"ok"` // Indicates whether the update was successful
 }
 
+// MoveFolderRequest represents the request to move a folder under a new parent
+type MoveFolderRequest struct {
+	ID         string `json:"-"`                             // ID of the folder to move
+	ParentID   string `json:"parent_id" validate:"required"` // ID of the new parent folder
+	OnConflict string `json:"-"`                             // Policy applied when the name is taken: fail or rename
+	Revision   int    `json:"-"`                             // Revision the folder must be at, from If-Match, zero for any
+}
+
+// MoveFolderResponse represents the response after moving a folder
+type MoveFolderResponse struct {
+	Ok bool `json:"ok"` // Indicates whether the move was successful
+}
+
+// CopyFolderRequest represents the request to copy a folder with its subtree under a parent
+type CopyFolderRequest struct {
+	ID         string `json:"-"`                             // ID of the folder to copy
+	ParentID   string `json:"parent_id" validate:"required"` // ID of the parent of the copy
+	OnConflict string `json:"-"`                             // Policy applied when the name is taken: fail or rename
+}
+
 // DeleteFolderRequest represents the request to delete a folder
 type DeleteFolderRequest struct {
-	ID string `json:"id" validate:"required"` // ID of the folder to delete
+	ID        string `json:"id" validate:"required"` // ID of the folder to delete
+	Recursive bool   `json:"recursive"`              // Delete subfolders and files as well
+	Revision  int    `json:"-"`                      // Revision the folder must be at, from If-Match, zero for any
 }
 
 // DeleteFolderResponse represents the response after deleting a folder
@@ -67,10 +158,30 @@ type DeleteFolderResponse struct {
 
 type GetFolderContentRequest struct {
 	FolderID string `json:"folder_id"`
+	Sort     string `json:"sort"`   // Field to sort by
+	Order    string `json:"order"`  // Sort order, ASC or DESC
+	Limit    int    `json:"limit"`  // Page size
+	Cursor   string `json:"cursor"` // Cursor returned with the previous page
 }
 
+// GetFolderContentResponse represents a page of a folder content: subfolders come before files
 type GetFolderContentResponse struct {
-	Length  int               `json:"length"`
-	Folders []ShortFolderInfo `json:"folders"`
-	Files   []ShortFileInfo   `json:"files"`
+	Length     int               `json:"length"`      // Number of items in the page
+	Folders    []ShortFolderInfo `json:"folders"`     // Subfolders in the page
+	Files      []ShortFileInfo   `json:"files"`       // Files in the page
+	NextCursor string            `json:"next_cursor"` // Cursor of the next page, empty on the last one
+}
+
+// GetRootFolderRequest represents the request to get the root folder of an owner
+type GetRootFolderRequest struct {
+	OwnerID string `json:"owner_id" validate:"required"` // ID of the owner
+}
+
+// GetRootContentRequest represents the request to list the top-level items of an owner
+type GetRootContentRequest struct {
+	OwnerID string `json:"owner_id" validate:"required"` // ID of the owner
+	Sort    string `json:"sort"`                         // Field to sort by
+	Order   string `json:"order"`                        // Sort order, ASC or DESC
+	Limit   int    `json:"limit"`                        // Page size
+	Cursor  string `json:"cursor"`                       // Cursor returned with the previous page
 }
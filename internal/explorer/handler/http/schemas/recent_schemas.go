@@ -0,0 +1,36 @@
+package schemas
+
+// RecordAccessRequest represents the request to record that a file was opened
+type RecordAccessRequest struct {
+	ID string `json:"id" validate:"required"` // ID of the file
+}
+
+// RecordAccessResponse represents the response after recording that a file was opened
+type RecordAccessResponse struct {
+	Ok bool `json:"ok"` // Indicates whether the access was recorded
+}
+
+// GetRecentRequest represents the request to get the recent feed of an owner
+type GetRecentRequest struct {
+	OwnerID string `json:"owner_id" validate:"required"` // ID of the owner
+	Kind    string `json:"kind"`                         // Feed to get, opened or modified
+	Limit   int    `json:"limit"`                        // Maximum number of files
+}
+
+// RecentFileInfo represents a file of a recent feed
+type RecentFileInfo struct {
+	ID       string `json:"id"`        // ID of the file
+	Name     string `json:"name"`      // Name of the file
+	FolderID string `json:"folder_id"` // ID of the folder holding the file
+	Type     string `json:"type"`      // Type of the file
+	Size     int    `json:"size"`      // Size of the file
+	Starred  bool   `json:"starred"`   // Whether the file is starred
+	At       string `json:"at"`        // Timestamp when the file was last opened or changed
+}
+
+// GetRecentResponse represents the recent feed of an owner, most recent first
+type GetRecentResponse struct {
+	Kind   string           `json:"kind"`   // Feed returned, opened or modified
+	Length int              `json:"length"` // Number of files in the feed
+	Files  []RecentFileInfo `json:"files"`  // Files in the feed
+}
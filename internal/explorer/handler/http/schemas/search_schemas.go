@@ -0,0 +1,42 @@
+package schemas
+
+import "time"
+
+// SearchRequest represents the request to search the folders and files of an owner by name
+type SearchRequest struct {
+	Query         string     `json:"q" validate:"required"`        // Text searched in the names
+	OwnerID       string     `json:"owner_id" validate:"required"` // ID of the owner
+	Kind          string     `json:"kind"`                         // Only search folders or files
+	Type          string     `json:"type"`                         // Type of the files
+	MinSize       *int       `json:"min_size"`                     // Smallest file size, inclusive
+	MaxSize       *int       `json:"max_size"`                     // Largest file size, inclusive
+	CreatedAfter  *time.Time `json:"created_after"`                // Start of the creation date range, inclusive
+	CreatedBefore *time.Time `json:"created_before"`               // End of the creation date range, exclusive
+	UpdatedAfter  *time.Time `json:"updated_after"`                // Start of the update date range, inclusive
+	UpdatedBefore *time.Time `json:"updated_before"`               // End of the update date range, exclusive
+	Tags          []string   `json:"tags"`                         // Tags the files must all have
+	Limit         int        `json:"limit"`                        // Page size
+	Cursor        string     `json:"cursor"`                       // Cursor returned with the previous page
+}
+
+// SearchHitInfo represents a folder or file matching a search
+type SearchHitInfo struct {
+	Kind      string     `json:"kind"`       // Kind of the item, folder or file
+	ID        string     `json:"id"`         // ID of the item
+	Name      string     `json:"name"`       // Name of the item
+	ParentID  string     `json:"parent_id"`  // ID of the folder containing the item
+	Type      string     `json:"type"`       // Type of a file
+	Size      int        `json:"size"`       // Size of a file
+	CreatedAt string     `json:"created_at"` // Timestamp when the item was created
+	UpdatedAt string     `json:"updated_at"` // Timestamp when the item was last updated
+	Tags      []string   `json:"tags"`       // Tags of a file
+	Rank      float64    `json:"rank"`       // Relevance of the hit, higher is better
+	Path      []PathItem `json:"path"`       // Folders containing the item from the root, ending with its parent
+}
+
+// SearchResponse represents a page of search results, most relevant first
+type SearchResponse struct {
+	Length     int             `json:"length"`      // Number of hits in the page
+	Hits       []SearchHitInfo `json:"hits"`        // Folders and files matching the search
+	NextCursor string          `json:"next_cursor"` // Cursor of the next page, empty on the last one
+}
@@ -0,0 +1,38 @@
+package schemas
+
+// StarRequest represents the request to star or unstar a folder or file
+type StarRequest struct {
+	Kind string `json:"kind" validate:"required"` // Kind of the item, folder or file
+	ID   string `json:"id" validate:"required"`   // ID of the item
+}
+
+// StarResponse represents the response after starring or unstarring an item
+type StarResponse struct {
+	Ok      bool `json:"ok"`      // Indicates whether the change was successful
+	Starred bool `json:"starred"` // Whether the item is now starred
+}
+
+// GetStarredRequest represents the request to list the starred items of an owner
+type GetStarredRequest struct {
+	OwnerID string `json:"owner_id" validate:"required"` // ID of the owner
+	Limit   int    `json:"limit"`                        // Page size
+	Cursor  string `json:"cursor"`                       // Cursor returned with the previous page
+}
+
+// StarredItemInfo represents a starred folder or file
+type StarredItemInfo struct {
+	Kind      string `json:"kind"`       // Kind of the item, folder or file
+	ID        string `json:"id"`         // ID of the item
+	Name      string `json:"name"`       // Name of the item
+	ParentID  string `json:"parent_id"`  // ID of the folder holding the item, empty for a root folder
+	Type      string `json:"type"`       // Type of a file
+	Size      int    `json:"size"`       // Size of a file, total size of the subtree of a folder
+	StarredAt string `json:"starred_at"` // Timestamp when the item was starred
+}
+
+// GetStarredResponse represents a page of the starred items of an owner, most recently starred first
+type GetStarredResponse struct {
+	Length     int               `json:"length"`      // Number of items in the page
+	Items      []StarredItemInfo `json:"items"`       // Items in the page
+	NextCursor string            `json:"next_cursor"` // Cursor of the next page, empty on the last one
+}
@@ -0,0 +1,48 @@
+package schemas
+
+// FileTagRequest represents the request to add a tag to a file or remove it
+type FileTagRequest struct {
+	ID       string `json:"id" validate:"required"`  // ID of the file
+	Tag      string `json:"tag" validate:"required"` // Tag to add or remove
+	Revision int    `json:"-"`                       // Revision the file must be at, from If-Match, zero for any
+}
+
+// FileTagsResponse represents the tags of a file after a change
+type FileTagsResponse struct {
+	Ok   bool     `json:"ok"`   // Indicates whether the change was successful
+	Tags []string `json:"tags"` // Tags of the file
+}
+
+// GetTagsRequest represents the request to list the tags of an owner
+type GetTagsRequest struct {
+	OwnerID string `json:"owner_id" validate:"required"` // ID of the owner
+}
+
+// TagInfo represents a tag with the number of files having it
+type TagInfo struct {
+	Tag   string `json:"tag"`   // Normalized tag
+	Count int    `json:"count"` // Number of files of the owner having the tag
+}
+
+// GetTagsResponse represents the tags of an owner, most used first
+type GetTagsResponse struct {
+	Length int       `json:"length"` // Number of tags
+	Tags   []TagInfo `json:"tags"`   // Tags with their usage counts
+}
+
+// GetFilesByTagRequest represents the request to list the files of an owner having a tag
+type GetFilesByTagRequest struct {
+	Tag     string `json:"tag" validate:"required"`      // Tag the files have
+	OwnerID string `json:"owner_id" validate:"required"` // ID of the owner
+	Sort    string `json:"sort"`                         // Field to sort by
+	Order   string `json:"order"`                        // Sort order, ASC or DESC
+	Limit   int    `json:"limit"`                        // Page size
+	Cursor  string `json:"cursor"`                       // Cursor returned with the previous page
+}
+
+// GetFilesByTagResponse represents a page of the files having a tag
+type GetFilesByTagResponse struct {
+	Length     int             `json:"length"`      // Number of files in the page
+	Files      []ShortFileInfo `json:"files"`       // List of files
+	NextCursor string          `json:"next_cursor"` // Cursor of the next page, empty on the last one
+}
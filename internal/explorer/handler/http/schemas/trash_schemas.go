@@ -0,0 +1,50 @@
+package schemas
+
+// GetTrashRequest represents the request to list the trash of an owner
+type GetTrashRequest struct {
+	OwnerID string `json:"owner_id" validate:"required"` // ID of the owner
+	Limit   int    `json:"limit"`                        // Page size
+	Cursor  string `json:"cursor"`                       // Cursor returned with the previous page
+}
+
+// TrashItemInfo represents a folder or file in the trash
+type TrashItemInfo struct {
+	Kind      string `json:"kind"`       // Kind of the item, folder or file
+	ID        string `json:"id"`         // ID of the item
+	Name      string `json:"name"`       // Name of the item
+	ParentID  string `json:"parent_id"`  // ID of the folder the item was deleted from
+	Size      int    `json:"size"`       // Size of a file
+	DeletedAt string `json:"deleted_at"` // Timestamp when the item was moved to the trash
+}
+
+// GetTrashResponse represents a page of the trash, most recently deleted items first
+type GetTrashResponse struct {
+	Length     int             `json:"length"`      // Number of items in the page
+	Items      []TrashItemInfo `json:"items"`       // Items in the page
+	NextCursor string          `json:"next_cursor"` // Cursor of the next page, empty on the last one
+}
+
+// TrashItemRequest represents the request to restore or purge an item of the trash
+type TrashItemRequest struct {
+	ID   string `json:"id" validate:"required"`   // ID of the item
+	Kind string `json:"kind" validate:"required"` // Kind of the item, folder or file
+}
+
+// RestoreTrashItemResponse represents the response after restoring an item of the trash
+type RestoreTrashItemResponse struct {
+	Ok       bool   `json:"ok"`        // Indicates whether the restore was successful
+	ParentID string `json:"parent_id"` // ID of the folder the item was restored to
+	Name     string `json:"name"`      // Name of the restored item, numbered if the original one was taken
+}
+
+// DeletedFileInfo identifies a file deleted for good
+type DeletedFileInfo struct {
+	ID         string `json:"id"`          // ID of the deleted file
+	ObjectPath string `json:"object_path"` // Path of the stored object to purge
+}
+
+// PurgeTrashItemResponse represents the response after purging an item of the trash
+type PurgeTrashItemResponse struct {
+	Ok           bool              `json:"ok"`            // Indicates whether the purge was successful
+	DeletedFiles []DeletedFileInfo `json:"deleted_files"` // Files deleted for good
+}
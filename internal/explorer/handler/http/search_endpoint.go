@@ -0,0 +1,91 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"remy_explorer/internal/explorer/handler/http/schemas"
+	"remy_explorer/internal/explorer/model"
+	"remy_explorer/internal/explorer/service/search"
+)
+
+// makeSearchEndpoint creates an endpoint for searching folders and files by name
+//
+//	@Summary		Search
+//	@Description	Search the folders and files of an owner whose name contains or resembles a text, most relevant first.
+//	@Description	The type, size and tag filters only match files. Items in the trash are not searched.
+//	@Tags			search
+//	@Accept			json
+//	@Produce		json
+//	@Param			q				query		string		true	"Text searched in the names"
+//	@Param			owner_id		query		string		true	"Owner ID"
+//	@Param			kind			query		string		false	"Only search folders or files"	Enums(folder, file)
+//	@Param			type			query		string		false	"Type of the files"
+//	@Param			min_size		query		int			false	"Smallest file size, inclusive"
+//	@Param			max_size		query		int			false	"Largest file size, inclusive"
+//	@Param			created_after	query		string		false	"Start of the creation date range, inclusive (RFC 3339 or YYYY-MM-DD)"
+//	@Param			created_before	query		string		false	"End of the creation date range, exclusive (RFC 3339 or YYYY-MM-DD)"
+//	@Param			updated_after	query		string		false	"Start of the update date range, inclusive (RFC 3339 or YYYY-MM-DD)"
+//	@Param			updated_before	query		string		false	"End of the update date range, exclusive (RFC 3339 or YYYY-MM-DD)"
+//	@Param			tag				query		[]string	false	"Tag the files must have, can be repeated"	collectionFormat(multi)
+//	@Param			limit			query		int			false	"Page size (1-1000)"	default(50)
+//	@Param			cursor			query		string		false	"Cursor returned with the previous page"
+//	@Success		200				{object}	schemas.SearchResponse
+//	@Failure		400				{object}	schemas.ErrorResponse
+//	@Failure		500				{object}	schemas.ErrorResponse
+//	@Router			/search [get]
+func makeSearchEndpoint(logger log.Logger, s search.SearchService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeSearchEndpoint", "request", request)
+		req, ok := request.(schemas.SearchRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		q := model.SearchQuery{
+			OwnerID:       req.OwnerID,
+			Text:          req.Query,
+			Kind:          req.Kind,
+			Type:          req.Type,
+			MinSize:       req.MinSize,
+			MaxSize:       req.MaxSize,
+			CreatedAfter:  req.CreatedAfter,
+			CreatedBefore: req.CreatedBefore,
+			UpdatedAfter:  req.UpdatedAfter,
+			UpdatedBefore: req.UpdatedBefore,
+			Tags:          req.Tags,
+			Limit:         req.Limit,
+			Cursor:        req.Cursor,
+		}
+		hits, next, err := s.Search(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		infos := make([]schemas.SearchHitInfo, len(hits))
+		for i, h := range hits {
+			path := make([]schemas.PathItem, len(h.Path))
+			for j, f := range h.Path {
+				path[j] = schemas.PathItem{ID: f.ID, Name: f.Name}
+			}
+			infos[i] = schemas.SearchHitInfo{
+				Kind:      h.Kind,
+				ID:        h.ID,
+				Name:      h.Name,
+				ParentID:  h.ParentID,
+				Type:      h.Type,
+				Size:      h.Size,
+				CreatedAt: h.CreatedAt.String(),
+				UpdatedAt: h.UpdatedAt.String(),
+				Tags:      h.Tags,
+				Rank:      h.Rank,
+				Path:      path,
+			}
+		}
+		return schemas.SearchResponse{
+			Length:     len(infos),
+			Hits:       infos,
+			NextCursor: next,
+		}, nil
+	}
+}
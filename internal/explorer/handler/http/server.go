@@ -1,7 +1,10 @@
 package http
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"github.com/go-kit/kit/endpoint"
@@ -10,17 +13,24 @@ import (
 	"github.com/go-kit/log/level"
 	"github.com/gorilla/mux"
 	httpSwagger "github.com/swaggo/http-swagger"
+	"io"
 	"net/http"
 	"reflect"
 	_ "remy_explorer/docs"
 	modelerr "remy_explorer/internal/explorer/err"
 	"remy_explorer/internal/explorer/handler/http/schemas"
+	"remy_explorer/internal/explorer/model"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // NewHTTPServer initializes and returns a new HTTP server with all routes defined.
 func NewHTTPServer(logger log.Logger, endpoints Endpoints) http.Handler {
 	r := mux.NewRouter()
 	r.Use(commonMiddleware(logger))
+	r.Use(requestContextMiddleware)
 
 	// Swagger UI
 	r.PathPrefix("/docs/").Handler(httpSwagger.WrapHandler)
@@ -31,6 +41,14 @@ func NewHTTPServer(logger log.Logger, endpoints Endpoints) http.Handler {
 	// Register file and folder routes
 	registerFileRoutes(logger, r, endpoints)
 	registerFolderRoutes(logger, r, endpoints)
+	registerTrashRoutes(logger, r, endpoints)
+	registerTagRoutes(logger, r, endpoints)
+	registerSearchRoutes(logger, r, endpoints)
+	registerBulkRoutes(logger, r, endpoints)
+	registerStarRoutes(logger, r, endpoints)
+	registerRecentRoutes(logger, r, endpoints)
+	registerAuditRoutes(logger, r, endpoints)
+	registerChangeRoutes(logger, r, endpoints)
 
 	return r
 }
@@ -87,6 +105,27 @@ func registerFileRoutes(logger log.Logger, r *mux.Router, endpoints Endpoints) {
 		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
 	))
 
+	r.Methods("GET").Path("/files/{id}/path").Handler(httptransport.NewServer(
+		endpoints.GetFilePath,
+		decodeGetFilePathRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	r.Methods("POST").Path("/files/{id}/move").Handler(httptransport.NewServer(
+		endpoints.MoveFile,
+		decodeMoveFileRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	r.Methods("POST").Path("/files/{id}/copy").Handler(httptransport.NewServer(
+		endpoints.CopyFile,
+		decodeCopyFileRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
 	r.Methods("PUT").Path("/files").Handler(httptransport.NewServer(
 		endpoints.UpdateFile,
 		decodeUpdateFileRequest,
@@ -94,12 +133,47 @@ func registerFileRoutes(logger log.Logger, r *mux.Router, endpoints Endpoints) {
 		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
 	))
 
+	r.Methods("PATCH").Path("/files/{id}").Handler(httptransport.NewServer(
+		endpoints.PatchFile,
+		decodePatchFileRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
 	r.Methods("DELETE").Path("/files/{id}").Handler(httptransport.NewServer(
 		endpoints.DeleteFile,
 		decodeDeleteFileRequest,
 		encodeResponse(logger),
 		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
 	))
+
+	r.Methods("GET").Path("/files/{id}/versions").Handler(httptransport.NewServer(
+		endpoints.GetFileVersions,
+		decodeGetFileVersionsRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	r.Methods("POST").Path("/files/{id}/versions/{v}/restore").Handler(httptransport.NewServer(
+		endpoints.RestoreFileVersion,
+		decodeRestoreFileVersionRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	r.Methods("GET").Path("/owners/{owner}/usage").Handler(httptransport.NewServer(
+		endpoints.GetUsage,
+		decodeGetUsageRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	r.Methods("GET").Path("/owners/{owner}/duplicates").Handler(httptransport.NewServer(
+		endpoints.GetDuplicates,
+		decodeGetDuplicatesRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
 }
 
 func registerFolderRoutes(logger log.Logger, r *mux.Router, endpoints Endpoints) {
@@ -124,6 +198,20 @@ func registerFolderRoutes(logger log.Logger, r *mux.Router, endpoints Endpoints)
 		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
 	))
 
+	r.Methods("GET").Path("/folders/{id}/path").Handler(httptransport.NewServer(
+		endpoints.GetFolderPath,
+		decodeGetFolderPathRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	r.Methods("GET").Path("/folders/{id}/tree").Handler(httptransport.NewServer(
+		endpoints.GetFolderTree,
+		decodeGetFolderTreeRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
 	r.Methods("PUT").Path("/folders").Handler(httptransport.NewServer(
 		endpoints.UpdateFolder,
 		decodeUpdateFolderRequest,
@@ -131,19 +219,238 @@ func registerFolderRoutes(logger log.Logger, r *mux.Router, endpoints Endpoints)
 		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
 	))
 
+	r.Methods("PATCH").Path("/folders/{id}").Handler(httptransport.NewServer(
+		endpoints.PatchFolder,
+		decodePatchFolderRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	r.Methods("POST").Path("/folders/{id}/move").Handler(httptransport.NewServer(
+		endpoints.MoveFolder,
+		decodeMoveFolderRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	r.Methods("POST").Path("/folders/{id}/copy").Handler(httptransport.NewServer(
+		endpoints.CopyFolder,
+		decodeCopyFolderRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
 	r.Methods("DELETE").Path("/folders/{id}").Handler(httptransport.NewServer(
 		endpoints.DeleteFolder,
 		decodeDeleteFolderRequest,
 		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
 	))
 
 	r.Methods("GET").Path("/folders/{id}/content").Handler(httptransport.NewServer(
 		endpoints.GetFolderContent,
 		decodeGetFolderContent,
 		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	r.Methods("GET").Path("/owners/{owner}/root").Handler(httptransport.NewServer(
+		endpoints.GetRootFolder,
+		decodeGetRootFolderRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	r.Methods("GET").Path("/owners/{owner}/root/content").Handler(httptransport.NewServer(
+		endpoints.GetRootContent,
+		decodeGetRootContentRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+}
+
+func registerTrashRoutes(logger log.Logger, r *mux.Router, endpoints Endpoints) {
+	r.Methods("GET").Path("/trash").Handler(httptransport.NewServer(
+		endpoints.GetTrash,
+		decodeGetTrashRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	r.Methods("POST").Path("/trash/{id}/restore").Handler(httptransport.NewServer(
+		endpoints.RestoreTrashItem,
+		decodeTrashItemRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	r.Methods("DELETE").Path("/trash/{id}").Handler(httptransport.NewServer(
+		endpoints.PurgeTrashItem,
+		decodeTrashItemRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+}
+
+func registerTagRoutes(logger log.Logger, r *mux.Router, endpoints Endpoints) {
+	r.Methods("POST").Path("/files/{id}/tags/{tag}").Handler(httptransport.NewServer(
+		endpoints.AddFileTag,
+		decodeFileTagRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	r.Methods("DELETE").Path("/files/{id}/tags/{tag}").Handler(httptransport.NewServer(
+		endpoints.RemoveFileTag,
+		decodeFileTagRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	r.Methods("GET").Path("/tags").Handler(httptransport.NewServer(
+		endpoints.GetTags,
+		decodeGetTagsRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	r.Methods("GET").Path("/tags/{tag}/files").Handler(httptransport.NewServer(
+		endpoints.GetFilesByTag,
+		decodeGetFilesByTagRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+}
+
+func registerSearchRoutes(logger log.Logger, r *mux.Router, endpoints Endpoints) {
+	r.Methods("GET").Path("/search").Handler(httptransport.NewServer(
+		endpoints.Search,
+		decodeSearchRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+}
+
+func registerBulkRoutes(logger log.Logger, r *mux.Router, endpoints Endpoints) {
+	r.Methods("POST").Path("/bulk/move").Handler(httptransport.NewServer(
+		endpoints.BulkMove,
+		decodeBulkMoveRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	r.Methods("POST").Path("/bulk/delete").Handler(httptransport.NewServer(
+		endpoints.BulkDelete,
+		decodeBulkDeleteRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	r.Methods("POST").Path("/bulk/tag").Handler(httptransport.NewServer(
+		endpoints.BulkAddTag,
+		decodeBulkTagRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	r.Methods("POST").Path("/bulk/untag").Handler(httptransport.NewServer(
+		endpoints.BulkRemoveTag,
+		decodeBulkTagRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+}
+
+func registerStarRoutes(logger log.Logger, r *mux.Router, endpoints Endpoints) {
+	r.Methods("PUT").Path("/files/{id}/star").Handler(httptransport.NewServer(
+		endpoints.Star,
+		decodeStarRequest(model.KindFile),
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	r.Methods("DELETE").Path("/files/{id}/star").Handler(httptransport.NewServer(
+		endpoints.Unstar,
+		decodeStarRequest(model.KindFile),
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	r.Methods("PUT").Path("/folders/{id}/star").Handler(httptransport.NewServer(
+		endpoints.Star,
+		decodeStarRequest(model.KindFolder),
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	r.Methods("DELETE").Path("/folders/{id}/star").Handler(httptransport.NewServer(
+		endpoints.Unstar,
+		decodeStarRequest(model.KindFolder),
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	r.Methods("GET").Path("/owners/{owner}/starred").Handler(httptransport.NewServer(
+		endpoints.GetStarred,
+		decodeGetStarredRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+}
+
+func registerRecentRoutes(logger log.Logger, r *mux.Router, endpoints Endpoints) {
+	r.Methods("POST").Path("/files/{id}/access").Handler(httptransport.NewServer(
+		endpoints.RecordAccess,
+		decodeRecordAccessRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+
+	r.Methods("GET").Path("/owners/{owner}/recent").Handler(httptransport.NewServer(
+		endpoints.GetRecent,
+		decodeGetRecentRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
 	))
 }
 
+func registerAuditRoutes(logger log.Logger, r *mux.Router, endpoints Endpoints) {
+	r.Methods("GET").Path("/audit").Handler(httptransport.NewServer(
+		endpoints.GetAudit,
+		decodeGetAuditRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+}
+
+func registerChangeRoutes(logger log.Logger, r *mux.Router, endpoints Endpoints) {
+	r.Methods("GET").Path("/changes").Handler(httptransport.NewServer(
+		endpoints.GetChanges,
+		decodeGetChangesRequest,
+		encodeResponse(logger),
+		httptransport.ServerErrorEncoder(encodeErrorResponse(logger)),
+	))
+}
+
+// requestContextMiddleware binds the actor named by the X-Actor header and the ID of the request to its context,
+// for the audit log. A request without an X-Request-ID header gets a random one, returned in the response.
+func requestContextMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get("X-Request-ID")
+		if id == "" {
+			b := make([]byte, 16)
+			_, _ = rand.Read(b)
+			id = hex.EncodeToString(b)
+		}
+		w.Header().Set("X-Request-ID", id)
+		ctx := model.WithRequestID(r.Context(), id)
+		if actor := r.Header.Get("X-Actor"); actor != "" {
+			ctx = model.WithActor(ctx, actor)
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 // commonMiddleware adds common HTTP headers to all responses.
 func commonMiddleware(logger log.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -171,16 +478,22 @@ func encodeResponse(logger log.Logger) httptransport.EncodeResponseFunc {
 		if err, ok := response.(error); ok {
 			level.Error(logger).Log("msg", "Error received in encoder", "error", err.Error())
 
-			var notFoundErr *modelerr.NotFound
-			if errors.As(err, &notFoundErr) {
-				http.Error(w, err.Error(), http.StatusNotFound)
-				level.Info(logger).Log("msg", "resource not found", "err", err)
-				return nil
+			code := statusCode(err)
+			http.Error(w, err.Error(), code)
+			if code == http.StatusInternalServerError {
+				level.Error(logger).Log("msg", "error processing request", "err", err)
+			} else {
+				level.Info(logger).Log("msg", "request rejected", "code", code, "err", err)
 			}
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			level.Error(logger).Log("msg", "error processing request", "err", err)
 			return nil
 		}
+		if h, ok := response.(httptransport.Headerer); ok {
+			for k, values := range h.Headers() {
+				for _, v := range values {
+					w.Header().Add(k, v)
+				}
+			}
+		}
 		w.WriteHeader(http.StatusOK)
 		return json.NewEncoder(w).Encode(response)
 	}
@@ -190,10 +503,10 @@ func encodeErrorResponse(logger log.Logger) httptransport.ErrorEncoder {
 	return func(ctx context.Context, err error, w http.ResponseWriter) {
 		level.Error(logger).Log("err", err, "msg", "handling error")
 
-		var notFoundErr *modelerr.NotFound
-		if errors.As(err, &notFoundErr) {
-			http.Error(w, err.Error(), http.StatusNotFound)
-			level.Info(logger).Log("msg", "resource not found", "err", err)
+		code := statusCode(err)
+		if code != http.StatusInternalServerError {
+			http.Error(w, err.Error(), code)
+			level.Info(logger).Log("msg", "request rejected", "code", code, "err", err)
 			return
 		}
 
@@ -203,11 +516,43 @@ func encodeErrorResponse(logger log.Logger) httptransport.ErrorEncoder {
 	}
 }
 
+// statusCode maps the typed service errors to HTTP status codes.
+func statusCode(err error) int {
+	var notFoundErr *modelerr.NotFound
+	var notEmptyErr *modelerr.NotEmpty
+	var invalidArgErr *modelerr.InvalidArgument
+	var moveCycleErr *modelerr.MoveCycle
+	var invalidMoveErr *modelerr.InvalidMove
+	var duplicateErr *modelerr.DuplicateError
+	var quotaErr *modelerr.QuotaExceeded
+	var preconditionErr *modelerr.PreconditionFailed
+	switch {
+	case errors.As(err, &notFoundErr):
+		return http.StatusNotFound
+	case errors.As(err, &notEmptyErr):
+		return http.StatusConflict
+	case errors.As(err, &invalidArgErr):
+		return http.StatusBadRequest
+	case errors.As(err, &moveCycleErr):
+		return http.StatusConflict
+	case errors.As(err, &invalidMoveErr):
+		return http.StatusUnprocessableEntity
+	case errors.As(err, &duplicateErr):
+		return http.StatusConflict
+	case errors.As(err, &quotaErr):
+		return http.StatusInsufficientStorage
+	case errors.As(err, &preconditionErr):
+		return http.StatusPreconditionFailed
+	}
+	return http.StatusInternalServerError
+}
+
 func decodeCreateFileRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	var req schemas.CreateFileRequest
 	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
 		return nil, e
 	}
+	req.OnConflict = r.URL.Query().Get("on_conflict")
 	return req, nil
 }
 
@@ -216,6 +561,34 @@ func decodeUpdateFileRequest(_ context.Context, r *http.Request) (interface{}, e
 	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
 		return nil, e
 	}
+	revision, err := decodeIfMatch(r)
+	if err != nil {
+		return nil, err
+	}
+	req.Revision = revision
+	return req, nil
+}
+
+func decodePatchFileRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, errors.New("id is missing in parameters")
+	}
+	var req schemas.PatchFileRequest
+	removed, err := decodeMergePatch(r, &req, "tags")
+	if err != nil {
+		return nil, err
+	}
+	if removed["tags"] {
+		req.Tags = &[]string{}
+	}
+	revision, err := decodeIfMatch(r)
+	if err != nil {
+		return nil, err
+	}
+	req.ID = id
+	req.Revision = revision
 	return req, nil
 }
 
@@ -236,7 +609,67 @@ func decodeGetFilesByParentIDRequest(_ context.Context, r *http.Request) (interf
 	if !ok {
 		return nil, errors.New("parentID is missing in parameters")
 	}
-	return schemas.GetFilesByFolderIDRequest{FolderID: parentID}, nil
+	opts, err := decodeListOptions(r)
+	if err != nil {
+		return nil, err
+	}
+	return schemas.GetFilesByFolderIDRequest{
+		FolderID: parentID,
+		Sort:     opts.Sort,
+		Order:    opts.Order,
+		Limit:    opts.Limit,
+		Cursor:   opts.Cursor,
+	}, nil
+}
+
+func decodeGetFilePathRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, errors.New("id is missing in parameters")
+	}
+	return schemas.GetFilePathRequest{ID: id}, nil
+}
+
+func decodeMoveFileRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, errors.New("id is missing in parameters")
+	}
+	var req schemas.MoveFileRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, &modelerr.InvalidArgument{Name: "body", Reason: e.Error()}
+	}
+	if req.FolderID == "" {
+		return nil, &modelerr.InvalidArgument{Name: "folder_id", Reason: "is required"}
+	}
+	revision, err := decodeIfMatch(r)
+	if err != nil {
+		return nil, err
+	}
+	req.ID = id
+	req.OnConflict = r.URL.Query().Get("on_conflict")
+	req.Revision = revision
+	return req, nil
+}
+
+func decodeCopyFileRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, errors.New("id is missing in parameters")
+	}
+	var req schemas.CopyFileRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, &modelerr.InvalidArgument{Name: "body", Reason: e.Error()}
+	}
+	if req.FolderID == "" {
+		return nil, &modelerr.InvalidArgument{Name: "folder_id", Reason: "is required"}
+	}
+	req.ID = id
+	req.OnConflict = r.URL.Query().Get("on_conflict")
+	return req, nil
 }
 
 func decodeDeleteFileRequest(_ context.Context, r *http.Request) (interface{}, error) {
@@ -245,7 +678,37 @@ func decodeDeleteFileRequest(_ context.Context, r *http.Request) (interface{}, e
 	if !ok {
 		return nil, errors.New("id is missing in parameters")
 	}
-	return schemas.DeleteFileRequest{ID: id}, nil
+	revision, err := decodeIfMatch(r)
+	if err != nil {
+		return nil, err
+	}
+	return schemas.DeleteFileRequest{ID: id, Revision: revision}, nil
+}
+
+func decodeGetFileVersionsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, errors.New("id is missing in parameters")
+	}
+	return schemas.GetFileVersionsRequest{ID: id}, nil
+}
+
+func decodeRestoreFileVersionRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, errors.New("id is missing in parameters")
+	}
+	version, err := strconv.Atoi(vars["v"])
+	if err != nil || version < 1 {
+		return nil, &modelerr.InvalidArgument{Name: "version", Reason: "must be a positive integer"}
+	}
+	revision, err := decodeIfMatch(r)
+	if err != nil {
+		return nil, err
+	}
+	return schemas.RestoreFileVersionRequest{ID: id, Version: version, Revision: revision}, nil
 }
 
 func decodeCreateFolderRequest(_ context.Context, r *http.Request) (interface{}, error) {
@@ -253,6 +716,7 @@ func decodeCreateFolderRequest(_ context.Context, r *http.Request) (interface{},
 	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
 		return nil, e
 	}
+	req.OnConflict = r.URL.Query().Get("on_conflict")
 	return req, nil
 }
 
@@ -271,7 +735,43 @@ func decodeGetFoldersByParentIDRequest(_ context.Context, r *http.Request) (inte
 	if !ok {
 		return nil, errors.New("parentID is missing in parameters")
 	}
-	return schemas.GetFoldersByParentIDRequest{ParentID: parentID}, nil
+	opts, err := decodeListOptions(r)
+	if err != nil {
+		return nil, err
+	}
+	return schemas.GetFoldersByParentIDRequest{
+		ParentID: parentID,
+		Sort:     opts.Sort,
+		Order:    opts.Order,
+		Limit:    opts.Limit,
+		Cursor:   opts.Cursor,
+	}, nil
+}
+
+func decodeGetFolderPathRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, errors.New("id is missing in parameters")
+	}
+	return schemas.GetFolderPathRequest{ID: id}, nil
+}
+
+func decodeGetFolderTreeRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, errors.New("id is missing in parameters")
+	}
+	req := schemas.GetFolderTreeRequest{ID: id, Depth: 1}
+	if v := r.URL.Query().Get("depth"); v != "" {
+		depth, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, &modelerr.InvalidArgument{Name: "depth", Reason: "must be an integer"}
+		}
+		req.Depth = depth
+	}
+	return req, nil
 }
 
 func decodeUpdateFolderRequest(_ context.Context, r *http.Request) (interface{}, error) {
@@ -279,6 +779,71 @@ func decodeUpdateFolderRequest(_ context.Context, r *http.Request) (interface{},
 	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
 		return nil, e
 	}
+	revision, err := decodeIfMatch(r)
+	if err != nil {
+		return nil, err
+	}
+	req.Revision = revision
+	return req, nil
+}
+
+func decodePatchFolderRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, errors.New("id is missing in parameters")
+	}
+	var req schemas.PatchFolderRequest
+	if _, err := decodeMergePatch(r, &req); err != nil {
+		return nil, err
+	}
+	revision, err := decodeIfMatch(r)
+	if err != nil {
+		return nil, err
+	}
+	req.ID = id
+	req.Revision = revision
+	return req, nil
+}
+
+func decodeMoveFolderRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, errors.New("id is missing in parameters")
+	}
+	var req schemas.MoveFolderRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, &modelerr.InvalidArgument{Name: "body", Reason: e.Error()}
+	}
+	if req.ParentID == "" {
+		return nil, &modelerr.InvalidArgument{Name: "parent_id", Reason: "is required"}
+	}
+	revision, err := decodeIfMatch(r)
+	if err != nil {
+		return nil, err
+	}
+	req.ID = id
+	req.OnConflict = r.URL.Query().Get("on_conflict")
+	req.Revision = revision
+	return req, nil
+}
+
+func decodeCopyFolderRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, errors.New("id is missing in parameters")
+	}
+	var req schemas.CopyFolderRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, &modelerr.InvalidArgument{Name: "body", Reason: e.Error()}
+	}
+	if req.ParentID == "" {
+		return nil, &modelerr.InvalidArgument{Name: "parent_id", Reason: "is required"}
+	}
+	req.ID = id
+	req.OnConflict = r.URL.Query().Get("on_conflict")
 	return req, nil
 }
 
@@ -288,7 +853,19 @@ func decodeDeleteFolderRequest(_ context.Context, r *http.Request) (interface{},
 	if !ok {
 		return nil, errors.New("id is missing in parameters")
 	}
-	return schemas.DeleteFolderRequest{ID: id}, nil
+	revision, err := decodeIfMatch(r)
+	if err != nil {
+		return nil, err
+	}
+	req := schemas.DeleteFolderRequest{ID: id, Revision: revision}
+	if v := r.URL.Query().Get("recursive"); v != "" {
+		recursive, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, &modelerr.InvalidArgument{Name: "recursive", Reason: "must be a boolean"}
+		}
+		req.Recursive = recursive
+	}
+	return req, nil
 }
 
 func decodeGetFolderContent(_ context.Context, r *http.Request) (interface{}, error) {
@@ -297,5 +874,404 @@ func decodeGetFolderContent(_ context.Context, r *http.Request) (interface{}, er
 	if !ok {
 		return nil, errors.New("FolderID is missing in parameters")
 	}
-	return schemas.GetFolderContentRequest{FolderID: ID}, nil
+	opts, err := decodeListOptions(r)
+	if err != nil {
+		return nil, err
+	}
+	return schemas.GetFolderContentRequest{
+		FolderID: ID,
+		Sort:     opts.Sort,
+		Order:    opts.Order,
+		Limit:    opts.Limit,
+		Cursor:   opts.Cursor,
+	}, nil
+}
+
+func decodeGetRootFolderRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	owner, ok := vars["owner"]
+	if !ok {
+		return nil, errors.New("owner is missing in parameters")
+	}
+	return schemas.GetRootFolderRequest{OwnerID: owner}, nil
+}
+
+func decodeGetRootContentRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	owner, ok := vars["owner"]
+	if !ok {
+		return nil, errors.New("owner is missing in parameters")
+	}
+	opts, err := decodeListOptions(r)
+	if err != nil {
+		return nil, err
+	}
+	return schemas.GetRootContentRequest{
+		OwnerID: owner,
+		Sort:    opts.Sort,
+		Order:   opts.Order,
+		Limit:   opts.Limit,
+		Cursor:  opts.Cursor,
+	}, nil
+}
+
+func decodeGetTrashRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	owner := r.URL.Query().Get("owner_id")
+	if owner == "" {
+		return nil, &modelerr.InvalidArgument{Name: "owner_id", Reason: "is required"}
+	}
+	opts, err := decodeListOptions(r)
+	if err != nil {
+		return nil, err
+	}
+	return schemas.GetTrashRequest{
+		OwnerID: owner,
+		Limit:   opts.Limit,
+		Cursor:  opts.Cursor,
+	}, nil
+}
+
+func decodeTrashItemRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, errors.New("id is missing in parameters")
+	}
+	return schemas.TrashItemRequest{ID: id, Kind: r.URL.Query().Get("kind")}, nil
+}
+
+func decodeGetUsageRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	owner, ok := vars["owner"]
+	if !ok {
+		return nil, errors.New("owner is missing in parameters")
+	}
+	return schemas.GetUsageRequest{OwnerID: owner}, nil
+}
+
+func decodeGetDuplicatesRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	owner, ok := vars["owner"]
+	if !ok {
+		return nil, errors.New("owner is missing in parameters")
+	}
+	req := schemas.GetDuplicatesRequest{OwnerID: owner}
+	if v := r.URL.Query().Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, &modelerr.InvalidArgument{Name: "limit", Reason: "must be an integer"}
+		}
+		req.Limit = limit
+	}
+	return req, nil
+}
+
+func decodeFileTagRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, errors.New("id is missing in parameters")
+	}
+	revision, err := decodeIfMatch(r)
+	if err != nil {
+		return nil, err
+	}
+	return schemas.FileTagRequest{ID: id, Tag: vars["tag"], Revision: revision}, nil
+}
+
+func decodeGetTagsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	owner := r.URL.Query().Get("owner_id")
+	if owner == "" {
+		return nil, &modelerr.InvalidArgument{Name: "owner_id", Reason: "is required"}
+	}
+	return schemas.GetTagsRequest{OwnerID: owner}, nil
+}
+
+func decodeGetFilesByTagRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	tag, ok := vars["tag"]
+	if !ok {
+		return nil, errors.New("tag is missing in parameters")
+	}
+	owner := r.URL.Query().Get("owner_id")
+	if owner == "" {
+		return nil, &modelerr.InvalidArgument{Name: "owner_id", Reason: "is required"}
+	}
+	opts, err := decodeListOptions(r)
+	if err != nil {
+		return nil, err
+	}
+	return schemas.GetFilesByTagRequest{
+		Tag:     tag,
+		OwnerID: owner,
+		Sort:    opts.Sort,
+		Order:   opts.Order,
+		Limit:   opts.Limit,
+		Cursor:  opts.Cursor,
+	}, nil
+}
+
+func decodeBulkMoveRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req schemas.BulkMoveRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, &modelerr.InvalidArgument{Name: "body", Reason: e.Error()}
+	}
+	if req.FolderID == "" {
+		return nil, &modelerr.InvalidArgument{Name: "folder_id", Reason: "is required"}
+	}
+	req.OnConflict = r.URL.Query().Get("on_conflict")
+	return req, nil
+}
+
+func decodeBulkDeleteRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req schemas.BulkDeleteRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, &modelerr.InvalidArgument{Name: "body", Reason: e.Error()}
+	}
+	return req, nil
+}
+
+func decodeBulkTagRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var req schemas.BulkTagRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, &modelerr.InvalidArgument{Name: "body", Reason: e.Error()}
+	}
+	return req, nil
+}
+
+func decodeSearchRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	q := r.URL.Query()
+	req := schemas.SearchRequest{
+		Query:   q.Get("q"),
+		OwnerID: q.Get("owner_id"),
+		Kind:    q.Get("kind"),
+		Type:    q.Get("type"),
+		Tags:    q["tag"],
+	}
+	if req.Query == "" {
+		return nil, &modelerr.InvalidArgument{Name: "q", Reason: "is required"}
+	}
+	if req.OwnerID == "" {
+		return nil, &modelerr.InvalidArgument{Name: "owner_id", Reason: "is required"}
+	}
+	var err error
+	if req.MinSize, err = decodeIntParam(r, "min_size"); err != nil {
+		return nil, err
+	}
+	if req.MaxSize, err = decodeIntParam(r, "max_size"); err != nil {
+		return nil, err
+	}
+	if req.CreatedAfter, err = decodeDateParam(r, "created_after"); err != nil {
+		return nil, err
+	}
+	if req.CreatedBefore, err = decodeDateParam(r, "created_before"); err != nil {
+		return nil, err
+	}
+	if req.UpdatedAfter, err = decodeDateParam(r, "updated_after"); err != nil {
+		return nil, err
+	}
+	if req.UpdatedBefore, err = decodeDateParam(r, "updated_before"); err != nil {
+		return nil, err
+	}
+	opts, err := decodeListOptions(r)
+	if err != nil {
+		return nil, err
+	}
+	req.Limit = opts.Limit
+	req.Cursor = opts.Cursor
+	return req, nil
+}
+
+// decodeStarRequest returns a decoder of star requests for items of the given kind.
+func decodeStarRequest(kind string) httptransport.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		vars := mux.Vars(r)
+		id, ok := vars["id"]
+		if !ok {
+			return nil, errors.New("id is missing in parameters")
+		}
+		return schemas.StarRequest{Kind: kind, ID: id}, nil
+	}
+}
+
+func decodeGetStarredRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	owner, ok := vars["owner"]
+	if !ok {
+		return nil, errors.New("owner is missing in parameters")
+	}
+	opts, err := decodeListOptions(r)
+	if err != nil {
+		return nil, err
+	}
+	return schemas.GetStarredRequest{
+		OwnerID: owner,
+		Limit:   opts.Limit,
+		Cursor:  opts.Cursor,
+	}, nil
+}
+
+func decodeRecordAccessRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, errors.New("id is missing in parameters")
+	}
+	return schemas.RecordAccessRequest{ID: id}, nil
+}
+
+func decodeGetRecentRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	owner, ok := vars["owner"]
+	if !ok {
+		return nil, errors.New("owner is missing in parameters")
+	}
+	req := schemas.GetRecentRequest{OwnerID: owner, Kind: r.URL.Query().Get("kind")}
+	if v := r.URL.Query().Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, &modelerr.InvalidArgument{Name: "limit", Reason: "must be an integer"}
+		}
+		req.Limit = limit
+	}
+	return req, nil
+}
+
+func decodeGetAuditRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	q := r.URL.Query()
+	opts, err := decodeListOptions(r)
+	if err != nil {
+		return nil, err
+	}
+	req := schemas.GetAuditRequest{
+		Kind:   q.Get("kind"),
+		ItemID: q.Get("item_id"),
+		Actor:  q.Get("actor"),
+		Limit:  opts.Limit,
+		Cursor: opts.Cursor,
+	}
+	if req.From, err = decodeDateParam(r, "from"); err != nil {
+		return nil, err
+	}
+	if req.To, err = decodeDateParam(r, "to"); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func decodeGetChangesRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	owner := r.URL.Query().Get("owner_id")
+	if owner == "" {
+		return nil, &modelerr.InvalidArgument{Name: "owner_id", Reason: "is required"}
+	}
+	opts, err := decodeListOptions(r)
+	if err != nil {
+		return nil, err
+	}
+	return schemas.GetChangesRequest{
+		OwnerID: owner,
+		Limit:   opts.Limit,
+		Cursor:  opts.Cursor,
+	}, nil
+}
+
+// decodeIntParam reads an optional integer query parameter, nil when it is absent.
+func decodeIntParam(r *http.Request, name string) (*int, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, &modelerr.InvalidArgument{Name: name, Reason: "must be an integer"}
+	}
+	return &n, nil
+}
+
+// decodeDateParam reads an optional timestamp query parameter given as RFC 3339 or as a date,
+// which stands for its midnight UTC. It is nil when the parameter is absent.
+func decodeDateParam(r *http.Request, name string) (*time.Time, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		if t, err = time.Parse(time.DateOnly, v); err != nil {
+			return nil, &modelerr.InvalidArgument{Name: name, Reason: "must be an RFC 3339 timestamp or a YYYY-MM-DD date"}
+		}
+	}
+	t = t.UTC()
+	return &t, nil
+}
+
+// decodeListOptions reads the sorting and paging query parameters shared by all listings.
+func decodeListOptions(r *http.Request) (model.ListOptions, error) {
+	q := r.URL.Query()
+	opts := model.ListOptions{
+		Sort:   q.Get("sort"),
+		Order:  q.Get("order"),
+		Cursor: q.Get("cursor"),
+	}
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return opts, &modelerr.InvalidArgument{Name: "limit", Reason: "must be an integer"}
+		}
+		opts.Limit = limit
+	}
+	return opts, nil
+}
+
+// etag returns the entity tag of a revision of a file or a folder, as sent in the ETag header.
+func etag(revision int) string {
+	return `"` + strconv.Itoa(revision) + `"`
+}
+
+// decodeMergePatch decodes a JSON Merge Patch (RFC 7396) body into req, whose pointer fields stay nil for the members left out.
+// Members unknown to req are rejected, so are null members, which remove a value, unless listed in removable.
+// It returns the removable members set to null.
+func decodeMergePatch(r *http.Request, req interface{}, removable ...string) (map[string]bool, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(body, &members); err != nil || members == nil {
+		return nil, &modelerr.InvalidArgument{Name: "body", Reason: "must be a JSON object"}
+	}
+	removed := make(map[string]bool)
+	for name, value := range members {
+		if string(value) != "null" {
+			continue
+		}
+		if !slices.Contains(removable, name) {
+			return nil, &modelerr.InvalidArgument{Name: name, Reason: "cannot be removed"}
+		}
+		removed[name] = true
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(req); err != nil {
+		return nil, &modelerr.InvalidArgument{Name: "body", Reason: err.Error()}
+	}
+	return removed, nil
+}
+
+// decodeIfMatch returns the revision of the entity tag in the If-Match header, see etag.
+// Without the header, or with "*", any revision matches and zero is returned.
+func decodeIfMatch(r *http.Request) (int, error) {
+	v := strings.TrimSpace(r.Header.Get("If-Match"))
+	if v == "" || v == "*" {
+		return 0, nil
+	}
+	invalid := &modelerr.InvalidArgument{Name: "If-Match", Reason: "must be an entity tag returned in ETag"}
+	if len(v) < 3 || v[0] != '"' || v[len(v)-1] != '"' {
+		return 0, invalid
+	}
+	revision, err := strconv.Atoi(v[1 : len(v)-1])
+	if err != nil || revision < 1 {
+		return 0, invalid
+	}
+	return revision, nil
 }
@@ -0,0 +1,117 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"remy_explorer/internal/explorer/handler/http/schemas"
+	"remy_explorer/internal/explorer/model"
+	"remy_explorer/internal/explorer/service/star"
+)
+
+// makeStarEndpoint creates an endpoint for starring a folder or file
+//
+//	@Summary		Star an item
+//	@Description	Star a file, or a folder with PUT /folders/{id}/star, to find it in the starred items of its owner.
+//	@Description	Starring a starred item changes nothing. Items in the trash cannot be starred.
+//	@Tags			stars
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"File or folder ID"
+//	@Success		200	{object}	schemas.StarResponse
+//	@Failure		404	{object}	schemas.ErrorResponse
+//	@Failure		500	{object}	schemas.ErrorResponse
+//	@Router			/files/{id}/star [put]
+//	@Router			/folders/{id}/star [put]
+func makeStarEndpoint(logger log.Logger, s star.StarService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeStarEndpoint", "request", request)
+		req, ok := request.(schemas.StarRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		if err := s.Star(ctx, req.Kind, req.ID); err != nil {
+			return nil, err
+		}
+		return schemas.StarResponse{Ok: true, Starred: true}, nil
+	}
+}
+
+// makeUnstarEndpoint creates an endpoint for removing the star of a folder or file
+//
+//	@Summary		Unstar an item
+//	@Description	Remove the star of a file, or of a folder with DELETE /folders/{id}/star.
+//	@Description	Unstarring an item without a star changes nothing.
+//	@Tags			stars
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"File or folder ID"
+//	@Success		200	{object}	schemas.StarResponse
+//	@Failure		404	{object}	schemas.ErrorResponse
+//	@Failure		500	{object}	schemas.ErrorResponse
+//	@Router			/files/{id}/star [delete]
+//	@Router			/folders/{id}/star [delete]
+func makeUnstarEndpoint(logger log.Logger, s star.StarService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeUnstarEndpoint", "request", request)
+		req, ok := request.(schemas.StarRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		if err := s.Unstar(ctx, req.Kind, req.ID); err != nil {
+			return nil, err
+		}
+		return schemas.StarResponse{Ok: true, Starred: false}, nil
+	}
+}
+
+// makeGetStarredEndpoint creates an endpoint for listing the starred items of an owner
+//
+//	@Summary		Get starred items
+//	@Description	Get a page of the folders and files an owner starred anywhere in its tree, most recently starred first.
+//	@Description	Items in the trash are not listed, they get their star back when restored.
+//	@Tags			stars
+//	@Accept			json
+//	@Produce		json
+//	@Param			owner	path		string	true	"Owner ID"
+//	@Param			limit	query		int		false	"Page size (1-1000)"	default(50)
+//	@Param			cursor	query		string	false	"Cursor returned with the previous page"
+//	@Success		200		{object}	schemas.GetStarredResponse
+//	@Failure		400		{object}	schemas.ErrorResponse
+//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Router			/owners/{owner}/starred [get]
+func makeGetStarredEndpoint(logger log.Logger, s star.StarService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeGetStarredEndpoint", "request", request)
+		req, ok := request.(schemas.GetStarredRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		items, next, err := s.GetStarred(ctx, req.OwnerID, model.ListOptions{
+			Limit:  req.Limit,
+			Cursor: req.Cursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		infos := make([]schemas.StarredItemInfo, len(items))
+		for i, t := range items {
+			infos[i] = schemas.StarredItemInfo{
+				Kind:      t.Kind,
+				ID:        t.ID,
+				Name:      t.Name,
+				ParentID:  t.ParentID,
+				Type:      t.Type,
+				Size:      t.Size,
+				StarredAt: t.StarredAt.String(),
+			}
+		}
+		return schemas.GetStarredResponse{
+			Length:     len(infos),
+			Items:      infos,
+			NextCursor: next,
+		}, nil
+	}
+}
@@ -0,0 +1,156 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"remy_explorer/internal/explorer/handler/http/schemas"
+	"remy_explorer/internal/explorer/model"
+	"remy_explorer/internal/explorer/service/file"
+)
+
+// makeAddFileTagEndpoint creates an endpoint for tagging a file
+//
+//	@Summary		Tag a file
+//	@Description	Add a tag to a file. Tags are trimmed and lower-cased, inner whitespace is collapsed to single spaces.
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"File ID"
+//	@Param			tag			path		string	true	"Tag"
+//	@Param			If-Match	header		string	false	"ETag of the revision the file must be at"
+//	@Success		200			{object}	schemas.FileTagsResponse
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		404			{object}	schemas.ErrorResponse
+//	@Failure		412			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Router			/files/{id}/tags/{tag} [post]
+func makeAddFileTagEndpoint(logger log.Logger, s file.FileService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeAddFileTagEndpoint", "request", request)
+		req, ok := request.(schemas.FileTagRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		tags, err := s.AddTag(ctx, req.ID, req.Tag, req.Revision)
+		if err != nil {
+			return nil, err
+		}
+		return schemas.FileTagsResponse{Ok: true, Tags: tags}, nil
+	}
+}
+
+// makeRemoveFileTagEndpoint creates an endpoint for removing a tag from a file
+//
+//	@Summary		Untag a file
+//	@Description	Remove a tag from a file. Removing a tag the file does not have changes nothing.
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"File ID"
+//	@Param			tag			path		string	true	"Tag"
+//	@Param			If-Match	header		string	false	"ETag of the revision the file must be at"
+//	@Success		200			{object}	schemas.FileTagsResponse
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		404			{object}	schemas.ErrorResponse
+//	@Failure		412			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Router			/files/{id}/tags/{tag} [delete]
+func makeRemoveFileTagEndpoint(logger log.Logger, s file.FileService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeRemoveFileTagEndpoint", "request", request)
+		req, ok := request.(schemas.FileTagRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		tags, err := s.RemoveTag(ctx, req.ID, req.Tag, req.Revision)
+		if err != nil {
+			return nil, err
+		}
+		return schemas.FileTagsResponse{Ok: true, Tags: tags}, nil
+	}
+}
+
+// makeGetTagsEndpoint creates an endpoint for listing the tags of an owner
+//
+//	@Summary		Get tags
+//	@Description	Get the tags used by the files of an owner with the number of files having each, most used first.
+//	@Description	Files in the trash are not counted.
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			owner_id	query		string	true	"Owner ID"
+//	@Success		200			{object}	schemas.GetTagsResponse
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Router			/tags [get]
+func makeGetTagsEndpoint(logger log.Logger, s file.FileService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeGetTagsEndpoint", "request", request)
+		req, ok := request.(schemas.GetTagsRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		usages, err := s.GetTags(ctx, req.OwnerID)
+		if err != nil {
+			return nil, err
+		}
+		tags := make([]schemas.TagInfo, len(usages))
+		for i, u := range usages {
+			tags[i] = schemas.TagInfo{Tag: u.Tag, Count: u.Count}
+		}
+		return schemas.GetTagsResponse{Length: len(tags), Tags: tags}, nil
+	}
+}
+
+// makeGetFilesByTagEndpoint creates an endpoint for listing the files having a tag
+//
+//	@Summary		Get files by tag
+//	@Description	Get a page of the files of an owner having a tag
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			tag			path		string	true	"Tag"
+//	@Param			owner_id	query		string	true	"Owner ID"
+//	@Param			sort		query		string	false	"Field to sort by"	Enums(id, name, size, created_at, updated_at)	default(id)
+//	@Param			order		query		string	false	"Sort order"		Enums(ASC, DESC)								default(ASC)
+//	@Param			limit		query		int		false	"Page size (1-1000)"	default(50)
+//	@Param			cursor		query		string	false	"Cursor returned with the previous page"
+//	@Success		200			{object}	schemas.GetFilesByTagResponse
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Router			/tags/{tag}/files [get]
+func makeGetFilesByTagEndpoint(logger log.Logger, s file.FileService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeGetFilesByTagEndpoint", "request", request)
+		req, ok := request.(schemas.GetFilesByTagRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		files, next, err := s.GetFilesByTag(ctx, req.OwnerID, req.Tag, model.ListOptions{
+			Sort:   req.Sort,
+			Order:  req.Order,
+			Limit:  req.Limit,
+			Cursor: req.Cursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		shortFiles := make([]schemas.ShortFileInfo, len(files))
+		for i, f := range files {
+			shortFiles[i] = schemas.ShortFileInfo{
+				ID:      f.ID,
+				Name:    f.Name,
+				Type:    f.Type,
+				Starred: f.Starred,
+			}
+		}
+		return schemas.GetFilesByTagResponse{
+			Length:     len(shortFiles),
+			Files:      shortFiles,
+			NextCursor: next,
+		}, nil
+	}
+}
@@ -0,0 +1,133 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"remy_explorer/internal/explorer/handler/http/schemas"
+	"remy_explorer/internal/explorer/model"
+	"remy_explorer/internal/explorer/service/trash"
+)
+
+// makeGetTrashEndpoint creates an endpoint for listing the trash of an owner
+//
+//	@Summary		Get trash
+//	@Description	Get a page of the folders and files an owner deleted, most recently deleted first.
+//	@Description	Items deleted together with a folder are not listed on their own.
+//	@Tags			trash
+//	@Accept			json
+//	@Produce		json
+//	@Param			owner_id	query		string	true	"Owner ID"
+//	@Param			limit		query		int		false	"Page size (1-1000)"	default(50)
+//	@Param			cursor		query		string	false	"Cursor returned with the previous page"
+//	@Success		200			{object}	schemas.GetTrashResponse
+//	@Failure		400			{object}	schemas.ErrorResponse
+//	@Failure		500			{object}	schemas.ErrorResponse
+//	@Router			/trash [get]
+func makeGetTrashEndpoint(logger log.Logger, s trash.TrashService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeGetTrashEndpoint", "request", request)
+		req, ok := request.(schemas.GetTrashRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		items, next, err := s.GetTrash(ctx, req.OwnerID, model.ListOptions{
+			Limit:  req.Limit,
+			Cursor: req.Cursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		infos := make([]schemas.TrashItemInfo, len(items))
+		for i, t := range items {
+			infos[i] = schemas.TrashItemInfo{
+				Kind:      t.Kind,
+				ID:        t.ID,
+				Name:      t.Name,
+				ParentID:  t.ParentID,
+				Size:      t.Size,
+				DeletedAt: t.DeletedAt.String(),
+			}
+		}
+		return schemas.GetTrashResponse{
+			Length:     len(infos),
+			Items:      infos,
+			NextCursor: next,
+		}, nil
+	}
+}
+
+// makeRestoreTrashItemEndpoint creates an endpoint for restoring an item of the trash
+//
+//	@Summary		Restore from trash
+//	@Description	Restore a folder or file to the folder it was deleted from, or to the owner's root folder if that one is gone.
+//	@Description	A folder is restored with the items deleted together with it. The item is renamed if its name has been taken.
+//	@Tags			trash
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Item ID"
+//	@Param			kind	query		string	true	"Item kind"	Enums(folder, file)
+//	@Success		200		{object}	schemas.RestoreTrashItemResponse
+//	@Failure		400		{object}	schemas.ErrorResponse
+//	@Failure		404		{object}	schemas.ErrorResponse
+//	@Failure		409		{object}	schemas.ErrorResponse
+//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Router			/trash/{id}/restore [post]
+func makeRestoreTrashItemEndpoint(logger log.Logger, s trash.TrashService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makeRestoreTrashItemEndpoint", "request", request)
+		req, ok := request.(schemas.TrashItemRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		item, err := s.Restore(ctx, req.Kind, req.ID)
+		if err != nil {
+			return nil, err
+		}
+		return schemas.RestoreTrashItemResponse{Ok: true, ParentID: item.ParentID, Name: item.Name}, nil
+	}
+}
+
+// makePurgeTrashItemEndpoint creates an endpoint for deleting an item of the trash for good
+//
+//	@Summary		Purge from trash
+//	@Description	Delete a folder or file of the trash for good, a folder with its whole subtree.
+//	@Description	The deleted files are listed for object cleanup.
+//	@Tags			trash
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Item ID"
+//	@Param			kind	query		string	true	"Item kind"	Enums(folder, file)
+//	@Success		200		{object}	schemas.PurgeTrashItemResponse
+//	@Failure		400		{object}	schemas.ErrorResponse
+//	@Failure		404		{object}	schemas.ErrorResponse
+//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Router			/trash/{id} [delete]
+func makePurgeTrashItemEndpoint(logger log.Logger, s trash.TrashService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		level.Info(logger).Log("msg", "entering makePurgeTrashItemEndpoint", "request", request)
+		req, ok := request.(schemas.TrashItemRequest)
+		if !ok {
+			return nil, errors.New("invalid request type")
+		}
+		deleted, err := s.Purge(ctx, req.Kind, req.ID)
+		if err != nil {
+			return nil, err
+		}
+		return schemas.PurgeTrashItemResponse{Ok: true, DeletedFiles: makeDeletedFilesResponse(deleted)}, nil
+	}
+}
+
+// makeDeletedFilesResponse lists the stored objects of deleted files, which can now be purged.
+func makeDeletedFilesResponse(deleted []*model.DeletedFile) []schemas.DeletedFileInfo {
+	files := make([]schemas.DeletedFileInfo, len(deleted))
+	for i, f := range deleted {
+		files[i] = schemas.DeletedFileInfo{
+			ID:         f.ID,
+			ObjectPath: f.ObjectPath,
+		}
+	}
+	return files
+}
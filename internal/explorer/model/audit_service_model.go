@@ -0,0 +1,118 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"time"
+)
+
+// Actions recorded in the audit log
+const (
+	AuditCreate = "create"
+	AuditUpdate = "update"
+	AuditMove   = "move"
+	AuditDelete = "delete"
+)
+
+// AuditRecord is an entry of the audit log: a change of a folder or file, who made it and in which request.
+type AuditRecord struct {
+	ID        string                 `json:"id"`
+	Time      time.Time              `json:"time"`
+	Actor     string                 `json:"actor"` // Empty if the request named no actor
+	Action    string                 `json:"action"`
+	Kind      string                 `json:"kind"` // KindFolder or KindFile
+	ItemID    string                 `json:"item_id"`
+	OwnerID   string                 `json:"owner"`
+	RequestID string                 `json:"request_id"`
+	Changes   map[string]AuditChange `json:"changes"` // Changed fields of the item by their JSON name
+}
+
+// AuditChange is the value of a field of an item before and after a change, nil when the item did not exist.
+type AuditChange struct {
+	Before any `json:"before,omitempty"`
+	After  any `json:"after,omitempty"`
+}
+
+// AuditFilter selects audit records, an empty field matches any record.
+type AuditFilter struct {
+	Kind   string
+	ItemID string
+	Actor  string
+	From   *time.Time // Inclusive
+	To     *time.Time // Exclusive
+}
+
+// NewAuditRecord describes a change of an item from before to after, given as the domain model of the item.
+// before is nil for a created item and after for a deleted one. The actor and the request ID are taken from ctx.
+func NewAuditRecord(ctx context.Context, action, kind, itemID, ownerID string, before, after any) (*AuditRecord, error) {
+	b, err := auditFields(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := auditFields(after)
+	if err != nil {
+		return nil, err
+	}
+	changes := make(map[string]AuditChange)
+	for name, v := range b {
+		if w, ok := a[name]; !ok || !reflect.DeepEqual(v, w) {
+			changes[name] = AuditChange{Before: v, After: a[name]}
+		}
+	}
+	for name, w := range a {
+		if _, ok := b[name]; !ok {
+			changes[name] = AuditChange{After: w}
+		}
+	}
+	return &AuditRecord{
+		Actor:     ActorFromContext(ctx),
+		Action:    action,
+		Kind:      kind,
+		ItemID:    itemID,
+		OwnerID:   ownerID,
+		RequestID: RequestIDFromContext(ctx),
+		Changes:   changes,
+	}, nil
+}
+
+// auditFields returns the fields of an item by their JSON name, nothing for a nil item.
+func auditFields(item any) (map[string]any, error) {
+	if v := reflect.ValueOf(item); !v.IsValid() || v.Kind() == reflect.Pointer && v.IsNil() {
+		return nil, nil
+	}
+	b, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+type actorKey struct{}
+type requestIDKey struct{}
+
+// WithActor returns a copy of ctx naming who makes the changes done with it.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor set by WithActor, empty if there is none.
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// WithRequestID returns a copy of ctx carrying the ID of the request it serves.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID set by WithRequestID, empty if there is none.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
@@ -0,0 +1,26 @@
+package model
+
+// ItemRef identifies a folder or a file by its kind, KindFolder or KindFile, and ID.
+type ItemRef struct {
+	Kind string `json:"kind"`
+	ID   string `json:"id"`
+}
+
+// Statuses of the items of a bulk operation.
+const (
+	// BulkDone marks an item the operation was applied to.
+	BulkDone = "done"
+	// BulkFailed marks an item the operation failed on.
+	BulkFailed = "failed"
+	// BulkRolledBack marks an item of an atomic operation undone because another item failed.
+	BulkRolledBack = "rolled_back"
+	// BulkSkipped marks an item of an atomic operation not attempted because an earlier item failed.
+	BulkSkipped = "skipped"
+)
+
+// BulkResult is the outcome of a bulk operation for one item.
+type BulkResult struct {
+	ItemRef
+	Status string `json:"status"`
+	Err    error  `json:"-"` // Cause of the failure of a BulkFailed item
+}
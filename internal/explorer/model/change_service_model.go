@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// Change is an entry of the change feed of an owner: a folder or file was created, updated, moved or deleted.
+// Moving an item to the trash deletes it and restoring it creates it again.
+type Change struct {
+	Seq      int       `json:"seq"`    // Position in the feed of the owner, increasing in commit order
+	Action   string    `json:"action"` // AuditCreate, AuditUpdate, AuditMove or AuditDelete
+	Kind     string    `json:"kind"`   // KindFolder or KindFile
+	ItemID   string    `json:"item_id"`
+	ParentID string    `json:"parent"` // Folder holding the item after the change, empty for a root folder
+	Name     string    `json:"name"`
+	Revision int       `json:"revision"`
+	Time     time.Time `json:"time"`
+}
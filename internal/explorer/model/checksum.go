@@ -0,0 +1,42 @@
+package model
+
+import (
+	"encoding/hex"
+	"fmt"
+	modelerr "remy_explorer/internal/explorer/err"
+	"strings"
+)
+
+// DefaultChecksumAlgorithm is the algorithm of a checksum given without one.
+const DefaultChecksumAlgorithm = "sha256"
+
+// checksumSizes maps the supported checksum algorithms to the size of their digest in bytes.
+var checksumSizes = map[string]int{
+	"sha256": 32,
+	"sha1":   20,
+	"md5":    16,
+}
+
+// NormalizeChecksum validates the checksum of a file content, a hex encoded digest, and returns its algorithm
+// and the digest in lower case. An empty checksum means none and yields empty strings.
+func NormalizeChecksum(algorithm, checksum string) (string, string, error) {
+	checksum = strings.ToLower(strings.TrimSpace(checksum))
+	algorithm = strings.ToLower(strings.TrimSpace(algorithm))
+	if checksum == "" {
+		if algorithm != "" {
+			return "", "", &modelerr.InvalidArgument{Name: "checksum", Reason: "is required with checksum_algorithm"}
+		}
+		return "", "", nil
+	}
+	if algorithm == "" {
+		algorithm = DefaultChecksumAlgorithm
+	}
+	size, ok := checksumSizes[algorithm]
+	if !ok {
+		return "", "", &modelerr.InvalidArgument{Name: "checksum_algorithm", Reason: "must be sha256, sha1 or md5"}
+	}
+	if digest, err := hex.DecodeString(checksum); err != nil || len(digest) != size {
+		return "", "", &modelerr.InvalidArgument{Name: "checksum", Reason: fmt.Sprintf("must be a %s digest of %d hex digits", algorithm, 2*size)}
+	}
+	return algorithm, checksum, nil
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"errors"
+	modelerr "remy_explorer/internal/explorer/err"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeChecksum(t *testing.T) {
+	sha256 := strings.Repeat("ab", 32)
+	tests := []struct {
+		algorithm     string
+		checksum      string
+		wantAlgorithm string
+		wantChecksum  string
+		invalid       string // Name of the invalid argument, empty if valid
+	}{
+		{"", "", "", "", ""},
+		{"", sha256, "sha256", sha256, ""},
+		{" SHA256 ", strings.ToUpper(sha256) + " ", "sha256", sha256, ""},
+		{"sha1", strings.Repeat("0f", 20), "sha1", strings.Repeat("0f", 20), ""},
+		{"MD5", strings.Repeat("FF", 16), "md5", strings.Repeat("ff", 16), ""},
+		{"sha256", "", "", "", "checksum"},
+		{"crc32", "deadbeef", "", "", "checksum_algorithm"},
+		{"md5", sha256, "", "", "checksum"},
+		{"sha256", strings.Repeat("zz", 32), "", "", "checksum"},
+		{"sha1", strings.Repeat("a", 39), "", "", "checksum"},
+	}
+	for _, tt := range tests {
+		algorithm, checksum, err := NormalizeChecksum(tt.algorithm, tt.checksum)
+		if tt.invalid != "" {
+			var invalid *modelerr.InvalidArgument
+			if !errors.As(err, &invalid) || invalid.Name != tt.invalid {
+				t.Errorf("NormalizeChecksum(%q, %q) error = %v, want InvalidArgument on %s", tt.algorithm, tt.checksum, err, tt.invalid)
+			}
+			continue
+		}
+		if err != nil || algorithm != tt.wantAlgorithm || checksum != tt.wantChecksum {
+			t.Errorf("NormalizeChecksum(%q, %q) = %q, %q, %v, want %q, %q",
+				tt.algorithm, tt.checksum, algorithm, checksum, err, tt.wantAlgorithm, tt.wantChecksum)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package model
+
+import modelerr "remy_explorer/internal/explorer/err"
+
+// ConflictPolicy tells what to do when an item is created in or moved to a folder
+// that already holds an item with the same name.
+type ConflictPolicy string
+
+const (
+	// ConflictFail rejects the operation with a DuplicateError.
+	ConflictFail ConflictPolicy = "fail"
+	// ConflictRename picks the first free numbered name, e.g. "report (1).pdf".
+	ConflictRename ConflictPolicy = "rename"
+	// ConflictOverwrite replaces the existing file. It is not supported for folders.
+	ConflictOverwrite ConflictPolicy = "overwrite"
+)
+
+// ParseConflictPolicy validates a conflict policy. An empty string selects ConflictFail.
+func ParseConflictPolicy(s string) (ConflictPolicy, error) {
+	switch p := ConflictPolicy(s); p {
+	case "":
+		return ConflictFail, nil
+	case ConflictFail, ConflictRename, ConflictOverwrite:
+		return p, nil
+	}
+	return "", &modelerr.InvalidArgument{Name: "on_conflict", Reason: "must be one of fail, rename, overwrite"}
+}
@@ -1,22 +1,9 @@
-// Package file CREATE TABLE IF NOT EXISTS public.file
-// (
-//
-//	id          BIGSERIAL PRIMARY KEY,
-//	owner_id    BIGINT                  NOT NULL,
-//	name        VARCHAR(255)            NOT NULL,
-//	folder_id   BIGINT                  NOT NULL,
-//	object_path VARCHAR(255)            NOT NULL,
-//	size        INT                     NOT NULL,
-//	type        VARCHAR(255)            NOT NULL,
-//	created_at  TIMESTAMP DEFAULT NOW() NOT NULL,
-//	updated_at  TIMESTAMP DEFAULT NOW() NOT NULL,
-//	tags        JSONB,
-//	CONSTRAINT fk_file_folder_id FOREIGN KEY (folder_id) REFERENCES public.folder (id)
-//
-// );
+// Package model contains the domain entities of the explorer service.
+// Their database schema is defined by the migrations in repository/postgresql/migrations.
 package model
 
 import (
+	modelerr "remy_explorer/internal/explorer/err"
 	"time"
 )
 
@@ -31,4 +18,109 @@ type File struct {
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
 	Tags       []string  `json:"tags"`
+	// Checksum of the content given by the client, hex encoded, empty if unknown, see NormalizeChecksum
+	ChecksumAlgorithm string `json:"checksum_algorithm"`
+	Checksum          string `json:"checksum"`
+	// Starred tells whether the owner starred the file, see StarredItem
+	Starred bool `json:"starred"`
+	// Revision is incremented by every change of the file. A non-zero revision
+	// given to an update must match the current one, see modelerr.PreconditionFailed.
+	Revision int `json:"revision"`
+}
+
+// FilePatch is a partial update of a file: a nil field is left unchanged.
+// A new content is given by ObjectPath together with its Size and, optionally, its Type and its checksum.
+// A non-zero Revision must match the current revision of the file.
+type FilePatch struct {
+	ID         string
+	Name       *string
+	FolderID   *string
+	ObjectPath *string
+	Size       *int
+	Type       *string
+	Tags       *[]string
+	Revision   int
+	// Checksum of the new content, it is cleared when the content changes without one
+	ChecksumAlgorithm *string
+	Checksum          *string
+}
+
+// DeletedFile identifies a stored object of a removed file, which can now be purged.
+// A file with several versions yields one DeletedFile per stored object.
+type DeletedFile struct {
+	ID         string `json:"id"`
+	ObjectPath string `json:"object_path"`
+}
+
+// FileVersion is a content of a file kept in its history. The current content is the latest version.
+type FileVersion struct {
+	FileID     string    `json:"file"`
+	Version    int       `json:"version"`
+	ObjectPath string    `json:"object_path"`
+	Size       int       `json:"size"`
+	Type       string    `json:"type"`
+	CreatedAt  time.Time `json:"created_at"`
+	Current    bool      `json:"current"`
+	// Checksum of the content given by the client, empty if unknown
+	ChecksumAlgorithm string `json:"checksum_algorithm"`
+	Checksum          string `json:"checksum"`
+}
+
+// TagUsage is a tag of an owner with the number of files having it.
+type TagUsage struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+// Quota limits the storage of an owner. A zero limit means unlimited.
+type Quota struct {
+	MaxBytes int `json:"max_bytes"`
+	MaxFiles int `json:"max_files"`
+}
+
+// Usage is the storage an owner uses against its quota.
+// The files in the trash and the kept versions count, as their stored objects still exist.
+type Usage struct {
+	OwnerID   string `json:"owner"`
+	UsedBytes int    `json:"used_bytes"`
+	FileCount int    `json:"file_count"`
+	Quota
+}
+
+// Check rejects adding bytes and files to the storage beyond the quota.
+func (u *Usage) Check(bytes, files int) error {
+	if u.MaxBytes > 0 && u.UsedBytes+bytes > u.MaxBytes {
+		return &modelerr.QuotaExceeded{OwnerID: u.OwnerID, Resource: "bytes", Used: u.UsedBytes, Adding: bytes, Limit: u.MaxBytes}
+	}
+	if u.MaxFiles > 0 && files > 0 && u.FileCount+files > u.MaxFiles {
+		return &modelerr.QuotaExceeded{OwnerID: u.OwnerID, Resource: "files", Used: u.FileCount, Adding: files, Limit: u.MaxFiles}
+	}
+	return nil
+}
+
+// CopiedItem maps an item of a copied subtree to its copy.
+type CopiedItem struct {
+	Kind     string `json:"kind"`
+	SourceID string `json:"source_id"`
+	ID       string `json:"id"`
+}
+
+// DuplicateGroup is a set of files of an owner holding the same content: the files with the same checksum
+// or, for the files without one, with the same name and size.
+type DuplicateGroup struct {
+	ChecksumAlgorithm string `json:"checksum_algorithm"` // Empty for a group matched by name and size
+	Checksum          string `json:"checksum"`
+	Name              string `json:"name"` // Empty for a group matched by checksum
+	Size              int    `json:"size"`
+	// ReclaimableBytes is the storage freed by keeping a single file of the group.
+	// Copies sharing a stored object count it once.
+	ReclaimableBytes int     `json:"reclaimable_bytes"`
+	Files            []*File `json:"files"`
+}
+
+// Duplicates lists the duplicate groups of an owner, most reclaimable bytes first.
+type Duplicates struct {
+	Groups           []*DuplicateGroup `json:"groups"`
+	GroupCount       int               `json:"group_count"`       // Number of groups of the owner, Groups may hold fewer
+	ReclaimableBytes int               `json:"reclaimable_bytes"` // Total over all the groups of the owner
 }
@@ -1,15 +1,3 @@
-// Package folder CREATE TABLE IF NOT EXISTS public.folder
-// (
-//
-//	id         BIGSERIAL PRIMARY KEY,
-//	owner_id   BIGINT                  NOT NULL,
-//	name       VARCHAR(255)            NOT NULL,
-//	parent_id  BIGINT,
-//	created_at TIMESTAMP DEFAULT NOW() NOT NULL,
-//	updated_at TIMESTAMP DEFAULT NOW() NOT NULL,
-//	CONSTRAINT fk_folder_parent_id FOREIGN KEY (parent_id) REFERENCES public.folder (id)
-//
-// );
 package model
 
 import (
@@ -23,4 +11,58 @@ type Folder struct {
 	ParentID  string    `json:"parent"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	// Totals of the subtree outside the trash, the folder itself excluded
+	TotalSize   int `json:"total_size"`
+	FileCount   int `json:"file_count"`
+	FolderCount int `json:"folder_count"`
+	// Starred tells whether the owner starred the folder, see StarredItem
+	Starred bool `json:"starred"`
+	// Revision is incremented by every change of the folder itself. A non-zero revision
+	// given to an update must match the current one, see modelerr.PreconditionFailed.
+	Revision int `json:"revision"`
+}
+
+// FolderPatch is a partial update of a folder: a nil field is left unchanged.
+// A non-zero Revision must match the current revision of the folder.
+type FolderPatch struct {
+	ID       string
+	Name     *string
+	ParentID *string
+	Revision int
+}
+
+// FolderNode is a folder of a folder tree together with its direct content counters.
+type FolderNode struct {
+	ID          string        `json:"id"`
+	Name        string        `json:"name"`
+	FolderCount int           `json:"folder_count"`
+	FileCount   int           `json:"file_count"`
+	Children    []*FolderNode `json:"children"`
+}
+
+// HasChildren reports whether the folder contains subfolders and can be expanded in a tree view.
+func (n *FolderNode) HasChildren() bool {
+	return n.FolderCount > 0
+}
+
+// Kinds of the items listed in a folder content.
+const (
+	KindFolder = "folder"
+	KindFile   = "file"
+)
+
+// ContentItem is a subfolder or a file listed in a folder content.
+type ContentItem struct {
+	Kind      string    `json:"kind"`
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	Size      int       `json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Subtree totals of a folder, zero for a file
+	TotalSize   int  `json:"total_size"`
+	FileCount   int  `json:"file_count"`
+	FolderCount int  `json:"folder_count"`
+	Starred     bool `json:"starred"`
 }
@@ -0,0 +1,9 @@
+package model
+
+// ListOptions selects the order and a page of a listing.
+type ListOptions struct {
+	Sort   string // Field to sort by, empty sorts by id
+	Order  string // ASC or DESC, empty sorts ascending
+	Limit  int    // Page size, 0 selects the default
+	Cursor string // Opaque cursor returned with the previous page, empty for the first page
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+const (
+	// RecentOpened lists the files an owner opened last
+	RecentOpened = "opened"
+	// RecentModified lists the files an owner changed last
+	RecentModified = "modified"
+)
+
+// RecentFile is a file of the recent feed of its owner, with the time it was last opened or changed.
+type RecentFile struct {
+	ID       string    `json:"id"`
+	OwnerID  string    `json:"owner"`
+	Name     string    `json:"name"`
+	FolderID string    `json:"folder"`
+	Type     string    `json:"type"`
+	Size     int       `json:"size"`
+	Starred  bool      `json:"starred"`
+	At       time.Time `json:"at"`
+}
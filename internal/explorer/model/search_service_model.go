@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// SearchQuery selects the folders and files of an owner whose name matches a text.
+// Zero fields do not filter. The type, size and tag filters only match files.
+type SearchQuery struct {
+	OwnerID       string
+	Text          string     // Text searched in the names, case-insensitively
+	Kind          string     // KindFolder or KindFile to search only one kind of item
+	Type          string     // Type of the files
+	MinSize       *int       // Smallest file size, inclusive
+	MaxSize       *int       // Largest file size, inclusive
+	CreatedAfter  *time.Time // Inclusive
+	CreatedBefore *time.Time // Exclusive
+	UpdatedAfter  *time.Time // Inclusive
+	UpdatedBefore *time.Time // Exclusive
+	Tags          []string   // Tags the files must all have
+	Limit         int        // Page size, 0 selects the default
+	Cursor        string     // Opaque cursor returned with the previous page, empty for the first page
+}
+
+// SearchHit is a folder or file matching a search, with its breadcrumb path.
+type SearchHit struct {
+	Kind      string    `json:"kind"` // KindFolder or KindFile
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	ParentID  string    `json:"parent"`
+	Type      string    `json:"type"`
+	Size      int       `json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Tags      []string  `json:"tags"`
+	Rank      float64   `json:"rank"` // Relevance of the hit, higher is better
+	Path      []*Folder `json:"path"` // Folders containing the hit from the root, ending with its parent
+}
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// StarredItem is a folder or file its owner starred to keep it at hand.
+type StarredItem struct {
+	Kind      string    `json:"kind"` // KindFolder or KindFile
+	ID        string    `json:"id"`
+	OwnerID   string    `json:"owner"`
+	Name      string    `json:"name"`
+	ParentID  string    `json:"parent"` // Empty for the root folder
+	Type      string    `json:"type"`
+	Size      int       `json:"size"` // Size of a file, total size of the subtree of a folder
+	StarredAt time.Time `json:"starred_at"`
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"fmt"
+	modelerr "remy_explorer/internal/explorer/err"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxTagLength is the maximum number of characters of a normalized tag.
+const MaxTagLength = 64
+
+// NormalizeTag trims a tag, collapses its inner whitespace to single spaces and lowers its case,
+// so that tags differing only in these respects are the same tag.
+func NormalizeTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.Join(strings.Fields(tag), " "))
+	if tag == "" {
+		return "", &modelerr.InvalidArgument{Name: "tag", Reason: "must not be blank"}
+	}
+	if utf8.RuneCountInString(tag) > MaxTagLength {
+		return "", &modelerr.InvalidArgument{Name: "tag", Reason: fmt.Sprintf("must be at most %d characters long", MaxTagLength)}
+	}
+	return tag, nil
+}
+
+// NormalizeTags normalizes tags and drops the duplicates, keeping the first occurrence of each.
+func NormalizeTags(tags []string) ([]string, error) {
+	res := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		tag, err := NormalizeTag(t)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			res = append(res, tag)
+		}
+	}
+	return res, nil
+}
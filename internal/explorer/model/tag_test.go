@@ -0,0 +1,57 @@
+package model
+
+import (
+	"errors"
+	modelerr "remy_explorer/internal/explorer/err"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	tests := []struct {
+		tag     string
+		want    string
+		invalid bool
+	}{
+		{tag: "Work", want: "work"},
+		{tag: "  to   Do \t later ", want: "to do later"},
+		{tag: "ÉTÉ", want: "été"},
+		{tag: "", invalid: true},
+		{tag: " \t\n", invalid: true},
+		{tag: strings.Repeat("é", MaxTagLength), want: strings.Repeat("é", MaxTagLength)},
+		{tag: strings.Repeat("a", MaxTagLength+1), invalid: true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeTag(tt.tag)
+		if tt.invalid {
+			var invalid *modelerr.InvalidArgument
+			if !errors.As(err, &invalid) {
+				t.Errorf("NormalizeTag(%q) error = %v, want InvalidArgument", tt.tag, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("NormalizeTag(%q) = %q, %v, want %q", tt.tag, got, err, tt.want)
+		}
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	got, err := NormalizeTags([]string{"Work", "home", " WORK ", "to  do", "Home"})
+	if err != nil {
+		t.Fatalf("NormalizeTags() error = %v", err)
+	}
+	if want := []string{"work", "home", "to do"}; !slices.Equal(got, want) {
+		t.Errorf("NormalizeTags() = %q, want %q", got, want)
+	}
+
+	got, err = NormalizeTags(nil)
+	if err != nil || got == nil || len(got) != 0 {
+		t.Errorf("NormalizeTags(nil) = %#v, %v, want an empty slice", got, err)
+	}
+
+	if _, err := NormalizeTags([]string{"ok", " "}); err == nil {
+		t.Error("NormalizeTags() with a blank tag returned no error")
+	}
+}
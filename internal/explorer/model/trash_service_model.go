@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// TrashItem is a deleted folder or file waiting in the trash to be restored or purged.
+// A folder is listed with the items deleted together with it hidden inside.
+type TrashItem struct {
+	Kind      string    `json:"kind"` // KindFolder or KindFile
+	ID        string    `json:"id"`
+	OwnerID   string    `json:"owner"`
+	Name      string    `json:"name"`
+	ParentID  string    `json:"parent"` // Folder the item was deleted from
+	Size      int       `json:"size"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
@@ -0,0 +1,120 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-kit/log"
+	"remy_explorer/internal/explorer/dto"
+	"remy_explorer/internal/explorer/model"
+	"strings"
+)
+
+type auditRepository struct {
+	client Client
+	log    log.Logger
+}
+
+// db returns the connection to run queries on, joining the transaction bound to ctx if there is one.
+func (r auditRepository) db(ctx context.Context) Client {
+	return conn(ctx, r.client)
+}
+
+// auditRow is the JSON form of a record read by jsonb_to_recordset in AddRecords.
+type auditRow struct {
+	Actor     string                       `json:"actor"`
+	Action    string                       `json:"action"`
+	Kind      string                       `json:"item_kind"`
+	ItemID    int                          `json:"item_id"`
+	OwnerID   string                       `json:"owner_id"`
+	RequestID string                       `json:"request_id"`
+	Changes   map[string]model.AuditChange `json:"changes"`
+}
+
+// AddRecords appends records to the audit log in one statement, however many there are.
+func (r auditRepository) AddRecords(ctx context.Context, records ...*dto.AuditRecordDTO) error {
+	if len(records) == 0 {
+		return nil
+	}
+	rows := make([]auditRow, len(records))
+	for i, rec := range records {
+		rows[i] = auditRow{
+			Actor:     rec.Actor.String,
+			Action:    rec.Action,
+			Kind:      rec.Kind,
+			ItemID:    rec.ItemID,
+			OwnerID:   rec.OwnerID,
+			RequestID: rec.RequestID.String,
+			Changes:   rec.Changes,
+		}
+	}
+	b, err := json.Marshal(rows)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit records: %w", err)
+	}
+	q := `INSERT INTO public.audit_log (actor, action, item_kind, item_id, owner_id, request_id, changes)
+SELECT NULLIF(actor, ''), action, item_kind, item_id, owner_id, NULLIF(request_id, ''), changes
+FROM jsonb_to_recordset($1::JSONB) AS r(actor TEXT, action TEXT, item_kind TEXT, item_id BIGINT, owner_id BIGINT, request_id TEXT, changes JSONB)`
+	if _, err := r.db(ctx).Exec(ctx, q, string(b)); err != nil {
+		return sqlError(err)
+	}
+	return nil
+}
+
+// GetRecords retrieves a page of the audit records matching filter, newest first.
+func (r auditRepository) GetRecords(ctx context.Context, filter *model.AuditFilter, page *dto.PageRequest) ([]*dto.AuditRecordDTO, error) {
+	args := []any{page.Fetch()}
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	conds := []string{"TRUE"}
+	if filter.Kind != "" {
+		conds = append(conds, "item_kind = "+arg(filter.Kind))
+	}
+	if filter.ItemID != "" {
+		conds = append(conds, "item_id = "+arg(filter.ItemID)+"::BIGINT")
+	}
+	if filter.Actor != "" {
+		conds = append(conds, "actor = "+arg(filter.Actor))
+	}
+	if filter.From != nil {
+		conds = append(conds, "occurred_at >= "+arg(*filter.From)+"::TIMESTAMP")
+	}
+	if filter.To != nil {
+		conds = append(conds, "occurred_at < "+arg(*filter.To)+"::TIMESTAMP")
+	}
+	if page.After != nil {
+		conds = append(conds, "id < "+arg(page.After.ID))
+	}
+	q := fmt.Sprintf(`SELECT id, occurred_at, actor, action, item_kind, item_id, owner_id, request_id, changes
+FROM public.audit_log
+WHERE %s
+ORDER BY id DESC
+LIMIT $1`, strings.Join(conds, " AND "))
+	rows, err := r.db(ctx).Query(ctx, q, args...)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+	defer rows.Close()
+	records := make([]*dto.AuditRecordDTO, 0)
+	for rows.Next() {
+		var a dto.AuditRecordDTO
+		if err := rows.Scan(&a.ID, &a.OccurredAt, &a.Actor, &a.Action, &a.Kind, &a.ItemID, &a.OwnerID, &a.RequestID, &a.Changes); err != nil {
+			return nil, fmt.Errorf("failed to scan audit record: %w", err)
+		}
+		records = append(records, &a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqlError(err)
+	}
+	return records, nil
+}
+
+// NewAuditRepo creates a new auditRepository.
+func NewAuditRepo(client Client, logger log.Logger) dto.AuditRepository {
+	return auditRepository{
+		client: client,
+		log:    log.With(logger, "auditRepository", "audit"),
+	}
+}
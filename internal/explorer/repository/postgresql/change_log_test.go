@@ -0,0 +1,42 @@
+package postgresql
+
+import "testing"
+
+func TestChangeLogAction(t *testing.T) {
+	ctx, client := testDB(t)
+	tests := []struct {
+		name       string
+		op         string
+		oldDeleted bool
+		newDeleted bool
+		moved      bool
+		changed    bool
+		want       string // Empty when the change is not in the feed
+	}{
+		{"created", "INSERT", false, false, false, true, "create"},
+		{"created in the trash", "INSERT", false, true, false, true, ""},
+		{"deleted", "DELETE", false, false, false, true, "delete"},
+		{"purged from the trash", "DELETE", true, false, false, true, ""},
+		{"moved to the trash", "UPDATE", false, true, false, true, "delete"},
+		{"moved to the trash with its parent", "UPDATE", false, true, true, true, "delete"},
+		{"restored", "UPDATE", true, false, false, true, "create"},
+		{"restored elsewhere", "UPDATE", true, false, true, true, "create"},
+		{"changed in the trash", "UPDATE", true, true, true, true, ""},
+		{"moved", "UPDATE", false, false, true, true, "move"},
+		{"updated", "UPDATE", false, false, false, true, "update"},
+		{"subtree totals only", "UPDATE", false, false, false, false, ""},
+	}
+	for _, tt := range tests {
+		q := `SELECT COALESCE(public.change_log_action($1,
+    CASE WHEN $2 THEN CURRENT_TIMESTAMP::TIMESTAMP END,
+    CASE WHEN $3 THEN CURRENT_TIMESTAMP::TIMESTAMP END,
+    $4, $5), '')`
+		var got string
+		if err := conn(ctx, client).QueryRow(ctx, q, tt.op, tt.oldDeleted, tt.newDeleted, tt.moved, tt.changed).Scan(&got); err != nil {
+			t.Fatalf("%s: change_log_action() error = %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: change_log_action() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kit/log"
+	"remy_explorer/internal/explorer/dto"
+)
+
+type changeRepository struct {
+	client Client
+	log    log.Logger
+}
+
+// db returns the connection to run queries on, joining the transaction bound to ctx if there is one.
+func (r changeRepository) db(ctx context.Context) Client {
+	return conn(ctx, r.client)
+}
+
+// GetChanges retrieves a page of the changes of an owner after the cursor of the page, oldest first.
+func (r changeRepository) GetChanges(ctx context.Context, ownerID string, page *dto.PageRequest) ([]*dto.ChangeDTO, error) {
+	after := 0
+	if page.After != nil {
+		after = page.After.ID
+	}
+	q := `SELECT seq, action, item_kind, item_id, parent_id::TEXT, name, revision, occurred_at
+FROM public.change_log
+WHERE owner_id = $1 AND seq > $2
+ORDER BY seq
+LIMIT $3`
+	rows, err := r.db(ctx).Query(ctx, q, ownerID, after, page.Fetch())
+	if err != nil {
+		return nil, sqlError(err)
+	}
+	defer rows.Close()
+	changes := make([]*dto.ChangeDTO, 0)
+	for rows.Next() {
+		var c dto.ChangeDTO
+		if err := rows.Scan(&c.Seq, &c.Action, &c.Kind, &c.ItemID, &c.ParentID, &c.Name, &c.Revision, &c.OccurredAt); err != nil {
+			return nil, fmt.Errorf("failed to scan change: %w", err)
+		}
+		changes = append(changes, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqlError(err)
+	}
+	return changes, nil
+}
+
+// NewChangeRepo creates a new changeRepository.
+func NewChangeRepo(client Client, logger log.Logger) dto.ChangeRepository {
+	return changeRepository{
+		client: client,
+		log:    log.With(logger, "changeRepository", "change"),
+	}
+}
@@ -9,8 +9,9 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	dto "remy_explorer/internal/explorer/dto"
-	"remy_explorer/internal/explorer/err"
+	modelerr "remy_explorer/internal/explorer/err"
 	"strconv"
+	"strings"
 )
 
 type fileRepository struct {
@@ -18,37 +19,29 @@ type fileRepository struct {
 	logger log.Logger
 }
 
-func (r fileRepository) GetFilesByFolderIdSorted(ctx context.Context, folderID string, sortOption *dto.SortOption) ([]*dto.FileDTO, error) {
-
-	q := fmt.Sprintf("SELECT id, owner_id, name, folder_id, object_path, size, type, created_at, updated_at, tags FROM public.file WHERE folder_id = $1 ORDER BY %s %s", sortOption.Field, sortOption.Order)
-	rows, err := r.client.Query(ctx, q, folderID)
-	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) {
-			pgErr = err.(*pgconn.PgError)
-			newErr := fmt.Errorf("SQL Error: %s, Details: %s, Where: %s, Code: %s, SQLState: %s", pgErr.Message, pgErr.Detail, pgErr.Where, pgErr.Code, pgErr.SQLState())
-			return nil, newErr
-		}
-		return nil, err
-
-	}
-	defer rows.Close()
-	result := make([]*dto.FileDTO, 0)
-	for rows.Next() {
-		var f dto.FileDTO
-		if err := rows.Scan(&f.ID, &f.OwnerID, &f.Name, &f.FolderID, &f.ObjectPath, &f.Size, &f.Type, &f.CreatedAt, &f.UpdatedAt, &f.Tags); err != nil {
-			return nil, err
-		}
-		result = append(result, &f)
-
-	}
-	return result, nil
+// db returns the connection to run queries on, joining the transaction bound to ctx if there is one.
+func (r fileRepository) db(ctx context.Context) Client {
+	return conn(ctx, r.client)
 }
 
-// CreateFile creates a new file in the database.
+// CreateFile creates a new file in the database with its content as the first version.
+// The folder must exist and must not be in the trash.
 func (r fileRepository) CreateFile(ctx context.Context, file *dto.FileDTO) (*string, error) {
-	q := `INSERT INTO public.file (name, folder_id, owner_id, size, type, object_path) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
-	if err := r.client.QueryRow(ctx, q, file.Name, file.FolderID, file.OwnerID, file.Size, file.Type, file.ObjectPath).Scan(&file.ID); err != nil {
+	q := `WITH f AS (
+    INSERT INTO public.file (name, folder_id, owner_id, size, type, object_path, tags, checksum_algorithm, checksum)
+    SELECT $1::VARCHAR, $2::BIGINT, $3::BIGINT, $4::BIGINT, $5::VARCHAR, $6::VARCHAR, $7::JSONB, $8::VARCHAR, $9::VARCHAR WHERE EXISTS (SELECT 1 FROM public.folder WHERE id = $2 AND deleted_at IS NULL)
+    RETURNING id, content_version, object_path, size, type, checksum_algorithm, checksum
+), v AS (
+    INSERT INTO public.file_version (file_id, version, object_path, size, type, checksum_algorithm, checksum) SELECT * FROM f
+)
+SELECT id FROM f`
+	if err := r.db(ctx).QueryRow(ctx, q, file.Name, file.FolderID, file.OwnerID, file.Size, file.Type, file.ObjectPath, file.Tags, file.ChecksumAlgorithm, file.Checksum).Scan(&file.ID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &modelerr.NotFound{ID: strconv.Itoa(file.FolderID)}
+		}
+		if isSQLState(err, uniqueViolation) {
+			return nil, &modelerr.DuplicateError{Name: file.Name, FolderID: strconv.Itoa(file.FolderID)}
+		}
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
 			pgErr = err.(*pgconn.PgError)
@@ -61,14 +54,14 @@ func (r fileRepository) CreateFile(ctx context.Context, file *dto.FileDTO) (*str
 	return &res, nil
 }
 
-// GetFileByID retrieves a file by its ID.
+// GetFileByID retrieves a file by its ID. Files in the trash are not found.
 func (r fileRepository) GetFileByID(ctx context.Context, id string) (*dto.FileDTO, error) {
-	q := `SELECT id, owner_id, name, folder_id, object_path, size, type, created_at, updated_at, tags FROM public.file WHERE id = $1`
+	q := `SELECT id, owner_id, name, folder_id, object_path, size, type, created_at, updated_at, tags, revision, checksum_algorithm, checksum, starred_at IS NOT NULL FROM public.file WHERE id = $1 AND deleted_at IS NULL`
 	var f dto.FileDTO
-	e := r.client.QueryRow(ctx, q, id).Scan(&f.ID, &f.OwnerID, &f.Name, &f.FolderID, &f.ObjectPath, &f.Size, &f.Type, &f.CreatedAt, &f.UpdatedAt, &f.Tags)
+	e := r.db(ctx).QueryRow(ctx, q, id).Scan(&f.ID, &f.OwnerID, &f.Name, &f.FolderID, &f.ObjectPath, &f.Size, &f.Type, &f.CreatedAt, &f.UpdatedAt, &f.Tags, &f.Revision, &f.ChecksumAlgorithm, &f.Checksum, &f.Starred)
 	if e != nil {
 		if errors.Is(e, pgx.ErrNoRows) {
-			return nil, &err.NotFound{ID: id}
+			return nil, &modelerr.NotFound{ID: id}
 		}
 
 		var pgErr *pgconn.PgError
@@ -82,30 +75,51 @@ func (r fileRepository) GetFileByID(ctx context.Context, id string) (*dto.FileDT
 	return &f, nil
 }
 
-// GetFilesByFolderID retrieves all files with a given folder ID.
-func (r fileRepository) GetFilesByFolderID(ctx context.Context, folderID string) ([]*dto.FileDTO, error) {
-	q := `SELECT id, owner_id, name, folder_id, object_path, size, type, created_at, updated_at, tags FROM public.file WHERE folder_id = $1`
-	rows, err := r.client.Query(ctx, q, folderID)
+// GetFilesByFolderID retrieves a page of the files with a given folder ID in the order of the page sort option.
+func (r fileRepository) GetFilesByFolderID(ctx context.Context, folderID string, page *dto.PageRequest) ([]*dto.FileDTO, error) {
+	cond, orderBy, args := keyset(page, 3)
+	q := fmt.Sprintf(`SELECT id, owner_id, name, folder_id, object_path, size, type, created_at, updated_at, tags, revision, checksum_algorithm, checksum, starred_at IS NOT NULL FROM public.file WHERE folder_id = $1 AND deleted_at IS NULL AND %s ORDER BY %s LIMIT $2`, cond, orderBy)
+	rows, err := r.db(ctx).Query(ctx, q, append([]any{folderID, page.Fetch()}, args...)...)
 	if err != nil {
 		return nil, fmt.Errorf("query failed: %w", err)
 	}
 	defer rows.Close()
 
-	var files []*dto.FileDTO
+	files := make([]*dto.FileDTO, 0)
 	for rows.Next() {
 		var f dto.FileDTO
-		if err := rows.Scan(&f.ID, &f.OwnerID, &f.Name, &f.FolderID, &f.ObjectPath, &f.Size, &f.Type, &f.CreatedAt, &f.UpdatedAt, &f.Tags); err != nil {
+		if err := rows.Scan(&f.ID, &f.OwnerID, &f.Name, &f.FolderID, &f.ObjectPath, &f.Size, &f.Type, &f.CreatedAt, &f.UpdatedAt, &f.Tags, &f.Revision, &f.ChecksumAlgorithm, &f.Checksum, &f.Starred); err != nil {
 			return nil, fmt.Errorf("scan failed: %w", err)
 		}
 		files = append(files, &f)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, sqlError(err)
+	}
 	return files, nil
 }
 
+// GetFileByName retrieves the file with the given name in a folder.
+func (r fileRepository) GetFileByName(ctx context.Context, folderID, name string) (*dto.FileDTO, error) {
+	q := `SELECT id, owner_id, name, folder_id, object_path, size, type, created_at, updated_at, tags, revision, checksum_algorithm, checksum, starred_at IS NOT NULL FROM public.file WHERE folder_id = $1 AND name = $2 AND deleted_at IS NULL`
+	var f dto.FileDTO
+	err := r.db(ctx).QueryRow(ctx, q, folderID, name).Scan(&f.ID, &f.OwnerID, &f.Name, &f.FolderID, &f.ObjectPath, &f.Size, &f.Type, &f.CreatedAt, &f.UpdatedAt, &f.Tags, &f.Revision, &f.ChecksumAlgorithm, &f.Checksum, &f.Starred)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &modelerr.NotFound{ID: name}
+		}
+		return nil, sqlError(err)
+	}
+	return &f, nil
+}
+
 // UpdateFile updates a file in the database.
 func (r fileRepository) UpdateFile(ctx context.Context, file *dto.FileDTO) error {
-	q := `UPDATE public.file SET name = $1, folder_id = $2, object_path = $3, size = $4, type = $5, updated_at = CURRENT_TIMESTAMP, tags = $7 WHERE id = $8`
-	if _, err := r.client.Exec(ctx, q, file.Name, file.FolderID, file.ObjectPath, file.Size, file.Type, file.Tags, file.ID); err != nil {
+	q := `UPDATE public.file SET name = $1, folder_id = $2, object_path = $3, size = $4, type = $5, updated_at = CURRENT_TIMESTAMP, tags = $6 WHERE id = $7 AND deleted_at IS NULL`
+	if _, err := r.db(ctx).Exec(ctx, q, file.Name, file.FolderID, file.ObjectPath, file.Size, file.Type, file.Tags, file.ID); err != nil {
+		if isSQLState(err, uniqueViolation) {
+			return &modelerr.DuplicateError{Name: file.Name, FolderID: strconv.Itoa(file.FolderID)}
+		}
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
 			pgErr = err.(*pgconn.PgError)
@@ -117,21 +131,370 @@ func (r fileRepository) UpdateFile(ctx context.Context, file *dto.FileDTO) error
 	return nil
 }
 
-// DeleteFile deletes a file from the database.
-func (r fileRepository) DeleteFile(ctx context.Context, id string) error {
-	q := `DELETE FROM public.file WHERE id = $1`
-	if _, err := r.client.Exec(ctx, q, id); err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) {
-			pgErr = err.(*pgconn.PgError)
-			newErr := fmt.Errorf("SQL Error: %s, Details: %s, Where: %s, Code: %s, SQLState: %s", pgErr.Message, pgErr.Detail, pgErr.Where, pgErr.Code, pgErr.SQLState())
-			return newErr
+// PatchFile updates the given fields of a file, the SET list holds only these fields.
+func (r fileRepository) PatchFile(ctx context.Context, file *dto.FileDTO, fields []dto.FileField) error {
+	args := []any{file.ID}
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	sets := make([]string, 0, len(fields)+1)
+	for _, f := range fields {
+		switch f {
+		case dto.FileName:
+			sets = append(sets, "name = "+arg(file.Name)+"::VARCHAR")
+		case dto.FileFolderID:
+			sets = append(sets, "folder_id = "+arg(file.FolderID)+"::BIGINT")
+		case dto.FileTags:
+			sets = append(sets, "tags = "+arg(file.Tags)+"::JSONB")
+		default:
+			return fmt.Errorf("unknown file field %q", f)
 		}
-		return err
+	}
+	sets = append(sets, "updated_at = CURRENT_TIMESTAMP")
+	q := fmt.Sprintf(`UPDATE public.file SET %s WHERE id = $1 AND deleted_at IS NULL`, strings.Join(sets, ", "))
+	tag, err := r.db(ctx).Exec(ctx, q, args...)
+	if err != nil {
+		if isSQLState(err, uniqueViolation) {
+			return &modelerr.DuplicateError{Name: file.Name, FolderID: strconv.Itoa(file.FolderID)}
+		}
+		return sqlError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return &modelerr.NotFound{ID: strconv.Itoa(file.ID)}
+	}
+	return nil
+}
+
+// UpdateFileContent replaces the stored object of a file, keeping its name and location.
+// The new content is recorded as the next version of the file.
+func (r fileRepository) UpdateFileContent(ctx context.Context, file *dto.FileDTO) (int, error) {
+	q := `WITH f AS (
+    UPDATE public.file SET object_path = $2, size = $3, type = $4, checksum_algorithm = $5, checksum = $6,
+        content_version = content_version + 1, updated_at = CURRENT_TIMESTAMP
+    WHERE id = $1 AND deleted_at IS NULL
+    RETURNING id, content_version, object_path, size, type, checksum_algorithm, checksum
+), v AS (
+    INSERT INTO public.file_version (file_id, version, object_path, size, type, checksum_algorithm, checksum) SELECT * FROM f
+)
+SELECT content_version FROM f`
+	var version int
+	if err := r.db(ctx).QueryRow(ctx, q, file.ID, file.ObjectPath, file.Size, file.Type, file.ChecksumAlgorithm, file.Checksum).Scan(&version); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, &modelerr.NotFound{ID: strconv.Itoa(file.ID)}
+		}
+		return 0, sqlError(err)
+	}
+	return version, nil
+}
+
+// GetFileVersions retrieves the versions of a file, latest first. Files in the trash are not found.
+func (r fileRepository) GetFileVersions(ctx context.Context, fileID string) ([]*dto.FileVersionDTO, error) {
+	q := `SELECT v.file_id, v.version, v.object_path, v.size, v.type, v.created_at, v.version = f.content_version, v.checksum_algorithm, v.checksum
+FROM public.file f JOIN public.file_version v ON v.file_id = f.id
+WHERE f.id = $1 AND f.deleted_at IS NULL
+ORDER BY v.version DESC`
+	rows, err := r.db(ctx).Query(ctx, q, fileID)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+	defer rows.Close()
+	versions := make([]*dto.FileVersionDTO, 0)
+	for rows.Next() {
+		var v dto.FileVersionDTO
+		if err := rows.Scan(&v.FileID, &v.Version, &v.ObjectPath, &v.Size, &v.Type, &v.CreatedAt, &v.Current, &v.ChecksumAlgorithm, &v.Checksum); err != nil {
+			return nil, fmt.Errorf("failed to scan file version: %w", err)
+		}
+		versions = append(versions, &v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqlError(err)
+	}
+	// Every file has at least its current version
+	if len(versions) == 0 {
+		return nil, &modelerr.NotFound{ID: fileID}
+	}
+	return versions, nil
+}
+
+// GetFileVersion retrieves a version of a file.
+func (r fileRepository) GetFileVersion(ctx context.Context, fileID string, version int) (*dto.FileVersionDTO, error) {
+	q := `SELECT v.file_id, v.version, v.object_path, v.size, v.type, v.created_at, v.version = f.content_version, v.checksum_algorithm, v.checksum
+FROM public.file f JOIN public.file_version v ON v.file_id = f.id
+WHERE f.id = $1 AND f.deleted_at IS NULL AND v.version = $2`
+	var v dto.FileVersionDTO
+	err := r.db(ctx).QueryRow(ctx, q, fileID, version).Scan(&v.FileID, &v.Version, &v.ObjectPath, &v.Size, &v.Type, &v.CreatedAt, &v.Current, &v.ChecksumAlgorithm, &v.Checksum)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &modelerr.NotFound{ID: fmt.Sprintf("%s@%d", fileID, version)}
+		}
+		return nil, sqlError(err)
+	}
+	return &v, nil
+}
+
+// PruneFileVersions deletes all but the latest keep versions of a file.
+// It returns the stored objects of the deleted versions that no remaining version of any file shares, so they can be purged.
+func (r fileRepository) PruneFileVersions(ctx context.Context, fileID string, keep int) ([]*dto.DeletedFileDTO, error) {
+	q := `WITH pruned AS (
+    DELETE FROM public.file_version v USING public.file f
+    WHERE v.file_id = f.id AND f.id = $1 AND v.version <= f.content_version - $2
+    RETURNING v.file_id, v.object_path, f.content_version
+)
+SELECT DISTINCT p.file_id, p.object_path FROM pruned p
+WHERE NOT EXISTS (
+    SELECT 1 FROM public.file_version k
+    WHERE k.object_path = p.object_path AND (k.file_id <> p.file_id OR k.version > p.content_version - $2)
+)`
+	rows, err := r.db(ctx).Query(ctx, q, fileID, keep)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+	defer rows.Close()
+	objects := make([]*dto.DeletedFileDTO, 0)
+	for rows.Next() {
+		var o dto.DeletedFileDTO
+		if err := rows.Scan(&o.ID, &o.ObjectPath); err != nil {
+			return nil, fmt.Errorf("failed to scan pruned version: %w", err)
+		}
+		objects = append(objects, &o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqlError(err)
+	}
+	return objects, nil
+}
+
+// GetFileRevision locks a file until the end of the transaction and returns its revision.
+// Files in the trash are not found.
+func (r fileRepository) GetFileRevision(ctx context.Context, id string) (int, error) {
+	q := `SELECT revision FROM public.file WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`
+	var revision int
+	if err := r.db(ctx).QueryRow(ctx, q, id).Scan(&revision); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, &modelerr.NotFound{ID: id}
+		}
+		return 0, sqlError(err)
+	}
+	return revision, nil
+}
+
+// CopyFile copies a file into a folder under the given name. The copy gets the tags and the current content
+// of the file, sharing its stored object, as its first version.
+// The file and the folder must exist and must not be in the trash.
+func (r fileRepository) CopyFile(ctx context.Context, id, folderID, name string) (*string, error) {
+	q := `WITH f AS (
+    INSERT INTO public.file (name, folder_id, owner_id, size, type, object_path, tags, checksum_algorithm, checksum)
+    SELECT $3::VARCHAR, $2::BIGINT, owner_id, size, type, object_path, tags, checksum_algorithm, checksum FROM public.file
+    WHERE id = $1 AND deleted_at IS NULL AND EXISTS (SELECT 1 FROM public.folder WHERE id = $2 AND deleted_at IS NULL)
+    RETURNING id, content_version, object_path, size, type, checksum_algorithm, checksum
+), v AS (
+    INSERT INTO public.file_version (file_id, version, object_path, size, type, checksum_algorithm, checksum) SELECT * FROM f
+)
+SELECT id FROM f`
+	var copyID int
+	if err := r.db(ctx).QueryRow(ctx, q, id, folderID, name).Scan(&copyID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &modelerr.NotFound{ID: id}
+		}
+		if isSQLState(err, uniqueViolation) {
+			return nil, &modelerr.DuplicateError{Name: name, FolderID: folderID}
+		}
+		return nil, sqlError(err)
+	}
+	res := strconv.Itoa(copyID)
+	return &res, nil
+}
+
+// MoveFile puts a file into another folder under the given name.
+func (r fileRepository) MoveFile(ctx context.Context, id, folderID, name string) error {
+	q := `UPDATE public.file SET folder_id = $2, name = $3, updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL`
+	tag, err := r.db(ctx).Exec(ctx, q, id, folderID, name)
+	if err != nil {
+		if isSQLState(err, uniqueViolation) {
+			return &modelerr.DuplicateError{Name: name, FolderID: folderID}
+		}
+		return sqlError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return &modelerr.NotFound{ID: id}
+	}
+	return nil
+}
+
+// TrashFile moves a file to the trash.
+// The deletion time is taken when the statement runs rather than when the transaction started, so a file deleted
+// on its own stays apart from a folder deleted later in the same transaction.
+func (r fileRepository) TrashFile(ctx context.Context, id string) error {
+	q := `UPDATE public.file SET deleted_at = clock_timestamp() WHERE id = $1 AND deleted_at IS NULL`
+	tag, err := r.db(ctx).Exec(ctx, q, id)
+	if err != nil {
+		return sqlError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return &modelerr.NotFound{ID: id}
 	}
 	return nil
 }
 
+// AddFileTag appends a tag to the tags of a file, keeping them distinct.
+func (r fileRepository) AddFileTag(ctx context.Context, id, tag string) ([]string, error) {
+	q := `UPDATE public.file SET tags = tags || jsonb_build_array($2::TEXT), updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL AND NOT tags @> jsonb_build_array($2::TEXT)
+RETURNING tags`
+	tags, err := r.updateTags(ctx, q, id, tag)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Either the file already has the tag or it does not exist
+		return r.getTags(ctx, id)
+	}
+	return tags, err
+}
+
+// RemoveFileTag removes a tag from the tags of a file.
+func (r fileRepository) RemoveFileTag(ctx context.Context, id, tag string) ([]string, error) {
+	q := `UPDATE public.file SET tags = tags - $2::TEXT, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL AND tags @> jsonb_build_array($2::TEXT)
+RETURNING tags`
+	tags, err := r.updateTags(ctx, q, id, tag)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Either the file does not have the tag or it does not exist
+		return r.getTags(ctx, id)
+	}
+	return tags, err
+}
+
+// updateTags runs a tag update returning the new tags of the file.
+func (r fileRepository) updateTags(ctx context.Context, q, id, tag string) ([]string, error) {
+	var tags []string
+	if err := r.db(ctx).QueryRow(ctx, q, id, tag).Scan(&tags); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		return nil, sqlError(err)
+	}
+	return tags, nil
+}
+
+// getTags retrieves the tags of a file.
+func (r fileRepository) getTags(ctx context.Context, id string) ([]string, error) {
+	q := `SELECT tags FROM public.file WHERE id = $1 AND deleted_at IS NULL`
+	var tags []string
+	if err := r.db(ctx).QueryRow(ctx, q, id).Scan(&tags); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &modelerr.NotFound{ID: id}
+		}
+		return nil, sqlError(err)
+	}
+	return tags, nil
+}
+
+// GetTags retrieves the tags used by the files of an owner outside the trash with their file counts, most used first.
+func (r fileRepository) GetTags(ctx context.Context, ownerID string) ([]*dto.TagUsageDTO, error) {
+	q := `SELECT t.tag, count(*) AS files
+FROM public.file f CROSS JOIN jsonb_array_elements_text(f.tags) AS t(tag)
+WHERE f.owner_id = $1 AND f.deleted_at IS NULL
+GROUP BY t.tag
+ORDER BY files DESC, t.tag`
+	rows, err := r.db(ctx).Query(ctx, q, ownerID)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+	defer rows.Close()
+	usages := make([]*dto.TagUsageDTO, 0)
+	for rows.Next() {
+		var u dto.TagUsageDTO
+		if err := rows.Scan(&u.Tag, &u.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		usages = append(usages, &u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqlError(err)
+	}
+	return usages, nil
+}
+
+// GetFilesByTag retrieves a page of the files of an owner having a tag in the order of the page sort option.
+func (r fileRepository) GetFilesByTag(ctx context.Context, ownerID, tag string, page *dto.PageRequest) ([]*dto.FileDTO, error) {
+	cond, orderBy, args := keyset(page, 4)
+	q := fmt.Sprintf(`SELECT id, owner_id, name, folder_id, object_path, size, type, created_at, updated_at, tags, revision, checksum_algorithm, checksum, starred_at IS NOT NULL FROM public.file
+WHERE owner_id = $1 AND tags @> jsonb_build_array($2::TEXT) AND deleted_at IS NULL AND %s ORDER BY %s LIMIT $3`, cond, orderBy)
+	rows, err := r.db(ctx).Query(ctx, q, append([]any{ownerID, tag, page.Fetch()}, args...)...)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+	defer rows.Close()
+	files := make([]*dto.FileDTO, 0)
+	for rows.Next() {
+		var f dto.FileDTO
+		if err := rows.Scan(&f.ID, &f.OwnerID, &f.Name, &f.FolderID, &f.ObjectPath, &f.Size, &f.Type, &f.CreatedAt, &f.UpdatedAt, &f.Tags, &f.Revision, &f.ChecksumAlgorithm, &f.Checksum, &f.Starred); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		files = append(files, &f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqlError(err)
+	}
+	return files, nil
+}
+
+// GetDuplicates retrieves up to limit duplicate groups of the files of an owner outside the trash, most reclaimable
+// bytes first, with the totals over all the groups. The files with a checksum are grouped by checksum, the other ones
+// by name and size. The reclaimable bytes of a group are the sizes of its distinct stored objects but the largest one.
+func (r fileRepository) GetDuplicates(ctx context.Context, ownerID string, limit int) (*dto.DuplicatesDTO, error) {
+	q := `WITH f AS (
+    SELECT id, owner_id, name, folder_id, object_path, size, type, created_at, updated_at, tags, revision, checksum_algorithm, checksum,
+           starred_at IS NOT NULL AS starred,
+           CASE WHEN checksum IS NULL THEN name END AS dup_name,
+           CASE WHEN checksum IS NULL THEN size END AS dup_size
+    FROM public.file WHERE owner_id = $1 AND deleted_at IS NULL
+), objects AS (
+    SELECT size, checksum_algorithm, checksum, dup_name, dup_size,
+           row_number() OVER (PARTITION BY checksum_algorithm, checksum, dup_name, dup_size, object_path) = 1 AS first_of_object
+    FROM f
+), groups AS (
+    SELECT checksum_algorithm, checksum, dup_name, dup_size, MAX(size) AS size,
+           SUM(size) FILTER (WHERE first_of_object) - MAX(size) AS reclaimable
+    FROM objects GROUP BY checksum_algorithm, checksum, dup_name, dup_size HAVING count(*) > 1
+), ranked AS (
+    SELECT *, row_number() OVER (ORDER BY reclaimable DESC, size DESC, checksum_algorithm, checksum, dup_name) AS group_rank,
+           count(*) OVER () AS group_count, SUM(reclaimable) OVER () AS total_reclaimable
+    FROM groups ORDER BY group_rank LIMIT $2
+)
+SELECT g.group_rank, g.group_count, g.total_reclaimable, g.checksum_algorithm, g.checksum, g.dup_name, g.size, g.reclaimable,
+       f.id, f.owner_id, f.name, f.folder_id, f.object_path, f.size, f.type, f.created_at, f.updated_at, f.tags, f.revision, f.checksum_algorithm, f.checksum, f.starred
+FROM ranked g JOIN f ON f.checksum_algorithm IS NOT DISTINCT FROM g.checksum_algorithm AND f.checksum IS NOT DISTINCT FROM g.checksum
+    AND f.dup_name IS NOT DISTINCT FROM g.dup_name AND f.dup_size IS NOT DISTINCT FROM g.dup_size
+ORDER BY g.group_rank, f.id`
+	rows, err := r.db(ctx).Query(ctx, q, ownerID, limit)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+	defer rows.Close()
+	res := &dto.DuplicatesDTO{Groups: make([]*dto.DuplicateGroupDTO, 0)}
+	var group *dto.DuplicateGroupDTO
+	lastRank := 0
+	for rows.Next() {
+		var rank int
+		var g dto.DuplicateGroupDTO
+		var f dto.FileDTO
+		if err := rows.Scan(&rank, &res.GroupCount, &res.ReclaimableBytes, &g.ChecksumAlgorithm, &g.Checksum, &g.Name, &g.Size, &g.ReclaimableBytes,
+			&f.ID, &f.OwnerID, &f.Name, &f.FolderID, &f.ObjectPath, &f.Size, &f.Type, &f.CreatedAt, &f.UpdatedAt, &f.Tags, &f.Revision, &f.ChecksumAlgorithm, &f.Checksum, &f.Starred); err != nil {
+			return nil, fmt.Errorf("failed to scan duplicate: %w", err)
+		}
+		// The rows of a group are consecutive
+		if rank != lastRank {
+			group, lastRank = &g, rank
+			res.Groups = append(res.Groups, group)
+		}
+		group.Files = append(group.Files, &f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqlError(err)
+	}
+	return res, nil
+}
+
 // NewFileRepo creates a new fileRepository.
 func NewFileRepo(client Client, logger log.Logger) dto.FileRepository {
 	return fileRepository{
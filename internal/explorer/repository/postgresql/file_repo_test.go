@@ -0,0 +1,86 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"github.com/go-kit/log"
+	"math"
+	"remy_explorer/internal/explorer/dto"
+	"strconv"
+	"testing"
+)
+
+// testOwner is the owner of the items created by the repository tests.
+const testOwner = "900000001"
+
+// createTestFile creates a file in the root folder of testOwner.
+func createTestFile(t *testing.T, ctx context.Context, files dto.FileRepository, folders dto.FolderRepository, name string, size int) *dto.FileDTO {
+	t.Helper()
+	root, err := folders.EnsureRootFolder(ctx, testOwner)
+	if err != nil {
+		t.Fatalf("EnsureRootFolder() error = %v", err)
+	}
+	f := &dto.FileDTO{
+		Name:       name,
+		FolderID:   root.ID,
+		OwnerID:    testOwner,
+		ObjectPath: sql.NullString{String: "objects/" + name, Valid: true},
+		Size:       size,
+		Type:       sql.NullString{String: "text/plain", Valid: true},
+		Tags:       []string{},
+	}
+	if _, err := files.CreateFile(ctx, f); err != nil {
+		t.Fatalf("CreateFile() error = %v", err)
+	}
+	return f
+}
+
+func TestFileRepositoryUpdateFile(t *testing.T) {
+	ctx, client := testDB(t)
+	files := NewFileRepo(client, log.NewNopLogger())
+	folders := NewFolderRepo(client, log.NewNopLogger())
+	f := createTestFile(t, ctx, files, folders, "a.txt", 10)
+
+	f.Name = "b.md"
+	f.ObjectPath = sql.NullString{String: "objects/b.md", Valid: true}
+	f.Size = 20
+	f.Type = sql.NullString{String: "text/markdown", Valid: true}
+	f.Tags = []string{"notes"}
+	if err := files.UpdateFile(ctx, f); err != nil {
+		t.Fatalf("UpdateFile() error = %v", err)
+	}
+
+	got, err := files.GetFileByID(ctx, strconv.Itoa(f.ID))
+	if err != nil {
+		t.Fatalf("GetFileByID() error = %v", err)
+	}
+	if got.Name != f.Name || got.FolderID != f.FolderID || got.ObjectPath != f.ObjectPath || got.Size != f.Size || got.Type != f.Type {
+		t.Errorf("GetFileByID() = %+v, want the fields of %+v", got, f)
+	}
+	if len(got.Tags) != 1 || got.Tags[0] != "notes" {
+		t.Errorf("GetFileByID() tags = %v, want [notes]", got.Tags)
+	}
+}
+
+func TestFileRepositoryCreateLargeFile(t *testing.T) {
+	ctx, client := testDB(t)
+	files := NewFileRepo(client, log.NewNopLogger())
+	folders := NewFolderRepo(client, log.NewNopLogger())
+	size := math.MaxInt32 + 1
+	f := createTestFile(t, ctx, files, folders, "large.bin", size)
+
+	got, err := files.GetFileByID(ctx, strconv.Itoa(f.ID))
+	if err != nil {
+		t.Fatalf("GetFileByID() error = %v", err)
+	}
+	if got.Size != size {
+		t.Errorf("GetFileByID() size = %d, want %d", got.Size, size)
+	}
+	versions, err := files.GetFileVersions(ctx, strconv.Itoa(f.ID))
+	if err != nil {
+		t.Fatalf("GetFileVersions() error = %v", err)
+	}
+	if len(versions) != 1 || versions[0].Size != size {
+		t.Errorf("GetFileVersions() = %+v, want one version of size %d", versions, size)
+	}
+}
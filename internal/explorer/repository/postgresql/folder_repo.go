@@ -10,6 +10,8 @@ import (
 	model "remy_explorer/internal/explorer/dto"
 	modelerr "remy_explorer/internal/explorer/err"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type folderRepository struct {
@@ -17,10 +19,24 @@ type folderRepository struct {
 	log    log.Logger
 }
 
+// db returns the connection to run queries on, joining the transaction bound to ctx if there is one.
+func (r folderRepository) db(ctx context.Context) Client {
+	return conn(ctx, r.client)
+}
+
 // CreateFolder creates a new folder in the database.
+// The parent must exist and must not be in the trash.
 func (r folderRepository) CreateFolder(ctx context.Context, folder *model.FolderDTO) (*string, error) {
-	q := `INSERT INTO public.folder (name, parent_id, owner_id) VALUES ($1, $2, $3) RETURNING id`
-	if err := r.client.QueryRow(ctx, q, folder.Name, folder.ParentID, folder.OwnerID).Scan(&folder.ID); err != nil {
+	q := `INSERT INTO public.folder (name, parent_id, owner_id)
+SELECT $1::VARCHAR, $2::BIGINT, $3::BIGINT WHERE EXISTS (SELECT 1 FROM public.folder WHERE id = $2 AND deleted_at IS NULL)
+RETURNING id`
+	if err := r.db(ctx).QueryRow(ctx, q, folder.Name, folder.ParentID, folder.OwnerID).Scan(&folder.ID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &modelerr.NotFound{ID: folder.ParentID.String}
+		}
+		if isSQLState(err, uniqueViolation) {
+			return nil, &modelerr.DuplicateError{Name: folder.Name, FolderID: folder.ParentID.String}
+		}
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
 			newErr := fmt.Errorf("SQL Error: %s, Details: %s, Where: %s, Code: %s, SQLState: %s", pgErr.Message, pgErr.Detail, pgErr.Where, pgErr.Code, pgErr.SQLState())
@@ -32,11 +48,11 @@ func (r folderRepository) CreateFolder(ctx context.Context, folder *model.Folder
 	return &res, nil
 }
 
-// GetFolderByID retrieves a folder by its ID.
+// GetFolderByID retrieves a folder by its ID. Folders in the trash are not found.
 func (r folderRepository) GetFolderByID(ctx context.Context, id string) (*model.FolderDTO, error) {
-	query := `SELECT id, owner_id, name, parent_id, created_at, updated_at FROM public.folder WHERE id = $1`
+	query := `SELECT id, owner_id, name, parent_id, created_at, updated_at, total_size, file_count, folder_count, revision, starred_at IS NOT NULL FROM public.folder WHERE id = $1 AND deleted_at IS NULL`
 	var folder model.FolderDTO
-	str := r.client.QueryRow(ctx, query, id)
+	str := r.db(ctx).QueryRow(ctx, query, id)
 	err := str.Scan(
 		&folder.ID,
 		&folder.OwnerID,
@@ -44,6 +60,11 @@ func (r folderRepository) GetFolderByID(ctx context.Context, id string) (*model.
 		&folder.ParentID,
 		&folder.CreatedAt,
 		&folder.UpdatedAt,
+		&folder.TotalSize,
+		&folder.FileCount,
+		&folder.FolderCount,
+		&folder.Revision,
+		&folder.Starred,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -60,14 +81,53 @@ func (r folderRepository) GetFolderByID(ctx context.Context, id string) (*model.
 	return &folder, nil
 }
 
-// GetFoldersByParentID retrieves all folders with a given parent ID.
-func (r folderRepository) GetFoldersByParentID(ctx context.Context, FolderID string) ([]*model.FolderDTO, error) {
+// GetFolderByName retrieves the subfolder with the given name in a folder.
+func (r folderRepository) GetFolderByName(ctx context.Context, parentID, name string) (*model.FolderDTO, error) {
+	q := `SELECT id, owner_id, name, parent_id, created_at, updated_at, total_size, file_count, folder_count, revision, starred_at IS NOT NULL FROM public.folder WHERE parent_id = $1 AND name = $2 AND deleted_at IS NULL`
+	var f model.FolderDTO
+	err := r.db(ctx).QueryRow(ctx, q, parentID, name).Scan(&f.ID, &f.OwnerID, &f.Name, &f.ParentID, &f.CreatedAt, &f.UpdatedAt, &f.TotalSize, &f.FileCount, &f.FolderCount, &f.Revision, &f.Starred)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &modelerr.NotFound{ID: name}
+		}
+		return nil, sqlError(err)
+	}
+	return &f, nil
+}
+
+// EnsureRootFolder returns the root folder of an owner, creating it on first use.
+// Concurrent callers wait on the partial unique index and all get the same folder.
+func (r folderRepository) EnsureRootFolder(ctx context.Context, ownerID string) (*model.FolderDTO, error) {
+	q := `WITH created AS (
+    INSERT INTO public.folder (owner_id, name) VALUES ($1, 'root')
+    ON CONFLICT (owner_id) WHERE parent_id IS NULL DO NOTHING
+    RETURNING id, owner_id, name, parent_id, created_at, updated_at, total_size, file_count, folder_count, revision, starred_at IS NOT NULL AS starred
+)
+SELECT id, owner_id, name, parent_id, created_at, updated_at, total_size, file_count, folder_count, revision, starred FROM created
+UNION ALL
+SELECT id, owner_id, name, parent_id, created_at, updated_at, total_size, file_count, folder_count, revision, starred_at IS NOT NULL FROM public.folder WHERE owner_id = $1 AND parent_id IS NULL
+LIMIT 1`
+	var f model.FolderDTO
+	err := r.db(ctx).QueryRow(ctx, q, ownerID).Scan(&f.ID, &f.OwnerID, &f.Name, &f.ParentID, &f.CreatedAt, &f.UpdatedAt, &f.TotalSize, &f.FileCount, &f.FolderCount, &f.Revision, &f.Starred)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// The root was inserted by a transaction that committed after this statement started
+			return r.EnsureRootFolder(ctx, ownerID)
+		}
+		return nil, sqlError(err)
+	}
+	return &f, nil
+}
+
+// GetFoldersByParentID retrieves a page of the folders with a given parent ID in the order of the page sort option.
+func (r folderRepository) GetFoldersByParentID(ctx context.Context, FolderID string, page *model.PageRequest) ([]*model.FolderDTO, error) {
 	_, err := r.GetFolderByID(ctx, FolderID)
 	if err != nil {
 		return nil, err
 	}
-	q := `SELECT id, owner_id, name, parent_id, created_at, updated_at FROM public.folder WHERE parent_id = $1`
-	rows, err := r.client.Query(ctx, q, FolderID)
+	cond, orderBy, args := keyset(page, 3)
+	q := fmt.Sprintf(`SELECT id, owner_id, name, parent_id, created_at, updated_at, total_size, file_count, folder_count, revision, starred_at IS NOT NULL FROM public.folder WHERE parent_id = $1 AND deleted_at IS NULL AND %s ORDER BY %s LIMIT $2`, cond, orderBy)
+	rows, err := r.db(ctx).Query(ctx, q, append([]any{FolderID, page.Fetch()}, args...)...)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
@@ -77,21 +137,137 @@ func (r folderRepository) GetFoldersByParentID(ctx context.Context, FolderID str
 		return nil, err
 	}
 	defer rows.Close()
-	var folders []*model.FolderDTO
+	folders := make([]*model.FolderDTO, 0)
 	for rows.Next() {
 		var f model.FolderDTO
-		if err := rows.Scan(&f.ID, &f.OwnerID, &f.Name, &f.ParentID, &f.CreatedAt, &f.UpdatedAt); err != nil {
+		if err := rows.Scan(&f.ID, &f.OwnerID, &f.Name, &f.ParentID, &f.CreatedAt, &f.UpdatedAt, &f.TotalSize, &f.FileCount, &f.FolderCount, &f.Revision, &f.Starred); err != nil {
 			return nil, fmt.Errorf("failed to scan folder: %w", err)
 		}
 		folders = append(folders, &f)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, sqlError(err)
+	}
 	return folders, nil
 }
 
+// GetFolderContent retrieves a page of the content of a folder: its subfolders first, then its files,
+// each in the order of the page sort option. Folders have no size and sort as empty when ordered by size.
+func (r folderRepository) GetFolderContent(ctx context.Context, folderID string, page *model.PageRequest) ([]*model.ContentItemDTO, error) {
+	if _, err := r.GetFolderByID(ctx, folderID); err != nil {
+		return nil, err
+	}
+	// rank keeps folders before files, the cursor resumes after the last item within its rank
+	afterRank := 0
+	if page.After != nil && page.After.Kind == "file" {
+		afterRank = 1
+	}
+	cond, orderBy, args := keyset(page, 4)
+	q := fmt.Sprintf(`SELECT kind, id, name, type, size, created_at, updated_at, total_size, file_count, folder_count, starred FROM (
+    SELECT 0 AS rank, 'folder' AS kind, id, name, NULL::VARCHAR AS type, 0 AS size, created_at, updated_at, total_size, file_count, folder_count,
+           starred_at IS NOT NULL AS starred
+    FROM public.folder WHERE parent_id = $1 AND deleted_at IS NULL
+    UNION ALL
+    SELECT 1, 'file', id, name, type, size, created_at, updated_at, 0, 0, 0, starred_at IS NOT NULL
+    FROM public.file WHERE folder_id = $1 AND deleted_at IS NULL
+) content
+WHERE rank > $3 OR (rank = $3 AND %s)
+ORDER BY rank, %s
+LIMIT $2`, cond, orderBy)
+	rows, err := r.db(ctx).Query(ctx, q, append([]any{folderID, page.Fetch(), afterRank}, args...)...)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+	defer rows.Close()
+	items := make([]*model.ContentItemDTO, 0)
+	for rows.Next() {
+		var c model.ContentItemDTO
+		if err := rows.Scan(&c.Kind, &c.ID, &c.Name, &c.Type, &c.Size, &c.CreatedAt, &c.UpdatedAt, &c.TotalSize, &c.FileCount, &c.FolderCount, &c.Starred); err != nil {
+			return nil, fmt.Errorf("failed to scan content item: %w", err)
+		}
+		items = append(items, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqlError(err)
+	}
+	return items, nil
+}
+
+// GetFolderPath retrieves the ancestors of a folder ordered from the root, ending with the folder itself.
+func (r folderRepository) GetFolderPath(ctx context.Context, id string) ([]*model.FolderDTO, error) {
+	q := `WITH RECURSIVE ancestors AS (
+    SELECT id, owner_id, name, parent_id, created_at, updated_at, 0 AS depth FROM public.folder WHERE id = $1 AND deleted_at IS NULL
+    UNION ALL
+    SELECT f.id, f.owner_id, f.name, f.parent_id, f.created_at, f.updated_at, a.depth + 1
+    FROM public.folder f JOIN ancestors a ON f.id = a.parent_id
+)
+SELECT id, owner_id, name, parent_id, created_at, updated_at FROM ancestors ORDER BY depth DESC`
+	rows, err := r.db(ctx).Query(ctx, q, id)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+	defer rows.Close()
+	path := make([]*model.FolderDTO, 0)
+	for rows.Next() {
+		var f model.FolderDTO
+		if err := rows.Scan(&f.ID, &f.OwnerID, &f.Name, &f.ParentID, &f.CreatedAt, &f.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan folder: %w", err)
+		}
+		path = append(path, &f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqlError(err)
+	}
+	if len(path) == 0 {
+		return nil, &modelerr.NotFound{ID: id}
+	}
+	return path, nil
+}
+
+// GetFolderTree retrieves a folder and its descendants up to depth levels below it,
+// each with the number of its direct subfolders and files.
+func (r folderRepository) GetFolderTree(ctx context.Context, id string, depth int) ([]*model.FolderTreeNodeDTO, error) {
+	q := `WITH RECURSIVE tree AS (
+    SELECT id, name, parent_id, 0 AS depth FROM public.folder WHERE id = $1 AND deleted_at IS NULL
+    UNION ALL
+    SELECT f.id, f.name, f.parent_id, t.depth + 1
+    FROM public.folder f JOIN tree t ON f.parent_id = t.id
+    WHERE t.depth < $2 AND f.deleted_at IS NULL
+)
+SELECT t.id, t.name, t.parent_id, t.depth,
+       (SELECT count(*) FROM public.folder c WHERE c.parent_id = t.id AND c.deleted_at IS NULL),
+       (SELECT count(*) FROM public.file c WHERE c.folder_id = t.id AND c.deleted_at IS NULL)
+FROM tree t
+ORDER BY t.depth, t.name, t.id`
+	rows, err := r.db(ctx).Query(ctx, q, id, depth)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+	defer rows.Close()
+	nodes := make([]*model.FolderTreeNodeDTO, 0)
+	for rows.Next() {
+		var n model.FolderTreeNodeDTO
+		if err := rows.Scan(&n.ID, &n.Name, &n.ParentID, &n.Depth, &n.FolderCount, &n.FileCount); err != nil {
+			return nil, fmt.Errorf("failed to scan folder tree node: %w", err)
+		}
+		nodes = append(nodes, &n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqlError(err)
+	}
+	if len(nodes) == 0 {
+		return nil, &modelerr.NotFound{ID: id}
+	}
+	return nodes, nil
+}
+
 // UpdateFolder updates a folder in the database.
 func (r folderRepository) UpdateFolder(ctx context.Context, folder *model.FolderDTO) error {
-	q := `UPDATE public.folder SET name = $1, parent_id = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $3`
-	if _, err := r.client.Exec(ctx, q, folder.Name, folder.ParentID, folder.ID); err != nil {
+	q := `UPDATE public.folder SET name = $1, parent_id = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $3 AND deleted_at IS NULL`
+	if _, err := r.db(ctx).Exec(ctx, q, folder.Name, folder.ParentID, folder.ID); err != nil {
+		if isSQLState(err, uniqueViolation) {
+			return &modelerr.DuplicateError{Name: folder.Name, FolderID: folder.ParentID.String}
+		}
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
 			newErr := fmt.Errorf("SQL Error: %s, Details: %s, Where: %s, Code: %s, SQLState: %s", pgErr.Message, pgErr.Detail, pgErr.Where, pgErr.Code, pgErr.SQLState())
@@ -102,21 +278,228 @@ func (r folderRepository) UpdateFolder(ctx context.Context, folder *model.Folder
 	return nil
 }
 
-// DeleteFolder deletes a folder from the database.
+// PatchFolder updates the given fields of a folder, the SET list holds only these fields.
+func (r folderRepository) PatchFolder(ctx context.Context, folder *model.FolderDTO, fields []model.FolderField) error {
+	args := []any{folder.ID}
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	sets := make([]string, 0, len(fields)+1)
+	for _, f := range fields {
+		switch f {
+		case model.FolderName:
+			sets = append(sets, "name = "+arg(folder.Name)+"::VARCHAR")
+		case model.FolderParentID:
+			sets = append(sets, "parent_id = "+arg(folder.ParentID)+"::BIGINT")
+		default:
+			return fmt.Errorf("unknown folder field %q", f)
+		}
+	}
+	sets = append(sets, "updated_at = CURRENT_TIMESTAMP")
+	q := fmt.Sprintf(`UPDATE public.folder SET %s WHERE id = $1 AND deleted_at IS NULL`, strings.Join(sets, ", "))
+	tag, err := r.db(ctx).Exec(ctx, q, args...)
+	if err != nil {
+		if isSQLState(err, uniqueViolation) {
+			return &modelerr.DuplicateError{Name: folder.Name, FolderID: folder.ParentID.String}
+		}
+		return sqlError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return &modelerr.NotFound{ID: strconv.Itoa(folder.ID)}
+	}
+	return nil
+}
+
+// MoveFolder puts a folder under another parent with the given name.
+func (r folderRepository) MoveFolder(ctx context.Context, id, parentID, name string) error {
+	q := `UPDATE public.folder SET parent_id = $2, name = $3, updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL`
+	tag, err := r.db(ctx).Exec(ctx, q, id, parentID, name)
+	if err != nil {
+		if isSQLState(err, uniqueViolation) {
+			return &modelerr.DuplicateError{Name: name, FolderID: parentID}
+		}
+		return sqlError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return &modelerr.NotFound{ID: id}
+	}
+	return nil
+}
 
-func (r folderRepository) DeleteFolder(ctx context.Context, id string) error {
-	q := `DELETE FROM public.folder WHERE id = $1`
-	if _, err := r.client.Exec(ctx, q, id); err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) {
-			newErr := fmt.Errorf("SQL Error: %s, Details: %s, Where: %s, Code: %s, SQLState: %s", pgErr.Message, pgErr.Detail, pgErr.Where, pgErr.Code, pgErr.SQLState())
-			return newErr
+// GetFolderRevision locks a folder until the end of the transaction and returns its revision.
+// Folders in the trash are not found.
+func (r folderRepository) GetFolderRevision(ctx context.Context, id string) (int, error) {
+	q := `SELECT revision FROM public.folder WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`
+	var revision int
+	if err := r.db(ctx).QueryRow(ctx, q, id).Scan(&revision); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, &modelerr.NotFound{ID: id}
 		}
-		return err
+		return 0, sqlError(err)
+	}
+	return revision, nil
+}
+
+// IsInSubtree reports whether folder id is rootID itself or one of its descendants by walking up from id.
+func (r folderRepository) IsInSubtree(ctx context.Context, rootID, id string) (bool, error) {
+	q := `WITH RECURSIVE ancestors AS (
+    SELECT id, parent_id FROM public.folder WHERE id = $2
+    UNION
+    SELECT f.id, f.parent_id FROM public.folder f JOIN ancestors a ON f.id = a.parent_id
+)
+SELECT EXISTS (SELECT 1 FROM ancestors WHERE id = $1)`
+	var found bool
+	if err := r.db(ctx).QueryRow(ctx, q, rootID, id).Scan(&found); err != nil {
+		return false, sqlError(err)
+	}
+	return found, nil
+}
+
+// LockTree takes a transaction-scoped advisory lock on the owner's folder tree,
+// so concurrent moves cannot combine into a cycle.
+func (r folderRepository) LockTree(ctx context.Context, ownerID string) error {
+	q := `SELECT pg_advisory_xact_lock(hashtext('folder_tree'), hashtext($1))`
+	if _, err := r.db(ctx).Exec(ctx, q, ownerID); err != nil {
+		return sqlError(err)
+	}
+	return nil
+}
+
+// LockFolder takes a transaction-scoped advisory lock on a folder,
+// so concurrent operations cannot pick the same free name in it.
+func (r folderRepository) LockFolder(ctx context.Context, id string) error {
+	q := `SELECT pg_advisory_xact_lock(hashtext('folder'), hashtext($1))`
+	if _, err := r.db(ctx).Exec(ctx, q, id); err != nil {
+		return sqlError(err)
+	}
+	return nil
+}
+
+// TrashFolder moves an empty folder to the trash. Items already in the trash do not count as content.
+// Like TrashFile, it stamps the time the statement runs.
+func (r folderRepository) TrashFolder(ctx context.Context, id string) error {
+	q := `UPDATE public.folder SET deleted_at = clock_timestamp() WHERE id = $1 AND deleted_at IS NULL
+    AND NOT EXISTS (SELECT 1 FROM public.folder WHERE parent_id = $1 AND deleted_at IS NULL)
+    AND NOT EXISTS (SELECT 1 FROM public.file WHERE folder_id = $1 AND deleted_at IS NULL)`
+	tag, err := r.db(ctx).Exec(ctx, q, id)
+	if err != nil {
+		return sqlError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		if _, err := r.GetFolderByID(ctx, id); err != nil {
+			return err
+		}
+		return &modelerr.NotEmpty{ID: id}
+	}
+	return nil
+}
+
+// TrashFolderRecursive moves a folder to the trash together with all its subfolders and files,
+// marking them with the same deletion time so they are restored together.
+// The time is taken once when the deletion runs, so items deleted earlier in the same transaction keep their own.
+// Both statements must run in one unit of work, otherwise a failure may leave the subtree half-trashed.
+func (r folderRepository) TrashFolderRecursive(ctx context.Context, id string) error {
+	var deletedAt time.Time
+	if err := r.db(ctx).QueryRow(ctx, `SELECT clock_timestamp()::TIMESTAMP`).Scan(&deletedAt); err != nil {
+		return sqlError(err)
+	}
+	subtree := `WITH RECURSIVE subtree AS (
+    SELECT id FROM public.folder WHERE id = $1 AND deleted_at IS NULL
+    UNION ALL
+    SELECT f.id FROM public.folder f JOIN subtree s ON f.parent_id = s.id WHERE f.deleted_at IS NULL
+)`
+	q := subtree + ` UPDATE public.file SET deleted_at = $2 WHERE folder_id IN (SELECT id FROM subtree) AND deleted_at IS NULL`
+	if _, err := r.db(ctx).Exec(ctx, q, id, deletedAt); err != nil {
+		return sqlError(err)
+	}
+	q = subtree + ` UPDATE public.folder SET deleted_at = $2 WHERE id IN (SELECT id FROM subtree)`
+	tag, err := r.db(ctx).Exec(ctx, q, id, deletedAt)
+	if err != nil {
+		return sqlError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return &modelerr.NotFound{ID: id}
 	}
 	return nil
 }
 
+// CopyFolder copies a folder under parentID with the given name, together with the subfolders and files below it
+// that are not in the trash. The IDs of the copies are drawn from the sequences up front,
+// so every copied folder can be inserted with the copy of its parent in a single statement.
+// A folder may be copied into its own subtree: the statements only see the subtree as it was before the copy.
+// Both statements must run in one unit of work.
+func (r folderRepository) CopyFolder(ctx context.Context, id, parentID, name string) ([]*model.CopiedItemDTO, error) {
+	q := `WITH RECURSIVE subtree AS (
+    SELECT id, owner_id, name, parent_id FROM public.folder WHERE id = $1 AND deleted_at IS NULL
+    UNION ALL
+    SELECT f.id, f.owner_id, f.name, f.parent_id FROM public.folder f JOIN subtree s ON f.parent_id = s.id WHERE f.deleted_at IS NULL
+), ids AS (
+    SELECT id AS source_id, nextval(pg_get_serial_sequence('public.folder', 'id')) AS id FROM subtree
+), copied AS (
+    INSERT INTO public.folder (id, owner_id, name, parent_id)
+    SELECT i.id, s.owner_id,
+           CASE WHEN s.id = $1 THEN $3::VARCHAR ELSE s.name END,
+           CASE WHEN s.id = $1 THEN $2::BIGINT ELSE p.id END
+    FROM subtree s JOIN ids i ON i.source_id = s.id LEFT JOIN ids p ON p.source_id = s.parent_id
+)
+SELECT 'folder', source_id, id FROM ids`
+	folders, err := r.scanCopied(ctx, q, id, parentID, name)
+	if err != nil {
+		if isSQLState(err, uniqueViolation) {
+			return nil, &modelerr.DuplicateError{Name: name, FolderID: parentID}
+		}
+		return nil, sqlError(err)
+	}
+	if len(folders) == 0 {
+		return nil, &modelerr.NotFound{ID: id}
+	}
+	sourceIDs := make([]int, len(folders))
+	copyIDs := make([]int, len(folders))
+	for i, f := range folders {
+		sourceIDs[i], copyIDs[i] = f.SourceID, f.ID
+	}
+	q = `WITH src AS (
+    SELECT f.id AS source_id, nextval(pg_get_serial_sequence('public.file', 'id')) AS id, m.id AS folder_id,
+           f.owner_id, f.name, f.object_path, f.size, f.type, f.tags, f.checksum_algorithm, f.checksum
+    FROM public.file f JOIN unnest($1::BIGINT[], $2::BIGINT[]) AS m(source_id, id) ON f.folder_id = m.source_id
+    WHERE f.deleted_at IS NULL
+), copied AS (
+    INSERT INTO public.file (id, name, folder_id, owner_id, size, type, object_path, tags, checksum_algorithm, checksum)
+    SELECT id, name, folder_id, owner_id, size, type, object_path, tags, checksum_algorithm, checksum FROM src
+    RETURNING id, content_version, object_path, size, type, checksum_algorithm, checksum
+), v AS (
+    INSERT INTO public.file_version (file_id, version, object_path, size, type, checksum_algorithm, checksum) SELECT * FROM copied
+)
+SELECT 'file', source_id, id FROM src`
+	files, err := r.scanCopied(ctx, q, sourceIDs, copyIDs)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+	return append(folders, files...), nil
+}
+
+// scanCopied runs a copy query returning the kind, source ID and copy ID of the copied items.
+func (r folderRepository) scanCopied(ctx context.Context, q string, args ...any) ([]*model.CopiedItemDTO, error) {
+	rows, err := r.db(ctx).Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := make([]*model.CopiedItemDTO, 0)
+	for rows.Next() {
+		var item model.CopiedItemDTO
+		if err := rows.Scan(&item.Kind, &item.SourceID, &item.ID); err != nil {
+			return nil, fmt.Errorf("failed to scan copied item: %w", err)
+		}
+		items = append(items, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 // NewFolderRepo creates a new folder folderRepository.
 func NewFolderRepo(client Client, logger log.Logger) model.FolderRepository {
 	return folderRepository{
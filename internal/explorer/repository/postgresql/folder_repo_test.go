@@ -0,0 +1,71 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"github.com/go-kit/log"
+	"remy_explorer/internal/explorer/dto"
+	"strconv"
+	"testing"
+)
+
+// createTestFolder creates a folder of testOwner under parentID.
+func createTestFolder(t *testing.T, ctx context.Context, folders dto.FolderRepository, parentID int, name string) *dto.FolderDTO {
+	t.Helper()
+	f := &dto.FolderDTO{
+		Name:     name,
+		OwnerID:  testOwner,
+		ParentID: sql.NullString{String: strconv.Itoa(parentID), Valid: true},
+	}
+	if _, err := folders.CreateFolder(ctx, f); err != nil {
+		t.Fatalf("CreateFolder() error = %v", err)
+	}
+	return f
+}
+
+// A file deleted on its own before its folder in the same transaction, as an atomic bulk delete does,
+// stays listed in the trash and is not restored with the folder.
+func TestTrashFileThenItsFolderInOneTransaction(t *testing.T) {
+	ctx, client := testDB(t)
+	files := NewFileRepo(client, log.NewNopLogger())
+	folders := NewFolderRepo(client, log.NewNopLogger())
+	trash := NewTrashRepo(client, log.NewNopLogger())
+	root, err := folders.EnsureRootFolder(ctx, testOwner)
+	if err != nil {
+		t.Fatalf("EnsureRootFolder() error = %v", err)
+	}
+	a := createTestFolder(t, ctx, folders, root.ID, "a")
+	f := createTestFile(t, ctx, files, folders, "f.txt", 1)
+	if err := files.MoveFile(ctx, strconv.Itoa(f.ID), strconv.Itoa(a.ID), f.Name); err != nil {
+		t.Fatalf("MoveFile() error = %v", err)
+	}
+
+	if err := files.TrashFile(ctx, strconv.Itoa(f.ID)); err != nil {
+		t.Fatalf("TrashFile() error = %v", err)
+	}
+	if err := folders.TrashFolderRecursive(ctx, strconv.Itoa(a.ID)); err != nil {
+		t.Fatalf("TrashFolderRecursive() error = %v", err)
+	}
+	page, err := dto.NewPageRequest(0, "", dto.TrashSortOption)
+	if err != nil {
+		t.Fatalf("NewPageRequest() error = %v", err)
+	}
+	items, err := trash.GetTrash(ctx, testOwner, page)
+	if err != nil {
+		t.Fatalf("GetTrash() error = %v", err)
+	}
+	listed := make(map[string]bool)
+	for _, item := range items {
+		listed[item.Kind+" "+strconv.Itoa(item.ID)] = true
+	}
+	if !listed["folder "+strconv.Itoa(a.ID)] || !listed["file "+strconv.Itoa(f.ID)] {
+		t.Errorf("GetTrash() = %v, want both the folder %d and the file %d", listed, a.ID, f.ID)
+	}
+
+	if err := trash.RestoreFolder(ctx, strconv.Itoa(a.ID), strconv.Itoa(root.ID), a.Name); err != nil {
+		t.Fatalf("RestoreFolder() error = %v", err)
+	}
+	if _, err := files.GetFileByID(ctx, strconv.Itoa(f.ID)); err == nil {
+		t.Errorf("GetFileByID() found the file, want it to stay in the trash")
+	}
+}
@@ -0,0 +1,147 @@
+package postgresql
+
+import (
+	"database/sql"
+	"github.com/go-kit/log"
+	"remy_explorer/internal/explorer/dto"
+	"strconv"
+	"testing"
+)
+
+// folderStats are the subtree totals of a folder maintained by the triggers.
+type folderStats struct {
+	TotalSize, FileCount, FolderCount int
+}
+
+// recountStats recomputes the totals of the folders of testOwner from scratch, the way 0011_folder_stats backfills them.
+const recountStats = `WITH RECURSIVE closure AS (
+    SELECT id AS ancestor_id, id AS folder_id FROM public.folder WHERE owner_id = $1
+    UNION ALL
+    SELECT c.ancestor_id, f.id FROM closure c JOIN public.folder f ON f.parent_id = c.folder_id
+), files AS (
+    SELECT c.ancestor_id, sum(f.size) AS total_size, count(*) AS file_count
+    FROM closure c JOIN public.file f ON f.folder_id = c.folder_id
+    WHERE f.deleted_at IS NULL
+    GROUP BY c.ancestor_id
+), folders AS (
+    SELECT c.ancestor_id, count(*) AS folder_count
+    FROM closure c JOIN public.folder f ON f.id = c.folder_id
+    WHERE c.folder_id <> c.ancestor_id AND f.deleted_at IS NULL
+    GROUP BY c.ancestor_id
+)
+SELECT x.id, COALESCE(fi.total_size, 0), COALESCE(fi.file_count, 0), COALESCE(fo.folder_count, 0)
+FROM public.folder x
+LEFT JOIN files fi ON fi.ancestor_id = x.id
+LEFT JOIN folders fo ON fo.ancestor_id = x.id
+WHERE x.owner_id = $1`
+
+func TestFolderStatsTriggers(t *testing.T) {
+	ctx, client := testDB(t)
+	files := NewFileRepo(client, log.NewNopLogger())
+	folders := NewFolderRepo(client, log.NewNopLogger())
+	trash := NewTrashRepo(client, log.NewNopLogger())
+	root, err := folders.EnsureRootFolder(ctx, testOwner)
+	if err != nil {
+		t.Fatalf("EnsureRootFolder() error = %v", err)
+	}
+	// root/a/b and root/c
+	a := createTestFolder(t, ctx, folders, root.ID, "a")
+	b := createTestFolder(t, ctx, folders, a.ID, "b")
+	c := createTestFolder(t, ctx, folders, root.ID, "c")
+	id := func(f *dto.FolderDTO) string { return strconv.Itoa(f.ID) }
+	all := []*dto.FolderDTO{root, a, b, c}
+
+	// The totals are read from the table, so folders in the trash are checked as well
+	check := func(step string, folders []*dto.FolderDTO, want ...folderStats) {
+		t.Helper()
+		for i, f := range folders {
+			var got folderStats
+			q := `SELECT total_size, file_count, folder_count FROM public.folder WHERE id = $1`
+			if err := conn(ctx, client).QueryRow(ctx, q, f.ID).Scan(&got.TotalSize, &got.FileCount, &got.FolderCount); err != nil {
+				t.Fatalf("%s: failed to read the totals of %s: %v", step, f.Name, err)
+			}
+			if got != want[i] {
+				t.Errorf("%s: totals of %s = %+v, want %+v", step, f.Name, got, want[i])
+			}
+		}
+	}
+	check("tree created", all, folderStats{0, 0, 3}, folderStats{0, 0, 1}, folderStats{}, folderStats{})
+
+	f := &dto.FileDTO{
+		Name:       "f.txt",
+		FolderID:   b.ID,
+		OwnerID:    testOwner,
+		ObjectPath: sql.NullString{String: "objects/f.txt", Valid: true},
+		Size:       10,
+		Type:       sql.NullString{String: "text/plain", Valid: true},
+		Tags:       []string{},
+	}
+	if _, err := files.CreateFile(ctx, f); err != nil {
+		t.Fatalf("CreateFile() error = %v", err)
+	}
+	check("file created in b", all, folderStats{10, 1, 3}, folderStats{10, 1, 1}, folderStats{10, 1, 0}, folderStats{})
+
+	f.ObjectPath = sql.NullString{String: "objects/f2.txt", Valid: true}
+	f.Size = 25
+	if _, err := files.UpdateFileContent(ctx, f); err != nil {
+		t.Fatalf("UpdateFileContent() error = %v", err)
+	}
+	check("file resized", all, folderStats{25, 1, 3}, folderStats{25, 1, 1}, folderStats{25, 1, 0}, folderStats{})
+
+	if err := files.MoveFile(ctx, strconv.Itoa(f.ID), id(c), f.Name); err != nil {
+		t.Fatalf("MoveFile() error = %v", err)
+	}
+	check("file moved to c", all, folderStats{25, 1, 3}, folderStats{0, 0, 1}, folderStats{}, folderStats{25, 1, 0})
+
+	// The moved folder takes the totals of its subtree along
+	if err := folders.MoveFolder(ctx, id(c), id(b), c.Name); err != nil {
+		t.Fatalf("MoveFolder() error = %v", err)
+	}
+	check("c moved into b", all, folderStats{25, 1, 3}, folderStats{25, 1, 2}, folderStats{25, 1, 1}, folderStats{25, 1, 0})
+
+	// Every trashed item is subtracted by its own row, so the trashed folders end up empty as well
+	if err := folders.TrashFolderRecursive(ctx, id(a)); err != nil {
+		t.Fatalf("TrashFolderRecursive() error = %v", err)
+	}
+	check("a trashed", all, folderStats{}, folderStats{}, folderStats{}, folderStats{})
+
+	if err := trash.RestoreFolder(ctx, id(a), id(root), a.Name); err != nil {
+		t.Fatalf("RestoreFolder() error = %v", err)
+	}
+	check("a restored", all, folderStats{25, 1, 3}, folderStats{25, 1, 2}, folderStats{25, 1, 1}, folderStats{25, 1, 0})
+
+	if err := folders.TrashFolderRecursive(ctx, id(b)); err != nil {
+		t.Fatalf("TrashFolderRecursive() error = %v", err)
+	}
+	check("b trashed", all, folderStats{0, 0, 1}, folderStats{}, folderStats{}, folderStats{})
+
+	// Purging items already subtracted when they were trashed changes nothing
+	if _, err := trash.PurgeFolder(ctx, id(b)); err != nil {
+		t.Fatalf("PurgeFolder() error = %v", err)
+	}
+	check("b purged", []*dto.FolderDTO{root, a}, folderStats{0, 0, 1}, folderStats{})
+
+	// The triggers agree with a count from scratch
+	rows, err := conn(ctx, client).Query(ctx, recountStats, testOwner)
+	if err != nil {
+		t.Fatalf("failed to recount the totals: %v", err)
+	}
+	recount := make(map[int]folderStats)
+	for rows.Next() {
+		var folderID int
+		var want folderStats
+		if err := rows.Scan(&folderID, &want.TotalSize, &want.FileCount, &want.FolderCount); err != nil {
+			t.Fatalf("failed to scan the recount: %v", err)
+		}
+		recount[folderID] = want
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("failed to recount the totals: %v", err)
+	}
+	if len(recount) != 2 {
+		t.Errorf("recount found %d folders, want root and a", len(recount))
+	}
+	for _, f := range []*dto.FolderDTO{root, a} {
+		check("recount", []*dto.FolderDTO{f}, recount[f.ID])
+	}
+}
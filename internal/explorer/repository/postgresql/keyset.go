@@ -0,0 +1,40 @@
+package postgresql
+
+import (
+	"fmt"
+	"remy_explorer/internal/explorer/dto"
+)
+
+// sortColumnTypes are the SQL types the cursor values of the sortable columns are cast to.
+// The column names come from a validated dto.SortOption, never from the client directly.
+var sortColumnTypes = map[string]string{
+	"name":       "TEXT",
+	"size":       "BIGINT",
+	"created_at": "TIMESTAMP",
+	"updated_at": "TIMESTAMP",
+}
+
+// keyset returns the condition skipping all rows up to the page cursor and the matching ORDER BY list.
+// Rows are ordered by the sort field with id breaking ties, so the order is total and stable under inserts.
+// Placeholders are numbered from $argN; args holds the values to bind to them.
+func keyset(page *dto.PageRequest, argN int) (cond, orderBy string, args []any) {
+	sort := page.Sort
+	cmp := ">"
+	if sort.Order == dto.Descending {
+		cmp = "<"
+	}
+	if sort.Field == "id" {
+		orderBy = fmt.Sprintf("id %s", sort.Order)
+		if page.After == nil {
+			return "TRUE", orderBy, nil
+		}
+		return fmt.Sprintf("id %s $%d", cmp, argN), orderBy, []any{page.After.ID}
+	}
+
+	orderBy = fmt.Sprintf("%s %s, id %s", sort.Field, sort.Order, sort.Order)
+	if page.After == nil {
+		return "TRUE", orderBy, nil
+	}
+	cond = fmt.Sprintf("(%s, id) %s ($%d::%s, $%d)", sort.Field, cmp, argN, sortColumnTypes[sort.Field], argN+1)
+	return cond, orderBy, []any{page.After.Value, page.After.ID}
+}
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.file;
+DROP TABLE IF EXISTS public.folder;
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS public.folder
+(
+    id         BIGSERIAL PRIMARY KEY,
+    owner_id   BIGINT                  NOT NULL,
+    name       VARCHAR(255)            NOT NULL,
+    parent_id  BIGINT,
+    created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMP DEFAULT NOW() NOT NULL,
+    CONSTRAINT fk_folder_parent_id FOREIGN KEY (parent_id) REFERENCES public.folder (id)
+);
+
+CREATE TABLE IF NOT EXISTS public.file
+(
+    id          BIGSERIAL PRIMARY KEY,
+    owner_id    BIGINT                  NOT NULL,
+    name        VARCHAR(255)            NOT NULL,
+    folder_id   BIGINT                  NOT NULL,
+    object_path VARCHAR(255)            NOT NULL,
+    size        INT                     NOT NULL,
+    type        VARCHAR(255)            NOT NULL,
+    created_at  TIMESTAMP DEFAULT NOW() NOT NULL,
+    updated_at  TIMESTAMP DEFAULT NOW() NOT NULL,
+    tags        JSONB,
+    CONSTRAINT fk_file_folder_id FOREIGN KEY (folder_id) REFERENCES public.folder (id)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS public.ix_file_folder_id;
+DROP INDEX IF EXISTS public.ix_folder_parent_id;
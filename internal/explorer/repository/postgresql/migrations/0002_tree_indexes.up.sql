@@ -0,0 +1,3 @@
+-- Children lookups used by listings, recursive subtree queries and tree counts
+CREATE INDEX IF NOT EXISTS ix_folder_parent_id ON public.folder (parent_id);
+CREATE INDEX IF NOT EXISTS ix_file_folder_id ON public.file (folder_id);
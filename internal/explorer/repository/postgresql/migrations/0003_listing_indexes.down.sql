@@ -0,0 +1,4 @@
+CREATE INDEX IF NOT EXISTS ix_folder_parent_id ON public.folder (parent_id);
+CREATE INDEX IF NOT EXISTS ix_file_folder_id ON public.file (folder_id);
+DROP INDEX IF EXISTS public.ix_file_folder_id_id;
+DROP INDEX IF EXISTS public.ix_folder_parent_id_id;
@@ -0,0 +1,5 @@
+-- Keyset pagination walks children in (parent, id) order; these indexes also serve plain parent lookups
+CREATE INDEX IF NOT EXISTS ix_folder_parent_id_id ON public.folder (parent_id, id);
+CREATE INDEX IF NOT EXISTS ix_file_folder_id_id ON public.file (folder_id, id);
+DROP INDEX IF EXISTS public.ix_folder_parent_id;
+DROP INDEX IF EXISTS public.ix_file_folder_id;
@@ -0,0 +1,3 @@
+-- Listings sorted by name page through (parent, name, id)
+CREATE INDEX IF NOT EXISTS ix_folder_parent_id_name_id ON public.folder (parent_id, name, id);
+CREATE INDEX IF NOT EXISTS ix_file_folder_id_name_id ON public.file (folder_id, name, id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS public.ux_folder_parent_id_name;
+DROP INDEX IF EXISTS public.ux_file_folder_id_name;
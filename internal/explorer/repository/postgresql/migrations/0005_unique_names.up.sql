@@ -0,0 +1,15 @@
+-- Existing duplicates get the row id appended, so the unique indexes can be built
+WITH dup AS (
+    SELECT id, row_number() OVER (PARTITION BY folder_id, name ORDER BY id) AS n FROM public.file
+)
+UPDATE public.file f SET name = f.name || ' (' || f.id || ')' FROM dup WHERE f.id = dup.id AND dup.n > 1;
+
+WITH dup AS (
+    SELECT id, row_number() OVER (PARTITION BY parent_id, name ORDER BY id) AS n
+    FROM public.folder WHERE parent_id IS NOT NULL
+)
+UPDATE public.folder f SET name = f.name || ' (' || f.id || ')' FROM dup WHERE f.id = dup.id AND dup.n > 1;
+
+CREATE UNIQUE INDEX ux_file_folder_id_name ON public.file (folder_id, name);
+CREATE UNIQUE INDEX ux_folder_parent_id_name ON public.folder (parent_id, name);
+
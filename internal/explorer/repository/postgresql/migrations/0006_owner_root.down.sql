@@ -0,0 +1,2 @@
+-- The root folders are kept as ordinary top-level folders
+DROP INDEX IF EXISTS public.ux_folder_owner_id_root;
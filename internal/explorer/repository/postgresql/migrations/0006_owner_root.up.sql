@@ -0,0 +1,17 @@
+-- Every owner gets a single root folder, the folders that used to be top-level move into it.
+-- Top-level duplicates get the row id appended first, as they become siblings.
+WITH dup AS (
+    SELECT id, row_number() OVER (PARTITION BY owner_id, name ORDER BY id) AS n
+    FROM public.folder WHERE parent_id IS NULL
+)
+UPDATE public.folder f SET name = f.name || ' (' || f.id || ')' FROM dup WHERE f.id = dup.id AND dup.n > 1;
+
+-- The UPDATE does not see the rows inserted by the CTE, so the new roots stay top-level
+WITH root AS (
+    INSERT INTO public.folder (owner_id, name)
+    SELECT DISTINCT owner_id, 'root' FROM public.folder WHERE parent_id IS NULL
+    RETURNING id, owner_id
+)
+UPDATE public.folder f SET parent_id = root.id FROM root WHERE f.owner_id = root.owner_id AND f.parent_id IS NULL;
+
+CREATE UNIQUE INDEX ux_folder_owner_id_root ON public.folder (owner_id) WHERE parent_id IS NULL;
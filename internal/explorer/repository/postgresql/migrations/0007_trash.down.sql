@@ -0,0 +1,24 @@
+-- Items still in the trash are deleted for good
+DROP INDEX IF EXISTS public.ix_file_owner_id_deleted_at;
+DROP INDEX IF EXISTS public.ix_folder_owner_id_deleted_at;
+
+WITH RECURSIVE trashed AS (
+    SELECT id FROM public.folder WHERE deleted_at IS NOT NULL
+    UNION
+    SELECT f.id FROM public.folder f JOIN trashed t ON f.parent_id = t.id
+)
+DELETE FROM public.file WHERE deleted_at IS NOT NULL OR folder_id IN (SELECT id FROM trashed);
+WITH RECURSIVE trashed AS (
+    SELECT id FROM public.folder WHERE deleted_at IS NOT NULL
+    UNION
+    SELECT f.id FROM public.folder f JOIN trashed t ON f.parent_id = t.id
+)
+DELETE FROM public.folder WHERE id IN (SELECT id FROM trashed);
+
+DROP INDEX public.ux_folder_parent_id_name;
+DROP INDEX public.ux_file_folder_id_name;
+CREATE UNIQUE INDEX ux_folder_parent_id_name ON public.folder (parent_id, name);
+CREATE UNIQUE INDEX ux_file_folder_id_name ON public.file (folder_id, name);
+
+ALTER TABLE public.file DROP COLUMN deleted_at;
+ALTER TABLE public.folder DROP COLUMN deleted_at;
@@ -0,0 +1,12 @@
+-- Deleted items stay in the trash until purged; all items trashed together share the same deleted_at
+ALTER TABLE public.folder ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE public.file ADD COLUMN deleted_at TIMESTAMP;
+
+-- Names only have to be unique among the items that are not in the trash
+DROP INDEX public.ux_folder_parent_id_name;
+DROP INDEX public.ux_file_folder_id_name;
+CREATE UNIQUE INDEX ux_folder_parent_id_name ON public.folder (parent_id, name) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX ux_file_folder_id_name ON public.file (folder_id, name) WHERE deleted_at IS NULL;
+
+CREATE INDEX ix_folder_owner_id_deleted_at ON public.folder (owner_id, deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX ix_file_owner_id_deleted_at ON public.file (owner_id, deleted_at) WHERE deleted_at IS NOT NULL;
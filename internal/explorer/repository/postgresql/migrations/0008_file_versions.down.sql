@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.file_version;
+ALTER TABLE public.file DROP COLUMN content_version;
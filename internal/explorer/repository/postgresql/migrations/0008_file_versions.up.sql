@@ -0,0 +1,18 @@
+-- Every content of a file is kept as a version, content_version is the number of the current one
+ALTER TABLE public.file ADD COLUMN content_version INT DEFAULT 1 NOT NULL;
+
+CREATE TABLE IF NOT EXISTS public.file_version
+(
+    file_id     BIGINT                  NOT NULL,
+    version     INT                     NOT NULL,
+    object_path VARCHAR(255)            NOT NULL,
+    size        INT                     NOT NULL,
+    type        VARCHAR(255)            NOT NULL,
+    created_at  TIMESTAMP DEFAULT NOW() NOT NULL,
+    CONSTRAINT pk_file_version PRIMARY KEY (file_id, version),
+    CONSTRAINT fk_file_version_file_id FOREIGN KEY (file_id) REFERENCES public.file (id) ON DELETE CASCADE
+);
+
+-- Existing files start their history with their current content
+INSERT INTO public.file_version (file_id, version, object_path, size, type, created_at)
+SELECT id, 1, object_path, size, type, updated_at FROM public.file;
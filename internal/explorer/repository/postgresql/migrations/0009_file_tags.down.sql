@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS public.ix_file_tags;
+
+ALTER TABLE public.file DROP CONSTRAINT ck_file_tags_array;
+ALTER TABLE public.file ALTER COLUMN tags DROP NOT NULL;
+ALTER TABLE public.file ALTER COLUMN tags DROP DEFAULT;
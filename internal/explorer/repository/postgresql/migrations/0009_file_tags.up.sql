@@ -0,0 +1,14 @@
+-- Tags are a JSON array of distinct normalized strings: trimmed, lower case, inner whitespace collapsed
+UPDATE public.file SET tags = '[]' WHERE tags IS NULL OR jsonb_typeof(tags) <> 'array';
+UPDATE public.file f SET tags = COALESCE((
+    SELECT jsonb_agg(DISTINCT t.tag ORDER BY t.tag)
+    FROM (SELECT lower(regexp_replace(btrim(e), '\s+', ' ', 'g')) AS tag FROM jsonb_array_elements_text(f.tags) e) t
+    WHERE t.tag <> ''
+), '[]');
+
+ALTER TABLE public.file ALTER COLUMN tags SET DEFAULT '[]';
+ALTER TABLE public.file ALTER COLUMN tags SET NOT NULL;
+ALTER TABLE public.file ADD CONSTRAINT ck_file_tags_array CHECK (jsonb_typeof(tags) = 'array');
+
+-- Serves the files by tag lookups, which use the containment operator
+CREATE INDEX ix_file_tags ON public.file USING GIN (tags jsonb_path_ops);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS public.ix_file_name_trgm;
+DROP INDEX IF EXISTS public.ix_folder_name_trgm;
+
+-- The pg_trgm extension is kept, other objects of the database may use it
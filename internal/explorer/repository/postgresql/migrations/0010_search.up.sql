@@ -0,0 +1,5 @@
+-- Name search matches substrings and similar names with trigrams
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX ix_folder_name_trgm ON public.folder USING GIN (lower(name) gin_trgm_ops) WHERE deleted_at IS NULL;
+CREATE INDEX ix_file_name_trgm ON public.file USING GIN (lower(name) gin_trgm_ops) WHERE deleted_at IS NULL;
@@ -0,0 +1,12 @@
+DROP TRIGGER IF EXISTS tr_folder_stats ON public.folder;
+DROP TRIGGER IF EXISTS tr_file_stats ON public.file;
+DROP FUNCTION IF EXISTS public.folder_stats_trigger();
+DROP FUNCTION IF EXISTS public.file_stats_trigger();
+DROP FUNCTION IF EXISTS public.folder_stats_add(BIGINT, BIGINT, INT, INT);
+
+ALTER TABLE public.folder DROP COLUMN folder_count;
+ALTER TABLE public.folder DROP COLUMN file_count;
+ALTER TABLE public.folder DROP COLUMN total_size;
+
+ALTER TABLE public.file_version ALTER COLUMN size TYPE INT;
+ALTER TABLE public.file ALTER COLUMN size TYPE INT;
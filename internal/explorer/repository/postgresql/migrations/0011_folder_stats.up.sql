@@ -0,0 +1,109 @@
+-- Sizes may exceed 2 GiB, and so may the folder totals summing them
+ALTER TABLE public.file ALTER COLUMN size TYPE BIGINT;
+ALTER TABLE public.file_version ALTER COLUMN size TYPE BIGINT;
+
+-- Every folder carries the totals of its subtree: the size and number of the files below it
+-- and the number of the folders below it, itself excluded. Only items outside the trash are counted.
+ALTER TABLE public.folder ADD COLUMN total_size BIGINT DEFAULT 0 NOT NULL;
+ALTER TABLE public.folder ADD COLUMN file_count INT DEFAULT 0 NOT NULL;
+ALTER TABLE public.folder ADD COLUMN folder_count INT DEFAULT 0 NOT NULL;
+
+WITH RECURSIVE closure AS (
+    SELECT id AS ancestor_id, id AS folder_id FROM public.folder
+    UNION ALL
+    SELECT c.ancestor_id, f.id FROM closure c JOIN public.folder f ON f.parent_id = c.folder_id
+), files AS (
+    SELECT c.ancestor_id, sum(f.size) AS total_size, count(*) AS file_count
+    FROM closure c JOIN public.file f ON f.folder_id = c.folder_id
+    WHERE f.deleted_at IS NULL
+    GROUP BY c.ancestor_id
+), folders AS (
+    SELECT c.ancestor_id, count(*) AS folder_count
+    FROM closure c JOIN public.folder f ON f.id = c.folder_id
+    WHERE c.folder_id <> c.ancestor_id AND f.deleted_at IS NULL
+    GROUP BY c.ancestor_id
+)
+UPDATE public.folder f
+SET total_size = COALESCE(fi.total_size, 0), file_count = COALESCE(fi.file_count, 0), folder_count = COALESCE(fo.folder_count, 0)
+FROM public.folder x
+LEFT JOIN files fi ON fi.ancestor_id = x.id
+LEFT JOIN folders fo ON fo.ancestor_id = x.id
+WHERE f.id = x.id;
+
+-- folder_stats_add adds a delta to the totals of a folder and of all its ancestors.
+-- The rows are locked in id order, so concurrent updates of a shared chain do not deadlock each other.
+CREATE FUNCTION public.folder_stats_add(start BIGINT, d_size BIGINT, d_files INT, d_folders INT) RETURNS VOID AS $$
+BEGIN
+    IF start IS NULL OR (d_size = 0 AND d_files = 0 AND d_folders = 0) THEN
+        RETURN;
+    END IF;
+    WITH RECURSIVE chain AS (
+        SELECT id, parent_id FROM public.folder WHERE id = start
+        UNION ALL
+        SELECT f.id, f.parent_id FROM public.folder f JOIN chain c ON f.id = c.parent_id
+    ), locked AS (
+        SELECT id FROM public.folder WHERE id IN (SELECT id FROM chain) ORDER BY id FOR UPDATE
+    )
+    UPDATE public.folder
+    SET total_size = total_size + d_size, file_count = file_count + d_files, folder_count = folder_count + d_folders
+    WHERE id IN (SELECT id FROM locked);
+END
+$$ LANGUAGE plpgsql;
+
+-- A file outside the trash counts in the totals of its folder and of all the ancestors of that folder.
+CREATE FUNCTION public.file_stats_trigger() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND OLD.folder_id = NEW.folder_id AND OLD.deleted_at IS NOT DISTINCT FROM NEW.deleted_at THEN
+        IF NEW.deleted_at IS NULL THEN
+            PERFORM public.folder_stats_add(NEW.folder_id, NEW.size - OLD.size, 0, 0);
+        END IF;
+        RETURN NULL;
+    END IF;
+    IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.deleted_at IS NULL THEN
+        PERFORM public.folder_stats_add(OLD.folder_id, -OLD.size, -1, 0);
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.deleted_at IS NULL THEN
+        PERFORM public.folder_stats_add(NEW.folder_id, NEW.size, 1, 0);
+    END IF;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+-- A folder outside the trash counts once in the totals of all its ancestors. A moved folder takes its totals along.
+-- Items trashed or restored together are each counted by their own row, so a statement must not both move
+-- a folder and change whether its descendants are in the trash.
+CREATE FUNCTION public.folder_stats_trigger() RETURNS TRIGGER AS $$
+DECLARE
+    old_live INT := 0;
+    new_live INT := 0;
+    moved    public.folder%ROWTYPE;
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.deleted_at IS NULL THEN
+        old_live := 1;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.deleted_at IS NULL THEN
+        new_live := 1;
+    END IF;
+    IF TG_OP = 'UPDATE' AND OLD.parent_id IS DISTINCT FROM NEW.parent_id THEN
+        SELECT * INTO moved FROM public.folder WHERE id = NEW.id;
+        PERFORM public.folder_stats_add(OLD.parent_id, -moved.total_size, -moved.file_count, -moved.folder_count - old_live);
+        PERFORM public.folder_stats_add(NEW.parent_id, moved.total_size, moved.file_count, moved.folder_count + new_live);
+        RETURN NULL;
+    END IF;
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        PERFORM public.folder_stats_add(OLD.parent_id, 0, 0, -old_live);
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        PERFORM public.folder_stats_add(NEW.parent_id, 0, 0, new_live);
+    END IF;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tr_file_stats
+    AFTER INSERT OR UPDATE OF size, folder_id, deleted_at OR DELETE ON public.file
+    FOR EACH ROW EXECUTE FUNCTION public.file_stats_trigger();
+
+CREATE TRIGGER tr_folder_stats
+    AFTER INSERT OR UPDATE OF parent_id, deleted_at OR DELETE ON public.folder
+    FOR EACH ROW EXECUTE FUNCTION public.folder_stats_trigger();
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS public.ix_file_owner_id;
+DROP TABLE IF EXISTS public.owner_quota;
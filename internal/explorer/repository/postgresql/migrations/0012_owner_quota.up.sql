@@ -0,0 +1,14 @@
+-- Quota overrides; an owner without a row, or a NULL limit, gets the default limit from the configuration.
+-- A zero limit means unlimited.
+CREATE TABLE IF NOT EXISTS public.owner_quota
+(
+    owner_id   BIGINT PRIMARY KEY,
+    max_bytes  BIGINT,
+    max_files  INT,
+    updated_at TIMESTAMP DEFAULT NOW() NOT NULL,
+    CONSTRAINT ck_owner_quota_max_bytes CHECK (max_bytes >= 0),
+    CONSTRAINT ck_owner_quota_max_files CHECK (max_files >= 0)
+);
+
+-- Usage sums the files of an owner, in the trash or not
+CREATE INDEX IF NOT EXISTS ix_file_owner_id ON public.file (owner_id);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS public.ix_file_version_object_path;
@@ -0,0 +1,3 @@
+-- Copied files share the stored objects of the original ones, so an object can only be purged
+-- once no version of any file refers to it any more
+CREATE INDEX IF NOT EXISTS ix_file_version_object_path ON public.file_version (object_path);
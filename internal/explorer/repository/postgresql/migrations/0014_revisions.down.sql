@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS tr_file_revision ON public.file;
+DROP TRIGGER IF EXISTS tr_folder_revision ON public.folder;
+DROP FUNCTION IF EXISTS public.revision_trigger();
+ALTER TABLE public.file DROP COLUMN IF EXISTS revision;
+ALTER TABLE public.folder DROP COLUMN IF EXISTS revision;
@@ -0,0 +1,19 @@
+-- Every change of a folder or a file increments its revision. Clients send the revision back in If-Match
+-- to make sure nobody changed the item since they read it. The subtree totals of a folder do not count as a change.
+ALTER TABLE public.folder ADD COLUMN revision INT DEFAULT 1 NOT NULL;
+ALTER TABLE public.file ADD COLUMN revision INT DEFAULT 1 NOT NULL;
+
+CREATE FUNCTION public.revision_trigger() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.revision := OLD.revision + 1;
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tr_folder_revision
+    BEFORE UPDATE OF name, parent_id, deleted_at ON public.folder
+    FOR EACH ROW EXECUTE FUNCTION public.revision_trigger();
+
+CREATE TRIGGER tr_file_revision
+    BEFORE UPDATE OF name, folder_id, object_path, size, type, tags, deleted_at ON public.file
+    FOR EACH ROW EXECUTE FUNCTION public.revision_trigger();
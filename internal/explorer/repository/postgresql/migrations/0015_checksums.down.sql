@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS public.ix_file_owner_checksum;
+ALTER TABLE public.file_version DROP COLUMN IF EXISTS checksum;
+ALTER TABLE public.file_version DROP COLUMN IF EXISTS checksum_algorithm;
+ALTER TABLE public.file DROP CONSTRAINT IF EXISTS ck_file_checksum;
+ALTER TABLE public.file DROP COLUMN IF EXISTS checksum;
+ALTER TABLE public.file DROP COLUMN IF EXISTS checksum_algorithm;
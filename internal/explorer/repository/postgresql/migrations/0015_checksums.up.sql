@@ -0,0 +1,11 @@
+-- Optional checksum of the content of a file, given by the client, kept with every version
+-- so that restoring a version restores its checksum too
+ALTER TABLE public.file ADD COLUMN checksum_algorithm VARCHAR(16);
+ALTER TABLE public.file ADD COLUMN checksum VARCHAR(128);
+ALTER TABLE public.file ADD CONSTRAINT ck_file_checksum CHECK ((checksum IS NULL) = (checksum_algorithm IS NULL));
+ALTER TABLE public.file_version ADD COLUMN checksum_algorithm VARCHAR(16);
+ALTER TABLE public.file_version ADD COLUMN checksum VARCHAR(128);
+
+-- Duplicate lookups group the files of an owner by checksum
+CREATE INDEX IF NOT EXISTS ix_file_owner_checksum ON public.file (owner_id, checksum_algorithm, checksum)
+    WHERE checksum IS NOT NULL AND deleted_at IS NULL;
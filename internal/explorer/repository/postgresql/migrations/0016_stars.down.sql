@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS public.ix_file_owner_starred;
+DROP INDEX IF EXISTS public.ix_folder_owner_starred;
+ALTER TABLE public.file DROP COLUMN IF EXISTS starred_at;
+ALTER TABLE public.folder DROP COLUMN IF EXISTS starred_at;
@@ -0,0 +1,9 @@
+-- Owners star folders and files they want at hand; starring is not a change of the item, so it keeps its revision
+ALTER TABLE public.folder ADD COLUMN starred_at TIMESTAMP;
+ALTER TABLE public.file ADD COLUMN starred_at TIMESTAMP;
+
+-- The starred listing walks the starred items of an owner, most recently starred first
+CREATE INDEX IF NOT EXISTS ix_folder_owner_starred ON public.folder (owner_id, starred_at, id)
+    WHERE starred_at IS NOT NULL AND deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS ix_file_owner_starred ON public.file (owner_id, starred_at, id)
+    WHERE starred_at IS NOT NULL AND deleted_at IS NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS public.ix_file_owner_updated;
+DROP TABLE IF EXISTS public.file_access;
@@ -0,0 +1,15 @@
+-- The last time each file was opened; one row per file keeps the recent feed free of duplicates
+CREATE TABLE IF NOT EXISTS public.file_access
+(
+    file_id     BIGINT PRIMARY KEY,
+    owner_id    BIGINT    NOT NULL,
+    accessed_at TIMESTAMP NOT NULL,
+    CONSTRAINT fk_file_access_file_id FOREIGN KEY (file_id) REFERENCES public.file (id) ON DELETE CASCADE
+);
+
+-- The opened feed and the pruning of the history walk the opened files of an owner, most recent first
+CREATE INDEX IF NOT EXISTS ix_file_access_owner_accessed ON public.file_access (owner_id, accessed_at, file_id);
+
+-- The modified feed walks the files of an owner, most recently changed first
+CREATE INDEX IF NOT EXISTS ix_file_owner_updated ON public.file (owner_id, updated_at, id)
+    WHERE deleted_at IS NULL;
@@ -0,0 +1,19 @@
+-- Every change of a folder or file made through the API, written in the transaction of the change.
+-- Items are not referenced by foreign keys, so their history outlives them.
+CREATE TABLE IF NOT EXISTS public.audit_log
+(
+    id          BIGSERIAL PRIMARY KEY,
+    occurred_at TIMESTAMP DEFAULT NOW() NOT NULL,
+    actor       TEXT,
+    action      VARCHAR(16)             NOT NULL,
+    item_kind   VARCHAR(16)             NOT NULL,
+    item_id     BIGINT                  NOT NULL,
+    owner_id    BIGINT                  NOT NULL,
+    request_id  TEXT,
+    changes     JSONB                   NOT NULL
+);
+
+-- The audit listing walks the records newest first, for an item, an actor or a time range
+CREATE INDEX IF NOT EXISTS ix_audit_log_item ON public.audit_log (item_kind, item_id, id);
+CREATE INDEX IF NOT EXISTS ix_audit_log_actor ON public.audit_log (actor, id);
+CREATE INDEX IF NOT EXISTS ix_audit_log_occurred ON public.audit_log (occurred_at, id);
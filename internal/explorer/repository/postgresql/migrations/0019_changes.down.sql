@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS tr_file_changes ON public.file;
+DROP TRIGGER IF EXISTS tr_folder_changes ON public.folder;
+DROP FUNCTION IF EXISTS public.file_changes_trigger();
+DROP FUNCTION IF EXISTS public.folder_changes_trigger();
+DROP FUNCTION IF EXISTS public.change_log_action(TEXT, TIMESTAMP, TIMESTAMP, BOOLEAN, BOOLEAN);
+DROP FUNCTION IF EXISTS public.change_log_add(BIGINT, TEXT, TEXT, BIGINT, BIGINT, TEXT, INT);
+
+DROP TABLE IF EXISTS public.change_log;
+DROP TABLE IF EXISTS public.owner_change_seq;
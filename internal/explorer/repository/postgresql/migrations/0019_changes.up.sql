@@ -0,0 +1,139 @@
+-- The change feed of an owner: one row per create, update, move and delete of a folder or file, numbered by a
+-- per-owner sequence. The counter row of an owner stays locked until the changing transaction ends, so the
+-- changes of an owner are numbered in commit order and a client never misses one behind its cursor.
+CREATE TABLE IF NOT EXISTS public.owner_change_seq
+(
+    owner_id BIGINT PRIMARY KEY,
+    last_seq BIGINT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS public.change_log
+(
+    owner_id    BIGINT                  NOT NULL,
+    seq         BIGINT                  NOT NULL,
+    occurred_at TIMESTAMP DEFAULT NOW() NOT NULL,
+    action      VARCHAR(16)             NOT NULL,
+    item_kind   VARCHAR(16)             NOT NULL,
+    item_id     BIGINT                  NOT NULL,
+    parent_id   BIGINT,
+    name        VARCHAR(255)            NOT NULL,
+    revision    INT                     NOT NULL,
+    PRIMARY KEY (owner_id, seq)
+);
+
+-- change_log_add appends a change to the feed of an owner under the next number of its sequence.
+CREATE FUNCTION public.change_log_add(p_owner BIGINT, p_action TEXT, p_kind TEXT, p_item BIGINT, p_parent BIGINT, p_name TEXT, p_revision INT) RETURNS VOID AS $$
+DECLARE
+    next_seq BIGINT;
+BEGIN
+    INSERT INTO public.owner_change_seq AS s (owner_id, last_seq) VALUES (p_owner, 1)
+    ON CONFLICT (owner_id) DO UPDATE SET last_seq = s.last_seq + 1
+    RETURNING s.last_seq INTO next_seq;
+    INSERT INTO public.change_log (owner_id, seq, action, item_kind, item_id, parent_id, name, revision)
+    VALUES (p_owner, next_seq, p_action, p_kind, p_item, p_parent, p_name, p_revision);
+END
+$$ LANGUAGE plpgsql;
+
+-- change_log_action tells how a row change looks to a client of the feed, NULL when it does not see it:
+-- leaving the trash creates an item again, purging an item already in the trash changes nothing,
+-- and an update leaving the revision and the star alone only touched derived columns such as the subtree totals.
+CREATE FUNCTION public.change_log_action(op TEXT, old_deleted TIMESTAMP, new_deleted TIMESTAMP, moved BOOLEAN, changed BOOLEAN) RETURNS TEXT AS $$
+BEGIN
+    IF op = 'INSERT' THEN
+        RETURN CASE WHEN new_deleted IS NULL THEN 'create' END;
+    ELSIF op = 'DELETE' THEN
+        RETURN CASE WHEN old_deleted IS NULL THEN 'delete' END;
+    ELSIF old_deleted IS NULL AND new_deleted IS NOT NULL THEN
+        RETURN 'delete';
+    ELSIF old_deleted IS NOT NULL AND new_deleted IS NULL THEN
+        RETURN 'create';
+    ELSIF new_deleted IS NOT NULL THEN
+        RETURN NULL;
+    ELSIF moved THEN
+        RETURN 'move';
+    ELSIF changed THEN
+        RETURN 'update';
+    END IF;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+CREATE FUNCTION public.folder_changes_trigger() RETURNS TRIGGER AS $$
+DECLARE
+    act  TEXT;
+    item public.folder%ROWTYPE;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        act := public.change_log_action(TG_OP, NULL, NEW.deleted_at, FALSE, TRUE);
+        item := NEW;
+    ELSIF TG_OP = 'DELETE' THEN
+        act := public.change_log_action(TG_OP, OLD.deleted_at, NULL, FALSE, TRUE);
+        item := OLD;
+    ELSE
+        act := public.change_log_action(TG_OP, OLD.deleted_at, NEW.deleted_at,
+            OLD.parent_id IS DISTINCT FROM NEW.parent_id,
+            OLD.revision <> NEW.revision OR OLD.starred_at IS DISTINCT FROM NEW.starred_at);
+        item := NEW;
+    END IF;
+    IF act IS NOT NULL THEN
+        PERFORM public.change_log_add(item.owner_id, act, 'folder', item.id, item.parent_id, item.name, item.revision);
+    END IF;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE FUNCTION public.file_changes_trigger() RETURNS TRIGGER AS $$
+DECLARE
+    act  TEXT;
+    item public.file%ROWTYPE;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        act := public.change_log_action(TG_OP, NULL, NEW.deleted_at, FALSE, TRUE);
+        item := NEW;
+    ELSIF TG_OP = 'DELETE' THEN
+        act := public.change_log_action(TG_OP, OLD.deleted_at, NULL, FALSE, TRUE);
+        item := OLD;
+    ELSE
+        act := public.change_log_action(TG_OP, OLD.deleted_at, NEW.deleted_at,
+            OLD.folder_id <> NEW.folder_id,
+            OLD.revision <> NEW.revision OR OLD.starred_at IS DISTINCT FROM NEW.starred_at);
+        item := NEW;
+    END IF;
+    IF act IS NOT NULL THEN
+        PERFORM public.change_log_add(item.owner_id, act, 'file', item.id, item.folder_id, item.name, item.revision);
+    END IF;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+-- Named to fire before the subtree totals triggers, so the folders locked for the totals are locked
+-- after the counter of the owner
+CREATE TRIGGER tr_folder_changes
+    AFTER INSERT OR UPDATE OR DELETE ON public.folder
+    FOR EACH ROW EXECUTE FUNCTION public.folder_changes_trigger();
+
+CREATE TRIGGER tr_file_changes
+    AFTER INSERT OR UPDATE OR DELETE ON public.file
+    FOR EACH ROW EXECUTE FUNCTION public.file_changes_trigger();
+
+-- The feed starts with the creation of every item outside the trash, parents before children,
+-- so a client reading it from the start builds the whole tree
+WITH RECURSIVE tree AS (
+    SELECT id, 0 AS depth FROM public.folder WHERE parent_id IS NULL
+    UNION ALL
+    SELECT f.id, t.depth + 1 FROM public.folder f JOIN tree t ON f.parent_id = t.id
+), items AS (
+    SELECT f.owner_id, 'folder' AS kind, f.id, f.parent_id, f.name, f.revision, 0 AS pass, t.depth
+    FROM public.folder f JOIN tree t ON t.id = f.id
+    WHERE f.deleted_at IS NULL
+    UNION ALL
+    SELECT owner_id, 'file', id, folder_id, name, revision, 1, 0
+    FROM public.file
+    WHERE deleted_at IS NULL
+)
+INSERT INTO public.change_log (owner_id, seq, action, item_kind, item_id, parent_id, name, revision)
+SELECT owner_id, row_number() OVER (PARTITION BY owner_id ORDER BY pass, depth, id), 'create', kind, id, parent_id, name, revision
+FROM items;
+
+INSERT INTO public.owner_change_seq (owner_id, last_seq)
+SELECT owner_id, max(seq) FROM public.change_log GROUP BY owner_id;
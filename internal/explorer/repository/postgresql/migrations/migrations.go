@@ -0,0 +1,13 @@
+// Package migrations embeds the versioned SQL schema migrations into the binary.
+//
+// Every migration is a pair of files named <version>_<name>.up.sql and
+// <version>_<name>.down.sql, where version is a zero-padded integer.
+// Migrations are applied in ascending version order by postgresql.Migrator.
+package migrations
+
+import "embed"
+
+// FS contains all migration files shipped with the service.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,245 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/jackc/pgx/v5"
+	"io/fs"
+	"regexp"
+	"remy_explorer/internal/explorer/repository/postgresql/migrations"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// migrationLockID is the key of the advisory lock that serializes concurrent migration runs.
+const migrationLockID = 7_325_001
+
+var migrationFileRe = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is a single versioned schema change.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus describes whether a migration has been applied to the database.
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// Migrator applies the embedded schema migrations and records them in public.schema_migrations.
+type Migrator struct {
+	client     Client
+	migrations []Migration
+	log        log.Logger
+}
+
+// NewMigrator creates a Migrator for the migrations embedded into the binary.
+func NewMigrator(client Client, logger log.Logger) (*Migrator, error) {
+	ms, err := loadMigrations(migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		client:     client,
+		migrations: ms,
+		log:        log.With(logger, "component", "migrator"),
+	}, nil
+}
+
+// loadMigrations reads the up/down pairs from fsys and returns them sorted by version.
+func loadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+	byVersion := make(map[int]*Migration)
+	for _, e := range entries {
+		m := migrationFileRe.FindStringSubmatch(e.Name())
+		if m == nil {
+			continue
+		}
+		version, _ := strconv.Atoi(m[1])
+		body, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", e.Name(), err)
+		}
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		} else if mig.Name != m[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, mig.Name, m[2])
+		}
+		if m[3] == "up" {
+			mig.Up = string(body)
+		} else {
+			mig.Down = string(body)
+		}
+	}
+	result := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" || mig.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both up and down files", mig.Version, mig.Name)
+		}
+		result = append(result, *mig)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+	return result, nil
+}
+
+// ensureTable creates the bookkeeping table if it does not exist yet.
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	q := `CREATE TABLE IF NOT EXISTS public.schema_migrations
+(
+    version    BIGINT PRIMARY KEY,
+    name       VARCHAR(255)            NOT NULL,
+    applied_at TIMESTAMP DEFAULT NOW() NOT NULL
+)`
+	if _, err := m.client.Exec(ctx, q); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+	return nil
+}
+
+// applied returns the applied versions with their application time.
+func (m *Migrator) applied(ctx context.Context, c Client) (map[int]time.Time, error) {
+	rows, err := c.Query(ctx, `SELECT version, applied_at FROM public.schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	defer rows.Close()
+	result := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan applied migration: %w", err)
+		}
+		result[version] = appliedAt
+	}
+	return result, rows.Err()
+}
+
+// Status reports every known migration along with the time it was applied, if any.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+	applied, err := m.applied(ctx, m.client)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]MigrationStatus, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		st := MigrationStatus{Migration: mig}
+		if at, ok := applied[mig.Version]; ok {
+			st.AppliedAt = &at
+		}
+		result = append(result, st)
+	}
+	return result, nil
+}
+
+// Up applies all pending migrations in version order and returns how many were applied.
+// Each migration runs in its own transaction, so a failing migration leaves the previous ones in place.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, mig := range m.migrations {
+		done, err := m.step(ctx, mig, true)
+		if err != nil {
+			return count, err
+		}
+		if done {
+			count++
+		}
+	}
+	level.Info(m.log).Log("message", "Migrations applied", "count", count)
+	return count, nil
+}
+
+// Down rolls back the given number of most recently applied migrations and returns how many were rolled back.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return 0, err
+	}
+	applied, err := m.applied(ctx, m.client)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for i := len(m.migrations) - 1; i >= 0 && count < steps; i-- {
+		mig := m.migrations[i]
+		if _, ok := applied[mig.Version]; !ok {
+			continue
+		}
+		done, err := m.step(ctx, mig, false)
+		if err != nil {
+			return count, err
+		}
+		if done {
+			count++
+		}
+	}
+	level.Info(m.log).Log("message", "Migrations rolled back", "count", count)
+	return count, nil
+}
+
+// step applies (up) or reverts (down) a single migration inside a transaction.
+// It returns false if there was nothing to do because another run got there first.
+func (m *Migrator) step(ctx context.Context, mig Migration, up bool) (done bool, err error) {
+	tx, err := m.client.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin migration transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+	if _, err = tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, migrationLockID); err != nil {
+		return false, fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	var exists bool
+	err = tx.QueryRow(ctx, `SELECT true FROM public.schema_migrations WHERE version = $1`, mig.Version).Scan(&exists)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return false, fmt.Errorf("failed to check migration %d: %w", mig.Version, err)
+	}
+	err = nil
+	if exists == up {
+		return false, tx.Rollback(ctx)
+	}
+
+	logger := log.With(m.log, "version", mig.Version, "name", mig.Name)
+	if up {
+		level.Info(logger).Log("message", "Applying migration")
+		if _, err = tx.Exec(ctx, mig.Up); err != nil {
+			return false, fmt.Errorf("migration %d_%s failed: %w", mig.Version, mig.Name, err)
+		}
+		if _, err = tx.Exec(ctx, `INSERT INTO public.schema_migrations (version, name) VALUES ($1, $2)`, mig.Version, mig.Name); err != nil {
+			return false, fmt.Errorf("failed to record migration %d: %w", mig.Version, err)
+		}
+	} else {
+		level.Info(logger).Log("message", "Reverting migration")
+		if _, err = tx.Exec(ctx, mig.Down); err != nil {
+			return false, fmt.Errorf("rollback of migration %d_%s failed: %w", mig.Version, mig.Name, err)
+		}
+		if _, err = tx.Exec(ctx, `DELETE FROM public.schema_migrations WHERE version = $1`, mig.Version); err != nil {
+			return false, fmt.Errorf("failed to unrecord migration %d: %w", mig.Version, err)
+		}
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit migration %d: %w", mig.Version, err)
+	}
+	return true, nil
+}
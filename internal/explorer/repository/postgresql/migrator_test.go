@@ -0,0 +1,73 @@
+package postgresql
+
+import (
+	"remy_explorer/internal/explorer/repository/postgresql/migrations"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadMigrationsOrdersByVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0010_late.up.sql":    {Data: []byte("up 10")},
+		"0010_late.down.sql":  {Data: []byte("down 10")},
+		"0002_early.up.sql":   {Data: []byte("up 2")},
+		"0002_early.down.sql": {Data: []byte("down 2")},
+		"0001_init.up.sql":    {Data: []byte("up 1")},
+		"0001_init.down.sql":  {Data: []byte("down 1")},
+		"README.md":           {Data: []byte("not a migration")},
+		"migrations.go":       {Data: []byte("package migrations")},
+	}
+	ms, err := loadMigrations(fsys)
+	if err != nil {
+		t.Fatalf("loadMigrations() error = %v", err)
+	}
+	want := []Migration{
+		{Version: 1, Name: "init", Up: "up 1", Down: "down 1"},
+		{Version: 2, Name: "early", Up: "up 2", Down: "down 2"},
+		{Version: 10, Name: "late", Up: "up 10", Down: "down 10"},
+	}
+	if len(ms) != len(want) {
+		t.Fatalf("loadMigrations() = %+v, want %+v", ms, want)
+	}
+	for i := range want {
+		if ms[i] != want[i] {
+			t.Errorf("migration %d = %+v, want %+v", i, ms[i], want[i])
+		}
+	}
+}
+
+func TestLoadMigrationsRejectsBrokenPairs(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"missing down": {
+			"0001_init.up.sql": {Data: []byte("up")},
+		},
+		"missing up": {
+			"0001_init.down.sql": {Data: []byte("down")},
+		},
+		"conflicting names": {
+			"0001_init.up.sql":    {Data: []byte("up")},
+			"0001_other.down.sql": {Data: []byte("down")},
+		},
+	}
+	for name, fsys := range tests {
+		if _, err := loadMigrations(fsys); err == nil {
+			t.Errorf("%s: loadMigrations() returned no error", name)
+		}
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	ms, err := loadMigrations(migrations.FS)
+	if err != nil {
+		t.Fatalf("loadMigrations() error = %v", err)
+	}
+	for i, m := range ms {
+		if m.Version != i+1 {
+			t.Errorf("migration %d_%s has version %d, want %d: versions must have no gaps", m.Version, m.Name, m.Version, i+1)
+		}
+		if strings.TrimSpace(m.Up) == "" || strings.TrimSpace(m.Down) == "" {
+			t.Errorf("migration %d_%s has an empty up or down file", m.Version, m.Name)
+		}
+	}
+}
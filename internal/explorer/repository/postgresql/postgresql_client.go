@@ -2,6 +2,7 @@ package postgresql
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -12,6 +13,11 @@ import (
 	"time"
 )
 
+// SQLSTATE codes of the PostgreSQL errors that are mapped to typed errors.
+const (
+	uniqueViolation = "23505"
+)
+
 // Client is a subset of the pgx.Conn interface.
 // It provides methods for executing SQL queries and transactions.
 type Client interface {
@@ -26,6 +32,10 @@ type Client interface {
 	// QueryRow sends a query to the database and returns a single row.
 	// The args are for any placeholder parameters in the query.
 	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+
+	// Begin starts a transaction.
+	// Calling Begin on a transaction creates a savepoint.
+	Begin(ctx context.Context) (pgx.Tx, error)
 }
 
 // NewClient creates a new Client from a pgx.Conn.
@@ -56,28 +66,17 @@ func NewClient(ctx context.Context, conn config.StorageConfig, maxAttempts int)
 	return pool, nil
 }
 
-//CREATE TABLE IF NOT EXISTS public.folder
-//(
-// id         BIGSERIAL PRIMARY KEY,
-// owner_id   BIGINT                  NOT NULL,
-// name       VARCHAR(255)            NOT NULL,
-// parent_id  BIGINT,
-// created_at TIMESTAMP DEFAULT NOW() NOT NULL,
-// updated_at TIMESTAMP DEFAULT NOW() NOT NULL,
-// CONSTRAINT fk_folder_parent_id FOREIGN KEY (parent_id) REFERENCES public.folder (id)
-//);
-//
-//CREATE TABLE IF NOT EXISTS public.file
-//(
-// id          BIGSERIAL PRIMARY KEY,
-// owner_id    BIGINT                  NOT NULL,
-// name        VARCHAR(255)            NOT NULL,
-// folder_id   BIGINT                  NOT NULL,
-// object_path VARCHAR(255)            NOT NULL,
-// size        INT                     NOT NULL,
-// type        VARCHAR(255)            NOT NULL,
-// created_at  TIMESTAMP DEFAULT NOW() NOT NULL,
-// updated_at  TIMESTAMP DEFAULT NOW() NOT NULL,
-// tags        JSONB,
-// CONSTRAINT fk_file_folder_id FOREIGN KEY (folder_id) REFERENCES public.folder (id)
-//);
+// sqlError adds the PostgreSQL error details to err, leaving other errors untouched.
+func sqlError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return fmt.Errorf("SQL Error: %s, Details: %s, Where: %s, Code: %s, SQLState: %s", pgErr.Message, pgErr.Detail, pgErr.Where, pgErr.Code, pgErr.SQLState())
+	}
+	return err
+}
+
+// isSQLState reports whether err is a PostgreSQL error with the given SQLSTATE code.
+func isSQLState(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
@@ -0,0 +1,53 @@
+package postgresql
+
+import (
+	"context"
+	"github.com/go-kit/log"
+	"remy_explorer/internal/explorer/dto"
+)
+
+type quotaRepository struct {
+	client Client
+	log    log.Logger
+}
+
+// db returns the connection to run queries on, joining the transaction bound to ctx if there is one.
+func (r quotaRepository) db(ctx context.Context) Client {
+	return conn(ctx, r.client)
+}
+
+// LockQuota takes a transaction-scoped advisory lock on the usage of an owner.
+func (r quotaRepository) LockQuota(ctx context.Context, ownerID string) error {
+	q := `SELECT pg_advisory_xact_lock(hashtext('quota'), hashtext($1))`
+	if _, err := r.db(ctx).Exec(ctx, q, ownerID); err != nil {
+		return sqlError(err)
+	}
+	return nil
+}
+
+// GetUsage sums the stored objects of the files of an owner, in the trash or not, and counts the files.
+// A stored object shared by several versions of a file, e.g. after a restore, is counted once.
+func (r quotaRepository) GetUsage(ctx context.Context, ownerID string) (*dto.UsageDTO, error) {
+	q := `SELECT
+    (SELECT COALESCE(sum(o.size), 0) FROM (
+        SELECT DISTINCT v.file_id, v.object_path, v.size
+        FROM public.file f JOIN public.file_version v ON v.file_id = f.id
+        WHERE f.owner_id = $1
+    ) o),
+    (SELECT count(*) FROM public.file WHERE owner_id = $1),
+    q.max_bytes, q.max_files
+FROM (SELECT $1::BIGINT AS owner_id) owner LEFT JOIN public.owner_quota q ON q.owner_id = owner.owner_id`
+	u := dto.UsageDTO{OwnerID: ownerID}
+	if err := r.db(ctx).QueryRow(ctx, q, ownerID).Scan(&u.UsedBytes, &u.FileCount, &u.MaxBytes, &u.MaxFiles); err != nil {
+		return nil, sqlError(err)
+	}
+	return &u, nil
+}
+
+// NewQuotaRepo creates a new quotaRepository.
+func NewQuotaRepo(client Client, logger log.Logger) dto.QuotaRepository {
+	return quotaRepository{
+		client: client,
+		log:    log.With(logger, "quotaRepository", "quota"),
+	}
+}
@@ -0,0 +1,98 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-kit/log"
+	"github.com/jackc/pgx/v5"
+	"remy_explorer/internal/explorer/dto"
+	modelerr "remy_explorer/internal/explorer/err"
+)
+
+type recentRepository struct {
+	client Client
+	log    log.Logger
+}
+
+// db returns the connection to run queries on, joining the transaction bound to ctx if there is one.
+func (r recentRepository) db(ctx context.Context) Client {
+	return conn(ctx, r.client)
+}
+
+// RecordAccess records that a file outside the trash was opened now and prunes the history of its owner to keep entries.
+func (r recentRepository) RecordAccess(ctx context.Context, fileID string, keep int) error {
+	q := `INSERT INTO public.file_access (file_id, owner_id, accessed_at)
+SELECT id, owner_id, CURRENT_TIMESTAMP FROM public.file WHERE id = $1 AND deleted_at IS NULL
+ON CONFLICT (file_id) DO UPDATE SET accessed_at = EXCLUDED.accessed_at
+RETURNING owner_id`
+	var ownerID string
+	if err := r.db(ctx).QueryRow(ctx, q, fileID).Scan(&ownerID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &modelerr.NotFound{ID: fileID}
+		}
+		return sqlError(err)
+	}
+	if keep <= 0 {
+		return nil
+	}
+	// The first entry beyond the cap and the older ones are dropped. Under the cap the subquery finds no row, so nothing is deleted
+	q = `DELETE FROM public.file_access WHERE owner_id = $1 AND (accessed_at, file_id) <= (
+    SELECT accessed_at, file_id FROM public.file_access WHERE owner_id = $1
+    ORDER BY accessed_at DESC, file_id DESC
+    OFFSET $2 LIMIT 1
+)`
+	if _, err := r.db(ctx).Exec(ctx, q, ownerID, keep); err != nil {
+		return sqlError(err)
+	}
+	return nil
+}
+
+// GetOpened retrieves the last files an owner opened, most recently opened first.
+func (r recentRepository) GetOpened(ctx context.Context, ownerID string, limit int) ([]*dto.RecentFileDTO, error) {
+	q := `SELECT f.id, f.owner_id, f.name, f.folder_id, f.type, f.size, f.starred_at IS NOT NULL, a.accessed_at
+FROM public.file_access a JOIN public.file f ON f.id = a.file_id
+WHERE a.owner_id = $1 AND f.deleted_at IS NULL
+ORDER BY a.accessed_at DESC, a.file_id DESC
+LIMIT $2`
+	return r.query(ctx, q, ownerID, limit)
+}
+
+// GetModified retrieves the last files of an owner that changed, most recently changed first.
+func (r recentRepository) GetModified(ctx context.Context, ownerID string, limit int) ([]*dto.RecentFileDTO, error) {
+	q := `SELECT id, owner_id, name, folder_id, type, size, starred_at IS NOT NULL, updated_at
+FROM public.file
+WHERE owner_id = $1 AND deleted_at IS NULL
+ORDER BY updated_at DESC, id DESC
+LIMIT $2`
+	return r.query(ctx, q, ownerID, limit)
+}
+
+// query runs a query of a recent feed and scans its rows.
+func (r recentRepository) query(ctx context.Context, q string, args ...any) ([]*dto.RecentFileDTO, error) {
+	rows, err := r.db(ctx).Query(ctx, q, args...)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+	defer rows.Close()
+	files := make([]*dto.RecentFileDTO, 0)
+	for rows.Next() {
+		var f dto.RecentFileDTO
+		if err := rows.Scan(&f.ID, &f.OwnerID, &f.Name, &f.FolderID, &f.Type, &f.Size, &f.Starred, &f.At); err != nil {
+			return nil, fmt.Errorf("failed to scan recent file: %w", err)
+		}
+		files = append(files, &f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqlError(err)
+	}
+	return files, nil
+}
+
+// NewRecentRepo creates a new recentRepository.
+func NewRecentRepo(client Client, logger log.Logger) dto.RecentRepository {
+	return recentRepository{
+		client: client,
+		log:    log.With(logger, "recentRepository", "recent"),
+	}
+}
@@ -0,0 +1,53 @@
+package postgresql
+
+import (
+	"github.com/go-kit/log"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestRecentRepositoryRecordAccess(t *testing.T) {
+	ctx, client := testDB(t)
+	files := NewFileRepo(client, log.NewNopLogger())
+	folders := NewFolderRepo(client, log.NewNopLogger())
+	recent := NewRecentRepo(client, log.NewNopLogger())
+	var ids []int
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		ids = append(ids, createTestFile(t, ctx, files, folders, name, 1).ID)
+	}
+	opened := func() []int {
+		t.Helper()
+		got, err := recent.GetOpened(ctx, testOwner, 10)
+		if err != nil {
+			t.Fatalf("GetOpened() error = %v", err)
+		}
+		res := make([]int, len(got))
+		for i, f := range got {
+			res[i] = f.ID
+		}
+		return res
+	}
+	record := func(id, keep int) {
+		t.Helper()
+		if err := recent.RecordAccess(ctx, strconv.Itoa(id), keep); err != nil {
+			t.Fatalf("RecordAccess() error = %v", err)
+		}
+	}
+
+	// The accesses of a transaction share a time, so the later created files come first
+	for _, id := range ids {
+		record(id, 5)
+	}
+	if got, want := opened(), []int{ids[2], ids[1], ids[0]}; !slices.Equal(got, want) {
+		t.Errorf("under the cap: opened = %v, want %v", got, want)
+	}
+	record(ids[2], 2)
+	if got, want := opened(), []int{ids[2], ids[1]}; !slices.Equal(got, want) {
+		t.Errorf("over the cap: opened = %v, want %v", got, want)
+	}
+	record(ids[2], 0)
+	if got, want := opened(), []int{ids[2], ids[1]}; !slices.Equal(got, want) {
+		t.Errorf("without a cap: opened = %v, want %v", got, want)
+	}
+}
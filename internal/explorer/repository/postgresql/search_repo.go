@@ -0,0 +1,136 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kit/log"
+	"remy_explorer/internal/explorer/dto"
+	"strings"
+)
+
+// searchRank scores how well a name matches the searched text, $3, higher is better:
+// an exact match comes before a prefix match ($5), which comes before a substring match ($4),
+// and the trigram similarity orders the names within each group and the fuzzy matches after them.
+const searchRank = `(CASE WHEN lower(name) = $3::TEXT THEN 3 WHEN lower(name) LIKE $5::TEXT THEN 2 WHEN lower(name) LIKE $4::TEXT THEN 1 ELSE 0 END
+    + similarity(lower(name), $3::TEXT))::FLOAT8`
+
+// searchMatch selects the names containing the searched text or similar enough to it.
+// Both operators are served by the trigram indexes on lower(name).
+const searchMatch = `(lower(name) LIKE $4::TEXT OR lower(name) % $3::TEXT)`
+
+// likeEscaper escapes the LIKE wildcards of a searched text, so they match literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type searchRepository struct {
+	client Client
+	log    log.Logger
+}
+
+// db returns the connection to run queries on, joining the transaction bound to ctx if there is one.
+func (r searchRepository) db(ctx context.Context) Client {
+	return conn(ctx, r.client)
+}
+
+// Search retrieves a page of the folders and files of an owner matching filter, most relevant first.
+// Every hit comes with the folders containing it from the root, ending with its parent.
+func (r searchRepository) Search(ctx context.Context, filter *dto.SearchFilter, page *dto.PageRequest) ([]*dto.SearchHitDTO, error) {
+	escaped := likeEscaper.Replace(filter.Text)
+	args := []any{filter.OwnerID, page.Fetch(), filter.Text, "%" + escaped + "%", escaped + "%"}
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	common := []string{"owner_id = $1", "deleted_at IS NULL", searchMatch}
+	if filter.CreatedAfter != nil {
+		common = append(common, "created_at >= "+arg(*filter.CreatedAfter)+"::TIMESTAMP")
+	}
+	if filter.CreatedBefore != nil {
+		common = append(common, "created_at < "+arg(*filter.CreatedBefore)+"::TIMESTAMP")
+	}
+	if filter.UpdatedAfter != nil {
+		common = append(common, "updated_at >= "+arg(*filter.UpdatedAfter)+"::TIMESTAMP")
+	}
+	if filter.UpdatedBefore != nil {
+		common = append(common, "updated_at < "+arg(*filter.UpdatedBefore)+"::TIMESTAMP")
+	}
+
+	folderCond := "FALSE"
+	if !filter.FilesOnly() {
+		folderCond = strings.Join(append([]string{"parent_id IS NOT NULL"}, common...), " AND ")
+	}
+	fileCond := "FALSE"
+	if !filter.FoldersOnly() {
+		conds := common
+		if filter.Type != "" {
+			conds = append(conds, "type = "+arg(filter.Type))
+		}
+		if filter.MinSize != nil {
+			conds = append(conds, "size >= "+arg(*filter.MinSize))
+		}
+		if filter.MaxSize != nil {
+			conds = append(conds, "size <= "+arg(*filter.MaxSize))
+		}
+		if len(filter.Tags) > 0 {
+			conds = append(conds, "tags @> "+arg(filter.Tags)+"::JSONB")
+		}
+		fileCond = strings.Join(conds, " AND ")
+	}
+
+	cursorCond := "TRUE"
+	if page.After != nil {
+		cursorCond = fmt.Sprintf("(rank, kind, id) < (%s::FLOAT8, %s::TEXT, %s::BIGINT)", arg(page.After.Value), arg(page.After.Kind), arg(page.After.ID))
+	}
+
+	q := fmt.Sprintf(`WITH RECURSIVE hits AS (
+    SELECT kind, id, name, parent_id, type, size, created_at, updated_at, tags, rank FROM (
+        SELECT 'folder' AS kind, id, name, parent_id, '' AS type, 0 AS size, created_at, updated_at, '[]'::JSONB AS tags, %[1]s AS rank
+        FROM public.folder WHERE %[2]s
+        UNION ALL
+        SELECT 'file', id, name, folder_id, type, size, created_at, updated_at, tags, %[1]s
+        FROM public.file WHERE %[3]s
+    ) h
+    WHERE %[4]s
+    ORDER BY rank DESC, kind DESC, id DESC
+    LIMIT $2
+), ancestors AS (
+    SELECT h.kind AS hit_kind, h.id AS hit_id, f.id, f.name, f.parent_id, 0 AS depth
+    FROM hits h JOIN public.folder f ON f.id = h.parent_id
+    UNION ALL
+    SELECT a.hit_kind, a.hit_id, f.id, f.name, f.parent_id, a.depth + 1
+    FROM ancestors a JOIN public.folder f ON f.id = a.parent_id
+)
+SELECT h.kind, h.id, h.name, h.parent_id, h.type, h.size, h.created_at, h.updated_at, h.tags, h.rank,
+       COALESCE((
+           SELECT jsonb_agg(jsonb_build_object('id', a.id, 'name', a.name, 'parent_id', a.parent_id) ORDER BY a.depth DESC)
+           FROM ancestors a WHERE a.hit_kind = h.kind AND a.hit_id = h.id
+       ), '[]')
+FROM hits h
+ORDER BY h.rank DESC, h.kind DESC, h.id DESC`, searchRank, folderCond, fileCond, cursorCond)
+
+	rows, err := r.db(ctx).Query(ctx, q, args...)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+	defer rows.Close()
+	hits := make([]*dto.SearchHitDTO, 0)
+	for rows.Next() {
+		var h dto.SearchHitDTO
+		if err := rows.Scan(&h.Kind, &h.ID, &h.Name, &h.ParentID, &h.Type, &h.Size, &h.CreatedAt, &h.UpdatedAt, &h.Tags, &h.Rank, &h.Path); err != nil {
+			return nil, fmt.Errorf("failed to scan search hit: %w", err)
+		}
+		hits = append(hits, &h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqlError(err)
+	}
+	return hits, nil
+}
+
+// NewSearchRepo creates a new searchRepository.
+func NewSearchRepo(client Client, logger log.Logger) dto.SearchRepository {
+	return searchRepository{
+		client: client,
+		log:    log.With(logger, "searchRepository", "search"),
+	}
+}
@@ -0,0 +1,86 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kit/log"
+	"remy_explorer/internal/explorer/dto"
+	modelerr "remy_explorer/internal/explorer/err"
+)
+
+// starredItems selects every starred folder and file outside the trash with the columns of dto.StarredItemDTO.
+const starredItems = `SELECT 'folder' AS kind, id, owner_id, name, parent_id::TEXT AS parent_id, NULL::VARCHAR AS type, total_size AS size, starred_at
+FROM public.folder WHERE starred_at IS NOT NULL AND deleted_at IS NULL
+UNION ALL
+SELECT 'file', id, owner_id, name, folder_id::TEXT, type, size, starred_at
+FROM public.file WHERE starred_at IS NOT NULL AND deleted_at IS NULL`
+
+type starRepository struct {
+	client Client
+	log    log.Logger
+}
+
+// db returns the connection to run queries on, joining the transaction bound to ctx if there is one.
+func (r starRepository) db(ctx context.Context) Client {
+	return conn(ctx, r.client)
+}
+
+// SetStarred stars or unstars a folder or file outside the trash.
+func (r starRepository) SetStarred(ctx context.Context, kind, id string, starred bool) error {
+	table := "public.file"
+	if kind == "folder" {
+		table = "public.folder"
+	}
+	value := "NULL"
+	if starred {
+		value = "COALESCE(starred_at, CURRENT_TIMESTAMP)"
+	}
+	q := fmt.Sprintf(`UPDATE %s SET starred_at = %s WHERE id = $1 AND deleted_at IS NULL`, table, value)
+	tag, err := r.db(ctx).Exec(ctx, q, id)
+	if err != nil {
+		return sqlError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return &modelerr.NotFound{ID: id}
+	}
+	return nil
+}
+
+// GetStarred retrieves a page of the starred items of an owner, most recently starred first.
+func (r starRepository) GetStarred(ctx context.Context, ownerID string, page *dto.PageRequest) ([]*dto.StarredItemDTO, error) {
+	cond := "TRUE"
+	args := []any{ownerID, page.Fetch()}
+	if page.After != nil {
+		cond = "(starred_at, kind, id) < ($3::TIMESTAMP, $4, $5)"
+		args = append(args, page.After.Value, page.After.Kind, page.After.ID)
+	}
+	q := fmt.Sprintf(`SELECT kind, id, owner_id, name, parent_id, type, size, starred_at FROM (%s) starred
+WHERE owner_id = $1 AND %s
+ORDER BY starred_at DESC, kind DESC, id DESC
+LIMIT $2`, starredItems, cond)
+	rows, err := r.db(ctx).Query(ctx, q, args...)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+	defer rows.Close()
+	items := make([]*dto.StarredItemDTO, 0)
+	for rows.Next() {
+		var s dto.StarredItemDTO
+		if err := rows.Scan(&s.Kind, &s.ID, &s.OwnerID, &s.Name, &s.ParentID, &s.Type, &s.Size, &s.StarredAt); err != nil {
+			return nil, fmt.Errorf("failed to scan starred item: %w", err)
+		}
+		items = append(items, &s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqlError(err)
+	}
+	return items, nil
+}
+
+// NewStarRepo creates a new starRepository.
+func NewStarRepo(client Client, logger log.Logger) dto.StarRepository {
+	return starRepository{
+		client: client,
+		log:    log.With(logger, "starRepository", "star"),
+	}
+}
@@ -0,0 +1,55 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"remy_explorer/internal/explorer/dto"
+)
+
+// txKey is the context key under which the running transaction is stored.
+type txKey struct{}
+
+type transactor struct {
+	client Client
+}
+
+// WithTx runs fn in a transaction, joining the one already bound to ctx if there is any.
+func (t transactor) WithTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+	tx, err := t.client.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(ctx)
+			panic(p)
+		}
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+	if err = fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// conn returns the transaction bound to ctx, or client if the call is not part of a unit of work.
+func conn(ctx context.Context, client Client) Client {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return client
+}
+
+// NewTransactor creates a dto.Transactor that starts transactions on client.
+func NewTransactor(client Client) dto.Transactor {
+	return transactor{client: client}
+}
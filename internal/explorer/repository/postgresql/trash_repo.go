@@ -0,0 +1,226 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-kit/log"
+	"github.com/jackc/pgx/v5"
+	"remy_explorer/internal/explorer/dto"
+	modelerr "remy_explorer/internal/explorer/err"
+	"time"
+)
+
+// trashItems selects every folder and file in the trash with the columns of dto.TrashItemDTO.
+// listed tells whether the item was deleted on its own rather than together with its parent folder.
+const trashItems = `SELECT 'folder' AS kind, f.id, f.owner_id, f.name, f.parent_id, 0 AS size, f.deleted_at,
+       p.deleted_at IS DISTINCT FROM f.deleted_at AS listed
+FROM public.folder f JOIN public.folder p ON p.id = f.parent_id
+WHERE f.deleted_at IS NOT NULL
+UNION ALL
+SELECT 'file', f.id, f.owner_id, f.name, f.folder_id, f.size, f.deleted_at,
+       p.deleted_at IS DISTINCT FROM f.deleted_at
+FROM public.file f JOIN public.folder p ON p.id = f.folder_id
+WHERE f.deleted_at IS NOT NULL`
+
+type trashRepository struct {
+	client Client
+	log    log.Logger
+}
+
+// db returns the connection to run queries on, joining the transaction bound to ctx if there is one.
+func (r trashRepository) db(ctx context.Context) Client {
+	return conn(ctx, r.client)
+}
+
+// GetTrash retrieves a page of the items an owner moved to the trash, most recently deleted first.
+func (r trashRepository) GetTrash(ctx context.Context, ownerID string, page *dto.PageRequest) ([]*dto.TrashItemDTO, error) {
+	cond := "TRUE"
+	args := []any{ownerID, page.Fetch()}
+	if page.After != nil {
+		cond = "(deleted_at, kind, id) < ($3::TIMESTAMP, $4, $5)"
+		args = append(args, page.After.Value, page.After.Kind, page.After.ID)
+	}
+	q := fmt.Sprintf(`SELECT kind, id, owner_id, name, parent_id, size, deleted_at FROM (%s) trash
+WHERE owner_id = $1 AND listed AND %s
+ORDER BY deleted_at DESC, kind DESC, id DESC
+LIMIT $2`, trashItems, cond)
+	rows, err := r.db(ctx).Query(ctx, q, args...)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+	defer rows.Close()
+	items := make([]*dto.TrashItemDTO, 0)
+	for rows.Next() {
+		var t dto.TrashItemDTO
+		if err := rows.Scan(&t.Kind, &t.ID, &t.OwnerID, &t.Name, &t.ParentID, &t.Size, &t.DeletedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan trash item: %w", err)
+		}
+		items = append(items, &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqlError(err)
+	}
+	return items, nil
+}
+
+// GetTrashItem retrieves a folder or file of the trash by its kind and ID.
+func (r trashRepository) GetTrashItem(ctx context.Context, kind, id string) (*dto.TrashItemDTO, error) {
+	q := fmt.Sprintf(`SELECT kind, id, owner_id, name, parent_id, size, deleted_at FROM (%s) trash WHERE kind = $1 AND id = $2`, trashItems)
+	var t dto.TrashItemDTO
+	err := r.db(ctx).QueryRow(ctx, q, kind, id).Scan(&t.Kind, &t.ID, &t.OwnerID, &t.Name, &t.ParentID, &t.Size, &t.DeletedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &modelerr.NotFound{ID: id}
+		}
+		return nil, sqlError(err)
+	}
+	return &t, nil
+}
+
+// RestoreFile takes a file out of the trash into a folder under the given name.
+func (r trashRepository) RestoreFile(ctx context.Context, id, folderID, name string) error {
+	q := `UPDATE public.file SET deleted_at = NULL, folder_id = $2, name = $3, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NOT NULL`
+	tag, err := r.db(ctx).Exec(ctx, q, id, folderID, name)
+	if err != nil {
+		if isSQLState(err, uniqueViolation) {
+			return &modelerr.DuplicateError{Name: name, FolderID: folderID}
+		}
+		return sqlError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return &modelerr.NotFound{ID: id}
+	}
+	return nil
+}
+
+// RestoreFolder takes a folder out of the trash under a parent with the given name,
+// together with the subfolders and files that share its deletion time.
+// The statements must run in one unit of work, otherwise a failure may leave the subtree half-restored.
+func (r trashRepository) RestoreFolder(ctx context.Context, id, parentID, name string) error {
+	subtree := `WITH RECURSIVE subtree AS (
+    SELECT id, deleted_at FROM public.folder WHERE id = $1 AND deleted_at IS NOT NULL
+    UNION ALL
+    SELECT f.id, f.deleted_at FROM public.folder f JOIN subtree s ON f.parent_id = s.id AND f.deleted_at = s.deleted_at
+)`
+	// The folder gets its new place while still in the trash, so it never clashes with a sibling under its old name.
+	// Moving it apart from the restore also keeps the folder totals maintained by the triggers exact.
+	q := `UPDATE public.folder SET parent_id = $2, name = $3, updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NOT NULL`
+	tag, err := r.db(ctx).Exec(ctx, q, id, parentID, name)
+	if err != nil {
+		return sqlError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return &modelerr.NotFound{ID: id}
+	}
+	q = subtree + ` UPDATE public.file f SET deleted_at = NULL FROM subtree s WHERE f.folder_id = s.id AND f.deleted_at = s.deleted_at`
+	if _, err := r.db(ctx).Exec(ctx, q, id); err != nil {
+		return sqlError(err)
+	}
+	q = subtree + ` UPDATE public.folder SET deleted_at = NULL WHERE id IN (SELECT id FROM subtree)`
+	if _, err := r.db(ctx).Exec(ctx, q, id); err != nil {
+		if isSQLState(err, uniqueViolation) {
+			return &modelerr.DuplicateError{Name: name, FolderID: parentID}
+		}
+		return sqlError(err)
+	}
+	return nil
+}
+
+// PurgeFile deletes a file of the trash from the database with its versions
+// and returns their stored objects so they can be purged.
+func (r trashRepository) PurgeFile(ctx context.Context, id string) ([]*dto.DeletedFileDTO, error) {
+	files, err := r.deleteFiles(ctx, `id = $1 AND deleted_at IS NOT NULL`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, &modelerr.NotFound{ID: id}
+	}
+	return files, nil
+}
+
+// PurgeFolder deletes a folder of the trash together with all its subfolders and files.
+// Everything below a folder in the trash is in the trash as well.
+// It returns the deleted files so their stored objects can be purged.
+// Both statements must run in one unit of work, otherwise a failure may leave the subtree half-deleted.
+func (r trashRepository) PurgeFolder(ctx context.Context, id string) ([]*dto.DeletedFileDTO, error) {
+	subtree := `WITH RECURSIVE subtree AS (
+    SELECT id FROM public.folder WHERE id = $1 AND deleted_at IS NOT NULL
+    UNION ALL
+    SELECT f.id FROM public.folder f JOIN subtree s ON f.parent_id = s.id
+)`
+	files, err := r.deleteFiles(ctx, `folder_id IN (`+subtree+` SELECT id FROM subtree)`, id)
+	if err != nil {
+		return nil, err
+	}
+	tag, err := r.db(ctx).Exec(ctx, subtree+` DELETE FROM public.folder WHERE id IN (SELECT id FROM subtree)`, id)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, &modelerr.NotFound{ID: id}
+	}
+	return files, nil
+}
+
+// PurgeExpired deletes all folders and files that have been in the trash for longer than retention.
+// The cutoff is computed by the database, which also set the deletion times.
+// Items below a folder were moved to the trash no later than the folder, so whole subtrees expire together.
+// Both statements must run in one unit of work.
+func (r trashRepository) PurgeExpired(ctx context.Context, retention time.Duration) ([]*dto.DeletedFileDTO, error) {
+	secs := retention.Seconds()
+	files, err := r.deleteFiles(ctx, `deleted_at < now() - make_interval(secs => $1)`, secs)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.db(ctx).Exec(ctx, `DELETE FROM public.folder WHERE deleted_at < now() - make_interval(secs => $1)`, secs); err != nil {
+		return nil, sqlError(err)
+	}
+	return files, nil
+}
+
+// deleteFiles deletes the files matching cond together with their versions
+// and returns every stored object they referred to that no remaining file shares.
+func (r trashRepository) deleteFiles(ctx context.Context, cond string, args ...any) ([]*dto.DeletedFileDTO, error) {
+	q := fmt.Sprintf(`WITH files AS (
+    DELETE FROM public.file WHERE %s RETURNING id, object_path
+), versions AS (
+    DELETE FROM public.file_version v USING files f WHERE v.file_id = f.id RETURNING v.file_id, v.object_path
+), objects AS (
+    SELECT id, object_path FROM files
+    UNION
+    SELECT file_id, object_path FROM versions
+)
+SELECT o.id, o.object_path FROM objects o
+WHERE NOT EXISTS (
+    SELECT 1 FROM public.file_version k
+    WHERE k.object_path = o.object_path AND k.file_id NOT IN (SELECT id FROM files)
+)`, cond)
+	rows, err := r.db(ctx).Query(ctx, q, args...)
+	if err != nil {
+		return nil, sqlError(err)
+	}
+	defer rows.Close()
+	files := make([]*dto.DeletedFileDTO, 0)
+	for rows.Next() {
+		var f dto.DeletedFileDTO
+		if err := rows.Scan(&f.ID, &f.ObjectPath); err != nil {
+			return nil, fmt.Errorf("failed to scan deleted file: %w", err)
+		}
+		files = append(files, &f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqlError(err)
+	}
+	return files, nil
+}
+
+// NewTrashRepo creates a new trashRepository.
+func NewTrashRepo(client Client, logger log.Logger) dto.TrashRepository {
+	return trashRepository{
+		client: client,
+		log:    log.With(logger, "trashRepository", "trash"),
+	}
+}
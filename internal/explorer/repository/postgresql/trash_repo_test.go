@@ -0,0 +1,186 @@
+package postgresql
+
+import (
+	"context"
+	"github.com/go-kit/log"
+	"remy_explorer/internal/explorer/dto"
+	"remy_explorer/internal/explorer/model"
+	"remy_explorer/internal/explorer/service/trash"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// trashTest holds the repositories of a trash test and the root folder of testOwner.
+type trashTest struct {
+	ctx     context.Context
+	client  Client
+	files   dto.FileRepository
+	folders dto.FolderRepository
+	trash   dto.TrashRepository
+	root    *dto.FolderDTO
+}
+
+func newTrashTest(t *testing.T) *trashTest {
+	t.Helper()
+	ctx, client := testDB(t)
+	tt := &trashTest{
+		ctx:     ctx,
+		client:  client,
+		files:   NewFileRepo(client, log.NewNopLogger()),
+		folders: NewFolderRepo(client, log.NewNopLogger()),
+		trash:   NewTrashRepo(client, log.NewNopLogger()),
+	}
+	root, err := tt.folders.EnsureRootFolder(ctx, testOwner)
+	if err != nil {
+		t.Fatalf("EnsureRootFolder() error = %v", err)
+	}
+	tt.root = root
+	return tt
+}
+
+// service returns a trash service running on the repositories of the test.
+func (tt *trashTest) service() trash.TrashService {
+	return trash.NewService(tt.trash, tt.files, tt.folders, NewTransactor(tt.client), 0, log.NewNopLogger())
+}
+
+func (tt *trashTest) trashFolder(t *testing.T, f *dto.FolderDTO) {
+	t.Helper()
+	if err := tt.folders.TrashFolderRecursive(tt.ctx, strconv.Itoa(f.ID)); err != nil {
+		t.Fatalf("TrashFolderRecursive(%s) error = %v", f.Name, err)
+	}
+}
+
+// listed returns the kinds and IDs of the items listed in the trash of testOwner.
+func (tt *trashTest) listed(t *testing.T) map[string]bool {
+	t.Helper()
+	page, err := dto.NewPageRequest(0, "", dto.TrashSortOption)
+	if err != nil {
+		t.Fatalf("NewPageRequest() error = %v", err)
+	}
+	items, err := tt.trash.GetTrash(tt.ctx, testOwner, page)
+	if err != nil {
+		t.Fatalf("GetTrash() error = %v", err)
+	}
+	res := make(map[string]bool)
+	for _, item := range items {
+		res[item.Kind+" "+strconv.Itoa(item.ID)] = true
+	}
+	return res
+}
+
+func TestRestoreFolderLeavesEarlierDeletedChild(t *testing.T) {
+	tt := newTrashTest(t)
+	a := createTestFolder(t, tt.ctx, tt.folders, tt.root.ID, "a")
+	b := createTestFolder(t, tt.ctx, tt.folders, a.ID, "b")
+	c := createTestFolder(t, tt.ctx, tt.folders, a.ID, "c")
+	tt.trashFolder(t, b)
+	tt.trashFolder(t, a)
+
+	listed := tt.listed(t)
+	if !listed["folder "+strconv.Itoa(a.ID)] || !listed["folder "+strconv.Itoa(b.ID)] || listed["folder "+strconv.Itoa(c.ID)] {
+		t.Errorf("GetTrash() = %v, want the folders a and b listed, c deleted with a", listed)
+	}
+
+	if err := tt.trash.RestoreFolder(tt.ctx, strconv.Itoa(a.ID), strconv.Itoa(tt.root.ID), a.Name); err != nil {
+		t.Fatalf("RestoreFolder() error = %v", err)
+	}
+	if _, err := tt.folders.GetFolderByID(tt.ctx, strconv.Itoa(c.ID)); err != nil {
+		t.Errorf("GetFolderByID(c) error = %v, want c restored with a", err)
+	}
+	if _, err := tt.folders.GetFolderByID(tt.ctx, strconv.Itoa(b.ID)); err == nil {
+		t.Errorf("GetFolderByID(b) found b, want it to stay in the trash")
+	}
+	if listed := tt.listed(t); !listed["folder "+strconv.Itoa(b.ID)] {
+		t.Errorf("GetTrash() = %v, want b listed", listed)
+	}
+}
+
+func TestRestoreFolderIntoTakenName(t *testing.T) {
+	tt := newTrashTest(t)
+	old := createTestFolder(t, tt.ctx, tt.folders, tt.root.ID, "docs")
+	tt.trashFolder(t, old)
+	createTestFolder(t, tt.ctx, tt.folders, tt.root.ID, "docs")
+
+	item, err := tt.service().Restore(tt.ctx, model.KindFolder, strconv.Itoa(old.ID))
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if item.Name != "docs (1)" || item.ParentID != strconv.Itoa(tt.root.ID) {
+		t.Errorf("Restore() = %s in %s, want docs (1) in %d", item.Name, item.ParentID, tt.root.ID)
+	}
+	got, err := tt.folders.GetFolderByID(tt.ctx, strconv.Itoa(old.ID))
+	if err != nil {
+		t.Fatalf("GetFolderByID() error = %v", err)
+	}
+	if got.Name != "docs (1)" {
+		t.Errorf("GetFolderByID() name = %q, want %q", got.Name, "docs (1)")
+	}
+}
+
+func TestRestoreFolderWithoutParent(t *testing.T) {
+	tt := newTrashTest(t)
+	a := createTestFolder(t, tt.ctx, tt.folders, tt.root.ID, "a")
+	b := createTestFolder(t, tt.ctx, tt.folders, a.ID, "b")
+	tt.trashFolder(t, b)
+	tt.trashFolder(t, a)
+
+	item, err := tt.service().Restore(tt.ctx, model.KindFolder, strconv.Itoa(b.ID))
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if item.ParentID != strconv.Itoa(tt.root.ID) {
+		t.Errorf("Restore() parent = %s, want the root %d", item.ParentID, tt.root.ID)
+	}
+	got, err := tt.folders.GetFolderByID(tt.ctx, strconv.Itoa(b.ID))
+	if err != nil {
+		t.Fatalf("GetFolderByID() error = %v", err)
+	}
+	if got.ParentID.String != strconv.Itoa(tt.root.ID) {
+		t.Errorf("GetFolderByID() parent = %s, want the root %d", got.ParentID.String, tt.root.ID)
+	}
+	if _, err := tt.folders.GetFolderByID(tt.ctx, strconv.Itoa(a.ID)); err == nil {
+		t.Errorf("GetFolderByID(a) found a, want it to stay in the trash")
+	}
+}
+
+func TestPurgeFolderAndExpired(t *testing.T) {
+	tt := newTrashTest(t)
+	a := createTestFolder(t, tt.ctx, tt.folders, tt.root.ID, "a")
+	b := createTestFolder(t, tt.ctx, tt.folders, a.ID, "b")
+	f := createTestFile(t, tt.ctx, tt.files, tt.folders, "f.txt", 1)
+	if err := tt.files.MoveFile(tt.ctx, strconv.Itoa(f.ID), strconv.Itoa(b.ID), f.Name); err != nil {
+		t.Fatalf("MoveFile() error = %v", err)
+	}
+	old := createTestFile(t, tt.ctx, tt.files, tt.folders, "old.txt", 1)
+	if err := tt.files.TrashFile(tt.ctx, strconv.Itoa(old.ID)); err != nil {
+		t.Fatalf("TrashFile() error = %v", err)
+	}
+	tt.trashFolder(t, a)
+	// Only the file has been in the trash for longer than the retention
+	q := `UPDATE public.file SET deleted_at = deleted_at - INTERVAL '2 days' WHERE id = $1`
+	if _, err := conn(tt.ctx, tt.client).Exec(tt.ctx, q, old.ID); err != nil {
+		t.Fatalf("failed to age the file: %v", err)
+	}
+
+	expired, err := tt.trash.PurgeExpired(tt.ctx, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("PurgeExpired() error = %v", err)
+	}
+	if len(expired) != 1 || expired[0].ID != old.ID || expired[0].ObjectPath != old.ObjectPath.String {
+		t.Errorf("PurgeExpired() = %+v, want the object of %s", expired, old.Name)
+	}
+
+	purged, err := tt.trash.PurgeFolder(tt.ctx, strconv.Itoa(a.ID))
+	if err != nil {
+		t.Fatalf("PurgeFolder() error = %v", err)
+	}
+	if len(purged) != 1 || purged[0].ID != f.ID || purged[0].ObjectPath != f.ObjectPath.String {
+		t.Errorf("PurgeFolder() = %+v, want the object of %s", purged, f.Name)
+	}
+	for _, item := range []struct{ kind, id string }{{model.KindFolder, strconv.Itoa(b.ID)}, {model.KindFile, strconv.Itoa(f.ID)}} {
+		if _, err := tt.trash.GetTrashItem(tt.ctx, item.kind, item.id); err == nil {
+			t.Errorf("GetTrashItem(%s %s) found the item, want it purged with its folder", item.kind, item.id)
+		}
+	}
+}
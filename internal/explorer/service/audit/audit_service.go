@@ -0,0 +1,70 @@
+package audit
+
+import (
+	"context"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"remy_explorer/internal/explorer/dto"
+	modelerr "remy_explorer/internal/explorer/err"
+	"remy_explorer/internal/explorer/model"
+	"strconv"
+)
+
+// AuditService provides the audit log of the changes made to folders and files
+type AuditService interface {
+	GetRecords(ctx context.Context, filter *model.AuditFilter, opts model.ListOptions) ([]*model.AuditRecord, string, error)
+}
+
+type service struct {
+	repo dto.AuditRepository
+	log  log.Logger
+}
+
+// GetRecords returns a page of the audit records matching filter, newest first,
+// and the cursor of the next page, empty on the last one. Only the limit and cursor of opts are used.
+// An item ID must come with the kind of the item.
+func (s service) GetRecords(ctx context.Context, filter *model.AuditFilter, opts model.ListOptions) ([]*model.AuditRecord, string, error) {
+	logger := log.With(s.log, "audit", "GetRecords")
+	if filter.Kind != "" && filter.Kind != model.KindFile && filter.Kind != model.KindFolder {
+		return nil, "", &modelerr.InvalidArgument{Name: "kind", Reason: "must be folder or file"}
+	}
+	if filter.ItemID != "" {
+		if filter.Kind == "" {
+			return nil, "", &modelerr.InvalidArgument{Name: "kind", Reason: "is required with item_id"}
+		}
+		if _, err := strconv.ParseInt(filter.ItemID, 10, 64); err != nil {
+			return nil, "", &modelerr.InvalidArgument{Name: "item_id", Reason: "must be an integer"}
+		}
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, "", &modelerr.InvalidArgument{Name: "to", Reason: "must be later than from"}
+	}
+	page, err := dto.NewPageRequest(opts.Limit, opts.Cursor, dto.AuditSortOption)
+	if err != nil {
+		return nil, "", err
+	}
+	recordDTOs, err := s.repo.GetRecords(ctx, filter, page)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return nil, "", err
+	}
+	recordDTOs, more := dto.TrimPage(page, recordDTOs)
+	var next string
+	if more {
+		next = recordDTOs[len(recordDTOs)-1].Cursor(dto.AuditSortOption).Encode()
+	}
+	records := make([]*model.AuditRecord, len(recordDTOs))
+	for i, r := range recordDTOs {
+		records[i] = r.ToDomain()
+	}
+	logger.Log("message", "Audit records retrieved", "count", len(records))
+	return records, next, nil
+}
+
+// NewService creates a new audit service
+func NewService(repo dto.AuditRepository, logger log.Logger) AuditService {
+	return &service{
+		repo: repo,
+		log:  log.With(logger, "service", "audit"),
+	}
+}
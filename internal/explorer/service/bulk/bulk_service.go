@@ -0,0 +1,185 @@
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"remy_explorer/internal/explorer/dto"
+	modelerr "remy_explorer/internal/explorer/err"
+	"remy_explorer/internal/explorer/model"
+	"remy_explorer/internal/explorer/service/file"
+	"remy_explorer/internal/explorer/service/folder"
+)
+
+// MaxItems is the largest number of items a bulk operation accepts.
+const MaxItems = 1000
+
+// BulkService applies file and folder operations to lists of items at once.
+// Every operation reports the outcome of each item. Without atomic the items are processed one by one,
+// each in its own transaction, so a failing item does not stop the others.
+// With atomic they are processed in one transaction that stops at the first failure and is rolled back.
+type BulkService interface {
+	Move(ctx context.Context, items []model.ItemRef, folderID string, policy model.ConflictPolicy, atomic bool) ([]*model.BulkResult, error)
+	Delete(ctx context.Context, items []model.ItemRef, recursive, atomic bool) ([]*model.BulkResult, error)
+	AddTag(ctx context.Context, items []model.ItemRef, tag string, atomic bool) ([]*model.BulkResult, error)
+	RemoveTag(ctx context.Context, items []model.ItemRef, tag string, atomic bool) ([]*model.BulkResult, error)
+}
+
+type service struct {
+	files   file.FileService
+	folders folder.FolderService
+	tx      dto.Transactor
+	log     log.Logger
+}
+
+// Move moves the items into folderID, resolving names already taken there according to policy.
+func (s service) Move(ctx context.Context, items []model.ItemRef, folderID string, policy model.ConflictPolicy, atomic bool) ([]*model.BulkResult, error) {
+	logger := log.With(s.log, "bulk", "Move")
+	results, err := s.run(ctx, items, atomic, func(ctx context.Context, item model.ItemRef) error {
+		if item.Kind == model.KindFolder {
+			return s.folders.MoveFolder(ctx, item.ID, folderID, policy, 0)
+		}
+		return s.files.MoveFile(ctx, item.ID, folderID, policy, 0)
+	})
+	return s.report(logger, results, err)
+}
+
+// Delete moves the items to the trash. A folder with content is only deleted when recursive is set.
+func (s service) Delete(ctx context.Context, items []model.ItemRef, recursive, atomic bool) ([]*model.BulkResult, error) {
+	logger := log.With(s.log, "bulk", "Delete")
+	results, err := s.run(ctx, items, atomic, func(ctx context.Context, item model.ItemRef) error {
+		if item.Kind == model.KindFolder {
+			if recursive {
+				return s.folders.DeleteFolderRecursive(ctx, item.ID, 0)
+			}
+			return s.folders.DeleteFolder(ctx, item.ID, 0)
+		}
+		_, err := s.files.DeleteFile(ctx, item.ID, 0)
+		return err
+	})
+	return s.report(logger, results, err)
+}
+
+// AddTag tags the items. Only files can be tagged, a folder item fails.
+func (s service) AddTag(ctx context.Context, items []model.ItemRef, tag string, atomic bool) ([]*model.BulkResult, error) {
+	logger := log.With(s.log, "bulk", "AddTag")
+	if _, err := model.NormalizeTag(tag); err != nil {
+		return nil, err
+	}
+	results, err := s.run(ctx, items, atomic, func(ctx context.Context, item model.ItemRef) error {
+		if item.Kind == model.KindFolder {
+			return &modelerr.InvalidArgument{Name: "kind", Reason: "only files can be tagged"}
+		}
+		_, err := s.files.AddTag(ctx, item.ID, tag, 0)
+		return err
+	})
+	return s.report(logger, results, err)
+}
+
+// RemoveTag removes a tag from the items. Only files can be tagged, a folder item fails.
+func (s service) RemoveTag(ctx context.Context, items []model.ItemRef, tag string, atomic bool) ([]*model.BulkResult, error) {
+	logger := log.With(s.log, "bulk", "RemoveTag")
+	if _, err := model.NormalizeTag(tag); err != nil {
+		return nil, err
+	}
+	results, err := s.run(ctx, items, atomic, func(ctx context.Context, item model.ItemRef) error {
+		if item.Kind == model.KindFolder {
+			return &modelerr.InvalidArgument{Name: "kind", Reason: "only files can be tagged"}
+		}
+		_, err := s.files.RemoveTag(ctx, item.ID, tag, 0)
+		return err
+	})
+	return s.report(logger, results, err)
+}
+
+// run validates the items and applies op to each of them, one by one or atomically.
+// A failing item makes the error of run nil: its failure is reported in its result.
+func (s service) run(ctx context.Context, items []model.ItemRef, atomic bool, op func(ctx context.Context, item model.ItemRef) error) ([]*model.BulkResult, error) {
+	if err := checkItems(items); err != nil {
+		return nil, err
+	}
+	results := make([]*model.BulkResult, len(items))
+	for i, item := range items {
+		results[i] = &model.BulkResult{ItemRef: item, Status: model.BulkSkipped}
+	}
+	if !atomic {
+		for i, item := range items {
+			if err := op(ctx, item); err != nil {
+				results[i].Status, results[i].Err = model.BulkFailed, err
+				continue
+			}
+			results[i].Status = model.BulkDone
+		}
+		return results, nil
+	}
+	failed := false
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		for i, item := range items {
+			if err := op(ctx, item); err != nil {
+				results[i].Status, results[i].Err = model.BulkFailed, err
+				failed = true
+				return err
+			}
+			results[i].Status = model.BulkDone
+		}
+		return nil
+	})
+	if err != nil {
+		if !failed {
+			return nil, err
+		}
+		for _, r := range results {
+			if r.Status == model.BulkDone {
+				r.Status = model.BulkRolledBack
+			}
+		}
+	}
+	return results, nil
+}
+
+// checkItems rejects an empty or oversized item list and items of an unknown kind or without an ID.
+func checkItems(items []model.ItemRef) error {
+	if len(items) == 0 {
+		return &modelerr.InvalidArgument{Name: "items", Reason: "must not be empty"}
+	}
+	if len(items) > MaxItems {
+		return &modelerr.InvalidArgument{Name: "items", Reason: fmt.Sprintf("must not hold more than %d items", MaxItems)}
+	}
+	for i, item := range items {
+		if item.Kind != model.KindFolder && item.Kind != model.KindFile {
+			return &modelerr.InvalidArgument{Name: fmt.Sprintf("items[%d].kind", i), Reason: "must be folder or file"}
+		}
+		if item.ID == "" {
+			return &modelerr.InvalidArgument{Name: fmt.Sprintf("items[%d].id", i), Reason: "is required"}
+		}
+	}
+	return nil
+}
+
+// report logs the outcome of a bulk operation.
+func (s service) report(logger log.Logger, results []*model.BulkResult, err error) ([]*model.BulkResult, error) {
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return nil, err
+	}
+	failed := 0
+	for _, r := range results {
+		if r.Status == model.BulkFailed {
+			failed++
+		}
+	}
+	logger.Log("message", "Bulk operation done", "items", len(results), "failed", failed)
+	return results, nil
+}
+
+// NewService creates a BulkService applying the operations of files and folders.
+// Atomic operations run as units of work started by tx, which the file and folder services join.
+func NewService(files file.FileService, folders folder.FolderService, tx dto.Transactor, logger log.Logger) BulkService {
+	return &service{
+		files:   files,
+		folders: folders,
+		tx:      tx,
+		log:     log.With(logger, "service", "bulk"),
+	}
+}
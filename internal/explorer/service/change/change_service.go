@@ -0,0 +1,61 @@
+package change
+
+import (
+	"context"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"remy_explorer/internal/explorer/dto"
+	modelerr "remy_explorer/internal/explorer/err"
+	"remy_explorer/internal/explorer/model"
+	"strconv"
+)
+
+// ChangeService provides the change feeds clients use to keep a copy of the tree of an owner in sync
+type ChangeService interface {
+	GetChanges(ctx context.Context, ownerID string, opts model.ListOptions) ([]*model.Change, string, bool, error)
+}
+
+type service struct {
+	repo dto.ChangeRepository
+	log  log.Logger
+}
+
+// GetChanges returns a page of the changes of an owner after opts.Cursor, oldest first, the cursor to ask for
+// the following changes with and whether more changes are already waiting. An empty cursor starts the feed
+// from the beginning, with the creation of every item. A page without changes returns the cursor it was given,
+// so clients can keep polling with it.
+// Only the limit and cursor of opts are used.
+func (s service) GetChanges(ctx context.Context, ownerID string, opts model.ListOptions) ([]*model.Change, string, bool, error) {
+	logger := log.With(s.log, "change", "GetChanges")
+	if _, err := strconv.ParseInt(ownerID, 10, 64); err != nil {
+		return nil, "", false, &modelerr.InvalidArgument{Name: "owner_id", Reason: "must be an integer"}
+	}
+	page, err := dto.NewPageRequest(opts.Limit, opts.Cursor, dto.ChangeSortOption)
+	if err != nil {
+		return nil, "", false, err
+	}
+	changeDTOs, err := s.repo.GetChanges(ctx, ownerID, page)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return nil, "", false, err
+	}
+	changeDTOs, more := dto.TrimPage(page, changeDTOs)
+	next := opts.Cursor
+	if len(changeDTOs) > 0 {
+		next = changeDTOs[len(changeDTOs)-1].Cursor(dto.ChangeSortOption).Encode()
+	}
+	changes := make([]*model.Change, len(changeDTOs))
+	for i, c := range changeDTOs {
+		changes[i] = c.ToDomain()
+	}
+	logger.Log("message", "Changes retrieved", "owner", ownerID, "count", len(changes))
+	return changes, next, more, nil
+}
+
+// NewService creates a new change service
+func NewService(repo dto.ChangeRepository, logger log.Logger) ChangeService {
+	return &service{
+		repo: repo,
+		log:  log.With(logger, "service", "change"),
+	}
+}
@@ -2,38 +2,125 @@ package file
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"remy_explorer/internal/explorer/dto"
 	modelerr "remy_explorer/internal/explorer/err"
 	"remy_explorer/internal/explorer/model"
+	"remy_explorer/internal/utils"
+	"slices"
+	"strconv"
 )
 
+// maxRenameAttempts bounds the search for a free numbered name.
+const maxRenameAttempts = 1000
+
 // FileService provides file operations
 type FileService interface {
-	CreateFile(ctx context.Context, f *model.File) (*string, error)
+	// CreateFile, UpdateFile, PatchFile and RestoreFileVersion also return the stored objects of the versions
+	// dropped beyond the cap, which can now be purged.
+	CreateFile(ctx context.Context, f *model.File, policy model.ConflictPolicy) (*string, []*model.DeletedFile, error)
 	GetFileByID(ctx context.Context, id string) (*model.File, error)
-	GetFilesByFolderID(ctx context.Context, parentID string) ([]*model.File, error)
-	UpdateFile(ctx context.Context, f *model.File) (bool, error)
-	DeleteFile(ctx context.Context, id string) (bool, error)
+	GetFilesByFolderID(ctx context.Context, parentID string, opts model.ListOptions) ([]*model.File, string, error)
+	// UpdateFile, PatchFile, RestoreFileVersion, AddTag, RemoveTag, MoveFile and DeleteFile change a file
+	// only if it is at the given revision, zero means any.
+	UpdateFile(ctx context.Context, f *model.File) ([]*model.DeletedFile, error)
+	PatchFile(ctx context.Context, patch *model.FilePatch) (*model.File, []*model.DeletedFile, error)
+	GetFileVersions(ctx context.Context, id string) ([]*model.FileVersion, error)
+	RestoreFileVersion(ctx context.Context, id string, version, revision int) (int, []*model.DeletedFile, error)
+	AddTag(ctx context.Context, id, tag string, revision int) ([]string, error)
+	RemoveTag(ctx context.Context, id, tag string, revision int) ([]string, error)
+	GetTags(ctx context.Context, ownerID string) ([]*model.TagUsage, error)
+	GetFilesByTag(ctx context.Context, ownerID, tag string, opts model.ListOptions) ([]*model.File, string, error)
+	GetUsage(ctx context.Context, ownerID string) (*model.Usage, error)
+	GetDuplicates(ctx context.Context, ownerID string, limit int) (*model.Duplicates, error)
+	MoveFile(ctx context.Context, id, folderID string, policy model.ConflictPolicy, revision int) error
+	CopyFile(ctx context.Context, id, folderID string, policy model.ConflictPolicy) (*string, error)
+	DeleteFile(ctx context.Context, id string, revision int) (bool, error)
 }
 
 type service struct {
-	repo dto.FileRepository
-	log  log.Logger
+	repo        dto.FileRepository
+	folders     dto.FolderRepository
+	quotas      dto.QuotaRepository
+	audits      dto.AuditRepository
+	tx          dto.Transactor
+	maxVersions int
+	quota       model.Quota
+	log         log.Logger
 }
 
-func (s service) CreateFile(ctx context.Context, f *model.File) (*string, error) {
+// CreateFile creates a file, resolving a name already taken in the folder according to policy.
+// Its tags are normalized, see model.NormalizeTag, and so is its checksum, see model.NormalizeChecksum.
+// With ConflictOverwrite the existing file keeps its ID and gets the content of the new one.
+// A file without a folder is created in the root folder of its owner.
+// The new content must fit in the quota of the owner.
+func (s service) CreateFile(ctx context.Context, f *model.File, policy model.ConflictPolicy) (*string, []*model.DeletedFile, error) {
 	logger := log.With(s.log, "folder", "UpdateFolder")
+	if f.Size < 0 {
+		return nil, nil, &modelerr.InvalidArgument{Name: "size", Reason: "must not be negative"}
+	}
+	algorithm, checksum, err := model.NormalizeChecksum(f.ChecksumAlgorithm, f.Checksum)
+	if err != nil {
+		return nil, nil, err
+	}
+	f.ChecksumAlgorithm, f.Checksum = algorithm, checksum
 	fileDTO := dto.FileToDTO(f)
-	id, err := s.repo.CreateFile(ctx, &fileDTO)
+	tags, err := model.NormalizeTags(f.Tags)
+	if err != nil {
+		return nil, nil, err
+	}
+	fileDTO.Tags = tags
+	var id *string
+	var pruned []*dto.DeletedFileDTO
+	err = s.tx.WithTx(ctx, func(ctx context.Context) (err error) {
+		if f.FolderID == "" {
+			if _, err := strconv.ParseInt(f.OwnerID, 10, 64); err != nil {
+				return &modelerr.InvalidArgument{Name: "owner_id", Reason: "must be an integer"}
+			}
+			root, err := s.folders.EnsureRootFolder(ctx, f.OwnerID)
+			if err != nil {
+				return err
+			}
+			fileDTO.FolderID = root.ID
+		}
+		var replaced *dto.FileDTO
+		fileDTO.Name, replaced, err = s.resolveName(ctx, strconv.Itoa(fileDTO.FolderID), f.Name, policy)
+		if err != nil {
+			return err
+		}
+		if replaced != nil {
+			// The replaced content is kept as a version, so the whole new size counts against the quota
+			if err := s.checkQuota(ctx, replaced.OwnerID, fileDTO.Size, 0); err != nil {
+				return err
+			}
+			before := *replaced
+			replaced.ObjectPath, replaced.Size, replaced.Type = fileDTO.ObjectPath, fileDTO.Size, fileDTO.Type
+			replaced.ChecksumAlgorithm, replaced.Checksum = fileDTO.ChecksumAlgorithm, fileDTO.Checksum
+			if _, pruned, err = s.updateContent(ctx, replaced); err != nil {
+				return err
+			}
+			res := strconv.Itoa(replaced.ID)
+			id = &res
+			return s.auditChange(ctx, model.AuditUpdate, &before, res)
+		}
+		if err := s.checkQuota(ctx, fileDTO.OwnerID, fileDTO.Size, 1); err != nil {
+			return err
+		}
+		if id, err = s.repo.CreateFile(ctx, &fileDTO); err != nil {
+			return err
+		}
+		return s.auditChange(ctx, model.AuditCreate, nil, *id)
+	})
 	if err != nil {
 		level.Error(logger).Log("err", err)
-		return nil, err
+		return nil, nil, err
 	}
-	logger.Log("message", "File created", "id", fileDTO.ID)
-	return id, nil
+	logger.Log("message", "File created", "id", fileDTO.ID, "pruned", len(pruned))
+	return id, toDeletedFiles(pruned), nil
 }
 
 func (s service) GetFileByID(ctx context.Context, id string) (*model.File, error) {
@@ -53,45 +140,672 @@ func (s service) GetFileByID(ctx context.Context, id string) (*model.File, error
 	return file, nil
 }
 
-func (s service) GetFilesByFolderID(ctx context.Context, parentID string) ([]*model.File, error) {
+// GetFilesByFolderID returns a page of the files in a folder and the cursor of the next page, empty on the last one.
+func (s service) GetFilesByFolderID(ctx context.Context, parentID string, opts model.ListOptions) ([]*model.File, string, error) {
 	logger := log.With(s.log, "folder", "GetFoldersByParentID")
-	fileDTOs, err := s.repo.GetFilesByFolderID(ctx, parentID)
+	sort, err := dto.NewSortOption(opts.Sort, opts.Order)
+	if err != nil {
+		return nil, "", err
+	}
+	page, err := dto.NewPageRequest(opts.Limit, opts.Cursor, sort)
+	if err != nil {
+		return nil, "", err
+	}
+	fileDTOs, err := s.repo.GetFilesByFolderID(ctx, parentID, page)
 	if err != nil {
 		level.Error(logger).Log("err", err)
-		return nil, err
+		return nil, "", err
+	}
+	fileDTOs, more := dto.TrimPage(page, fileDTOs)
+	var next string
+	if more {
+		next = fileDTOs[len(fileDTOs)-1].Cursor(sort).Encode()
 	}
 	files := make([]*model.File, len(fileDTOs))
 	for i, f := range fileDTOs {
 		files[i] = f.ToDomain()
 	}
 	logger.Log("message", "Files retrieved", "count", len(files))
-	return files, nil
+	return files, next, nil
 }
 
-func (s service) UpdateFile(ctx context.Context, f *model.File) (bool, error) {
+// UpdateFile updates a file. A new stored object is recorded as the next version of the file,
+// without one the current content is kept. A new content must fit in the quota of the owner.
+// Nil tags keep the current ones and an empty folder keeps the current folder, a new folder must pass the same
+// checks as a move. A non-zero f.Revision must match the current revision of the file.
+func (s service) UpdateFile(ctx context.Context, f *model.File) ([]*model.DeletedFile, error) {
 	logger := log.With(s.log, "folder", "UpdateFolder")
+	if f.Size < 0 {
+		return nil, &modelerr.InvalidArgument{Name: "size", Reason: "must not be negative"}
+	}
 	fileDTO := dto.FileToDTO(f)
-	if err := s.repo.UpdateFile(ctx, &fileDTO); err != nil {
+	if f.Tags != nil {
+		tags, err := model.NormalizeTags(f.Tags)
+		if err != nil {
+			return nil, err
+		}
+		fileDTO.Tags = tags
+	}
+	var pruned []*dto.DeletedFileDTO
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.checkRevision(ctx, f.ID, f.Revision); err != nil {
+			return err
+		}
+		current, err := s.repo.GetFileByID(ctx, f.ID)
+		if err != nil {
+			return err
+		}
+		// An empty folder keeps the file where it is, any other one must pass the move checks
+		if f.FolderID == "" {
+			fileDTO.FolderID = current.FolderID
+		} else if f.FolderID != strconv.Itoa(current.FolderID) {
+			if err := s.checkTarget(ctx, current, f.FolderID, "move"); err != nil {
+				return err
+			}
+			if fileDTO.FolderID, err = strconv.Atoi(f.FolderID); err != nil {
+				return &modelerr.InvalidArgument{Name: "folder_id", Reason: "must be a folder ID"}
+			}
+		}
+		if fileDTO.Tags == nil {
+			fileDTO.Tags = current.Tags
+		}
+		if fileDTO.ObjectPath.String != "" && fileDTO.ObjectPath != current.ObjectPath {
+			if err := s.checkQuota(ctx, current.OwnerID, fileDTO.Size, 0); err != nil {
+				return err
+			}
+			if _, pruned, err = s.updateContent(ctx, &fileDTO); err != nil {
+				return err
+			}
+		} else {
+			fileDTO.ObjectPath, fileDTO.Size, fileDTO.Type = current.ObjectPath, current.Size, current.Type
+		}
+		if err := s.repo.UpdateFile(ctx, &fileDTO); err != nil {
+			return err
+		}
+		return s.auditChange(ctx, model.AuditUpdate, current, f.ID)
+	})
+	if err != nil {
 		level.Error(logger).Log("err", err)
-		return false, err
+		return nil, err
 	}
-	logger.Log("message", "File updated", "id", fileDTO.ID)
-	return true, nil
+	logger.Log("message", "File updated", "id", fileDTO.ID, "pruned", len(pruned))
+	return toDeletedFiles(pruned), nil
+}
+
+// PatchFile changes only the fields set in patch and returns the updated file.
+// A new folder must pass the same checks as a move, without resolving name conflicts.
+// A new content is recorded as a new version and must fit in the quota of the owner.
+func (s service) PatchFile(ctx context.Context, patch *model.FilePatch) (*model.File, []*model.DeletedFile, error) {
+	logger := log.With(s.log, "file", "PatchFile")
+	if patch.Name != nil && *patch.Name == "" {
+		return nil, nil, &modelerr.InvalidArgument{Name: "name", Reason: "must not be empty"}
+	}
+	if patch.ObjectPath == nil && (patch.Size != nil || patch.Type != nil || patch.Checksum != nil || patch.ChecksumAlgorithm != nil) {
+		return nil, nil, &modelerr.InvalidArgument{Name: "path", Reason: "is required to change the size, the type or the checksum"}
+	}
+	var algorithm, checksum string
+	if patch.ChecksumAlgorithm != nil {
+		algorithm = *patch.ChecksumAlgorithm
+	}
+	if patch.Checksum != nil {
+		checksum = *patch.Checksum
+	}
+	algorithm, checksum, err := model.NormalizeChecksum(algorithm, checksum)
+	if err != nil {
+		return nil, nil, err
+	}
+	if patch.ObjectPath != nil {
+		if *patch.ObjectPath == "" {
+			return nil, nil, &modelerr.InvalidArgument{Name: "path", Reason: "must not be empty"}
+		}
+		if patch.Size == nil || *patch.Size < 0 {
+			return nil, nil, &modelerr.InvalidArgument{Name: "size", Reason: "is required with path and must not be negative"}
+		}
+	}
+	var tags []string
+	if patch.Tags != nil {
+		if tags, err = model.NormalizeTags(*patch.Tags); err != nil {
+			return nil, nil, err
+		}
+	}
+	var updated *dto.FileDTO
+	var pruned []*dto.DeletedFileDTO
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.checkRevision(ctx, patch.ID, patch.Revision); err != nil {
+			return err
+		}
+		current, err := s.repo.GetFileByID(ctx, patch.ID)
+		if err != nil {
+			return err
+		}
+		file := *current
+		var fields []dto.FileField
+		if patch.FolderID != nil && *patch.FolderID != strconv.Itoa(current.FolderID) {
+			if err := s.checkTarget(ctx, current, *patch.FolderID, "move"); err != nil {
+				return err
+			}
+			if file.FolderID, err = strconv.Atoi(*patch.FolderID); err != nil {
+				return &modelerr.InvalidArgument{Name: "folder_id", Reason: "must be a folder ID"}
+			}
+			fields = append(fields, dto.FileFolderID)
+		}
+		if patch.Name != nil && *patch.Name != current.Name {
+			file.Name = *patch.Name
+			fields = append(fields, dto.FileName)
+		}
+		if patch.Tags != nil && !slices.Equal(tags, current.Tags) {
+			file.Tags = tags
+			fields = append(fields, dto.FileTags)
+		}
+		if patch.ObjectPath != nil && *patch.ObjectPath != current.ObjectPath.String {
+			if err := s.checkQuota(ctx, current.OwnerID, *patch.Size, 0); err != nil {
+				return err
+			}
+			file.ObjectPath = sql.NullString{String: *patch.ObjectPath, Valid: true}
+			file.Size = *patch.Size
+			if patch.Type != nil {
+				file.Type = sql.NullString{String: *patch.Type, Valid: true}
+			}
+			file.ChecksumAlgorithm = sql.NullString{String: algorithm, Valid: checksum != ""}
+			file.Checksum = sql.NullString{String: checksum, Valid: checksum != ""}
+			if _, pruned, err = s.updateContent(ctx, &file); err != nil {
+				return err
+			}
+		}
+		if len(fields) > 0 {
+			if err := s.repo.PatchFile(ctx, &file, fields); err != nil {
+				return err
+			}
+		}
+		if updated, err = s.repo.GetFileByID(ctx, patch.ID); err != nil {
+			return err
+		}
+		return s.audit(ctx, model.AuditUpdate, current, updated)
+	})
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return nil, nil, err
+	}
+	logger.Log("message", "File patched", "id", patch.ID, "pruned", len(pruned))
+	return updated.ToDomain(), toDeletedFiles(pruned), nil
+}
+
+// GetFileVersions returns the history of a file, latest version first.
+func (s service) GetFileVersions(ctx context.Context, id string) ([]*model.FileVersion, error) {
+	logger := log.With(s.log, "file", "GetFileVersions")
+	versionDTOs, err := s.repo.GetFileVersions(ctx, id)
+	if err != nil {
+		var errNotFound *modelerr.NotFound
+		if errors.As(err, &errNotFound) {
+			level.Info(logger).Log("err", err, "msg", "file not found")
+			return nil, err
+		}
+		level.Error(logger).Log("err", err, "msg", "failed to retrieve file versions")
+		return nil, err
+	}
+	versions := make([]*model.FileVersion, len(versionDTOs))
+	for i, v := range versionDTOs {
+		versions[i] = v.ToDomain()
+	}
+	logger.Log("message", "File versions retrieved", "id", id, "count", len(versions))
+	return versions, nil
+}
+
+// RestoreFileVersion makes the content of a past version current again and returns the resulting version.
+// The restored content is recorded as a new version, so the history is never rewritten.
+// Restoring the current version changes nothing. A non-zero revision must match the current revision of the file.
+func (s service) RestoreFileVersion(ctx context.Context, id string, version, revision int) (int, []*model.DeletedFile, error) {
+	logger := log.With(s.log, "file", "RestoreFileVersion")
+	var restored int
+	var pruned []*dto.DeletedFileDTO
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.checkRevision(ctx, id, revision); err != nil {
+			return err
+		}
+		v, err := s.repo.GetFileVersion(ctx, id, version)
+		if err != nil {
+			return err
+		}
+		if v.Current {
+			restored = v.Version
+			return nil
+		}
+		current, err := s.repo.GetFileByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		before := *current
+		current.ObjectPath = sql.NullString{String: v.ObjectPath, Valid: true}
+		current.Size = v.Size
+		current.Type = sql.NullString{String: v.Type, Valid: true}
+		current.ChecksumAlgorithm, current.Checksum = v.ChecksumAlgorithm, v.Checksum
+		if restored, pruned, err = s.updateContent(ctx, current); err != nil {
+			return err
+		}
+		return s.auditChange(ctx, model.AuditUpdate, &before, id)
+	})
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return 0, nil, err
+	}
+	logger.Log("message", "File version restored", "id", id, "version", version, "new_version", restored, "pruned", len(pruned))
+	return restored, toDeletedFiles(pruned), nil
+}
+
+// GetDuplicates returns up to limit groups of files of an owner holding the same content, most reclaimable bytes first.
+// A zero limit selects dto.DefaultPageLimit.
+func (s service) GetDuplicates(ctx context.Context, ownerID string, limit int) (*model.Duplicates, error) {
+	logger := log.With(s.log, "file", "GetDuplicates")
+	if _, err := strconv.ParseInt(ownerID, 10, 64); err != nil {
+		return nil, &modelerr.InvalidArgument{Name: "owner_id", Reason: "must be an integer"}
+	}
+	if limit == 0 {
+		limit = dto.DefaultPageLimit
+	}
+	if limit < 0 || limit > dto.MaxPageLimit {
+		return nil, &modelerr.InvalidArgument{Name: "limit", Reason: fmt.Sprintf("must be between 1 and %d", dto.MaxPageLimit)}
+	}
+	dupDTO, err := s.repo.GetDuplicates(ctx, ownerID, limit)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return nil, err
+	}
+	logger.Log("message", "Duplicates retrieved", "owner", ownerID, "groups", dupDTO.GroupCount, "reclaimable_bytes", dupDTO.ReclaimableBytes)
+	return dupDTO.ToDomain(), nil
+}
+
+// GetUsage returns the storage used by an owner against its quota.
+func (s service) GetUsage(ctx context.Context, ownerID string) (*model.Usage, error) {
+	logger := log.With(s.log, "file", "GetUsage")
+	if _, err := strconv.ParseInt(ownerID, 10, 64); err != nil {
+		return nil, &modelerr.InvalidArgument{Name: "owner_id", Reason: "must be an integer"}
+	}
+	usageDTO, err := s.quotas.GetUsage(ctx, ownerID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return nil, err
+	}
+	usage := usageDTO.ToDomain(s.quota)
+	logger.Log("message", "Usage retrieved", "owner", ownerID, "used_bytes", usage.UsedBytes, "file_count", usage.FileCount)
+	return usage, nil
+}
+
+// checkQuota rejects adding bytes and files to the storage of an owner beyond its quota.
+// It holds the quota lock of the owner until the end of the transaction, so it must run in one.
+func (s service) checkQuota(ctx context.Context, ownerID string, bytes, files int) error {
+	if err := s.quotas.LockQuota(ctx, ownerID); err != nil {
+		return err
+	}
+	usageDTO, err := s.quotas.GetUsage(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+	return usageDTO.ToDomain(s.quota).Check(bytes, files)
+}
+
+// updateContent records the content of file as its next version and drops the versions beyond the cap.
+// It returns the new version and the stored objects no version refers to anymore.
+func (s service) updateContent(ctx context.Context, file *dto.FileDTO) (int, []*dto.DeletedFileDTO, error) {
+	version, err := s.repo.UpdateFileContent(ctx, file)
+	if err != nil {
+		return 0, nil, err
+	}
+	if s.maxVersions <= 0 {
+		return version, nil, nil
+	}
+	pruned, err := s.repo.PruneFileVersions(ctx, strconv.Itoa(file.ID), s.maxVersions)
+	return version, pruned, err
+}
+
+// toDeletedFiles converts the stored objects of pruned versions, which can now be removed from the object storage.
+func toDeletedFiles(pruned []*dto.DeletedFileDTO) []*model.DeletedFile {
+	files := make([]*model.DeletedFile, len(pruned))
+	for i, f := range pruned {
+		files[i] = f.ToDomain()
+	}
+	return files
+}
+
+// MoveFile moves a file into another folder of the same owner, resolving a name already taken there according to policy.
+// With ConflictOverwrite the file already using the name is moved to the trash.
+// A non-zero revision must match the current revision of the file.
+func (s service) MoveFile(ctx context.Context, id, folderID string, policy model.ConflictPolicy, revision int) error {
+	logger := log.With(s.log, "file", "MoveFile")
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.checkRevision(ctx, id, revision); err != nil {
+			return err
+		}
+		current, err := s.repo.GetFileByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if strconv.Itoa(current.FolderID) == folderID {
+			return nil
+		}
+		if err := s.checkTarget(ctx, current, folderID, "move"); err != nil {
+			return err
+		}
+		name, replaced, err := s.resolveName(ctx, folderID, current.Name, policy)
+		if err != nil {
+			return err
+		}
+		if replaced != nil {
+			if err := s.trash(ctx, replaced); err != nil {
+				return err
+			}
+		}
+		if err := s.repo.MoveFile(ctx, id, folderID, name); err != nil {
+			return err
+		}
+		return s.auditChange(ctx, model.AuditMove, current, id)
+	})
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+	logger.Log("message", "File moved", "id", id, "folder", folderID)
+	return nil
+}
+
+// CopyFile copies a file into a folder of the same owner, resolving a name already taken there according to policy,
+// and returns the ID of the copy. Only the metadata is copied, the copy shares the stored object of the file.
+// With ConflictOverwrite the file already using the name is moved to the trash, unless it is the copied file itself,
+// in which case the copy is renamed as with ConflictRename.
+func (s service) CopyFile(ctx context.Context, id, folderID string, policy model.ConflictPolicy) (*string, error) {
+	logger := log.With(s.log, "file", "CopyFile")
+	var copyID *string
+	err := s.tx.WithTx(ctx, func(ctx context.Context) (err error) {
+		current, err := s.repo.GetFileByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.checkTarget(ctx, current, folderID, "copy"); err != nil {
+			return err
+		}
+		name, replaced, err := s.resolveName(ctx, folderID, current.Name, policy)
+		if err != nil {
+			return err
+		}
+		if replaced != nil && replaced.ID == current.ID {
+			// The file cannot replace itself, the copy gets a numbered name next to it instead
+			if name, _, err = s.resolveName(ctx, folderID, current.Name, model.ConflictRename); err != nil {
+				return err
+			}
+		} else if replaced != nil {
+			if err := s.trash(ctx, replaced); err != nil {
+				return err
+			}
+		}
+		if err := s.checkQuota(ctx, current.OwnerID, current.Size, 1); err != nil {
+			return err
+		}
+		if copyID, err = s.repo.CopyFile(ctx, id, folderID, name); err != nil {
+			return err
+		}
+		return s.auditChange(ctx, model.AuditCreate, nil, *copyID)
+	})
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return nil, err
+	}
+	logger.Log("message", "File copied", "id", id, "folder", folderID, "copy", *copyID)
+	return copyID, nil
+}
+
+// checkTarget validates that file may be put into folderID by op, a move or a copy.
+func (s service) checkTarget(ctx context.Context, file *dto.FileDTO, folderID, op string) error {
+	id := strconv.Itoa(file.ID)
+	target, err := s.folders.GetFolderByID(ctx, folderID)
+	if err != nil {
+		var errNotFound *modelerr.NotFound
+		if errors.As(err, &errNotFound) {
+			return &modelerr.InvalidMove{ID: id, TargetID: folderID, Reason: "target folder does not exist", Op: op}
+		}
+		return err
+	}
+	if target.OwnerID != file.OwnerID {
+		return &modelerr.InvalidMove{ID: id, TargetID: folderID, Reason: "target folder belongs to another owner", Op: op}
+	}
+	return nil
+}
+
+// AddTag tags a file and returns its tags. Adding a tag the file already has changes nothing.
+// A non-zero revision must match the current revision of the file.
+func (s service) AddTag(ctx context.Context, id, tag string, revision int) ([]string, error) {
+	logger := log.With(s.log, "file", "AddTag")
+	tag, err := model.NormalizeTag(tag)
+	if err != nil {
+		return nil, err
+	}
+	var tags []string
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.checkRevision(ctx, id, revision); err != nil {
+			return err
+		}
+		current, err := s.repo.GetFileByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if tags, err = s.repo.AddFileTag(ctx, id, tag); err != nil {
+			return err
+		}
+		return s.auditChange(ctx, model.AuditUpdate, current, id)
+	})
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return nil, err
+	}
+	logger.Log("message", "File tagged", "id", id, "tag", tag)
+	return tags, nil
+}
+
+// RemoveTag removes a tag from a file and returns its remaining tags.
+// A non-zero revision must match the current revision of the file.
+func (s service) RemoveTag(ctx context.Context, id, tag string, revision int) ([]string, error) {
+	logger := log.With(s.log, "file", "RemoveTag")
+	tag, err := model.NormalizeTag(tag)
+	if err != nil {
+		return nil, err
+	}
+	var tags []string
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.checkRevision(ctx, id, revision); err != nil {
+			return err
+		}
+		current, err := s.repo.GetFileByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if tags, err = s.repo.RemoveFileTag(ctx, id, tag); err != nil {
+			return err
+		}
+		return s.auditChange(ctx, model.AuditUpdate, current, id)
+	})
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return nil, err
+	}
+	logger.Log("message", "File untagged", "id", id, "tag", tag)
+	return tags, nil
 }
 
-func (s service) DeleteFile(ctx context.Context, id string) (bool, error) {
+// GetTags returns the tags used by an owner with the number of files having each, most used first.
+func (s service) GetTags(ctx context.Context, ownerID string) ([]*model.TagUsage, error) {
+	logger := log.With(s.log, "file", "GetTags")
+	if _, err := strconv.ParseInt(ownerID, 10, 64); err != nil {
+		return nil, &modelerr.InvalidArgument{Name: "owner_id", Reason: "must be an integer"}
+	}
+	usageDTOs, err := s.repo.GetTags(ctx, ownerID)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return nil, err
+	}
+	usages := make([]*model.TagUsage, len(usageDTOs))
+	for i, u := range usageDTOs {
+		usages[i] = u.ToDomain()
+	}
+	logger.Log("message", "Tags retrieved", "owner", ownerID, "count", len(usages))
+	return usages, nil
+}
+
+// GetFilesByTag returns a page of the files of an owner having a tag and the cursor of the next page, empty on the last one.
+func (s service) GetFilesByTag(ctx context.Context, ownerID, tag string, opts model.ListOptions) ([]*model.File, string, error) {
+	logger := log.With(s.log, "file", "GetFilesByTag")
+	if _, err := strconv.ParseInt(ownerID, 10, 64); err != nil {
+		return nil, "", &modelerr.InvalidArgument{Name: "owner_id", Reason: "must be an integer"}
+	}
+	tag, err := model.NormalizeTag(tag)
+	if err != nil {
+		return nil, "", err
+	}
+	sort, err := dto.NewSortOption(opts.Sort, opts.Order)
+	if err != nil {
+		return nil, "", err
+	}
+	page, err := dto.NewPageRequest(opts.Limit, opts.Cursor, sort)
+	if err != nil {
+		return nil, "", err
+	}
+	fileDTOs, err := s.repo.GetFilesByTag(ctx, ownerID, tag, page)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return nil, "", err
+	}
+	fileDTOs, more := dto.TrimPage(page, fileDTOs)
+	var next string
+	if more {
+		next = fileDTOs[len(fileDTOs)-1].Cursor(sort).Encode()
+	}
+	files := make([]*model.File, len(fileDTOs))
+	for i, f := range fileDTOs {
+		files[i] = f.ToDomain()
+	}
+	logger.Log("message", "Files retrieved", "tag", tag, "count", len(files))
+	return files, next, nil
+}
+
+// checkRevision rejects changing a file that is not at the expected revision, zero expects any.
+// It locks the file, so its revision cannot change before the surrounding transaction ends.
+func (s service) checkRevision(ctx context.Context, id string, expected int) error {
+	if expected == 0 {
+		return nil
+	}
+	current, err := s.repo.GetFileRevision(ctx, id)
+	if err != nil {
+		return err
+	}
+	if current != expected {
+		return &modelerr.PreconditionFailed{ID: id, Expected: expected, Current: current}
+	}
+	return nil
+}
+
+// resolveName applies policy to a file name about to be used in folderID.
+// It returns the name to use and, with ConflictOverwrite, the file currently holding it.
+// With ConflictFail the name is kept and the unique index reports a conflict.
+func (s service) resolveName(ctx context.Context, folderID, name string, policy model.ConflictPolicy) (string, *dto.FileDTO, error) {
+	if policy == model.ConflictFail {
+		return name, nil, nil
+	}
+	if err := s.folders.LockFolder(ctx, folderID); err != nil {
+		return "", nil, err
+	}
+	candidate := name
+	for n := 1; n <= maxRenameAttempts; n++ {
+		existing, err := s.repo.GetFileByName(ctx, folderID, candidate)
+		var errNotFound *modelerr.NotFound
+		if errors.As(err, &errNotFound) {
+			return candidate, nil, nil
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		if policy == model.ConflictOverwrite {
+			return name, existing, nil
+		}
+		candidate = utils.NumberedName(name, n, true)
+	}
+	return "", nil, &modelerr.DuplicateError{Name: name, FolderID: folderID}
+}
+
+// DeleteFile moves a file to the trash, from where it can be restored until it is purged.
+// A non-zero revision must match the current revision of the file.
+func (s service) DeleteFile(ctx context.Context, id string, revision int) (bool, error) {
 	logger := log.With(s.log, "folder", "DeleteFolder")
-	if err := s.repo.DeleteFile(ctx, id); err != nil {
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.checkRevision(ctx, id, revision); err != nil {
+			return err
+		}
+		current, err := s.repo.GetFileByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		return s.trash(ctx, current)
+	})
+	if err != nil {
 		level.Error(logger).Log("err", err)
 		return false, err
 	}
-	logger.Log("message", "File deleted", "id", id)
+	logger.Log("message", "File moved to trash", "id", id)
 	return true, nil
 }
 
-func NewService(repo dto.FileRepository, logger log.Logger) FileService {
+// trash moves a file to the trash and records its deletion.
+func (s service) trash(ctx context.Context, file *dto.FileDTO) error {
+	if err := s.repo.TrashFile(ctx, strconv.Itoa(file.ID)); err != nil {
+		return err
+	}
+	return s.audit(ctx, model.AuditDelete, file, nil)
+}
+
+// auditChange records a change of the file id from before, nil for a created file, to its current state.
+func (s service) auditChange(ctx context.Context, action string, before *dto.FileDTO, id string) error {
+	after, err := s.repo.GetFileByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.audit(ctx, action, before, after)
+}
+
+// audit records a change of a file in the transaction bound to ctx.
+// before is nil for a created file and after for a deleted one. An update changing nothing is not recorded.
+func (s service) audit(ctx context.Context, action string, before, after *dto.FileDTO) error {
+	item := after
+	var b, a *model.File
+	if before != nil {
+		b, item = before.ToDomain(), before
+	}
+	if after != nil {
+		a, item = after.ToDomain(), after
+	}
+	record, err := model.NewAuditRecord(ctx, action, model.KindFile, strconv.Itoa(item.ID), item.OwnerID, b, a)
+	if err != nil {
+		return err
+	}
+	if action == model.AuditUpdate && len(record.Changes) == 0 {
+		return nil
+	}
+	recordDTO, err := dto.AuditRecordToDTO(record)
+	if err != nil {
+		return err
+	}
+	return s.audits.AddRecords(ctx, recordDTO)
+}
+
+// NewService creates a FileService. Mutations run as units of work started by tx.
+// The folder repository is used to validate and lock the folders files are put into.
+// At most maxVersions versions of a file are kept, zero keeps them all.
+// Owners without a quota override in quotas get the limits of quota.
+// Every change is recorded in audits in the transaction making it.
+func NewService(repo dto.FileRepository, folders dto.FolderRepository, quotas dto.QuotaRepository, audits dto.AuditRepository, tx dto.Transactor, maxVersions int, quota model.Quota, logger log.Logger) FileService {
 	return &service{
-		repo: repo,
-		log:  log.With(logger, "service", "file"),
+		repo:        repo,
+		folders:     folders,
+		quotas:      quotas,
+		audits:      audits,
+		tx:          tx,
+		maxVersions: maxVersions,
+		quota:       quota,
+		log:         log.With(logger, "service", "file"),
 	}
 }
@@ -2,31 +2,73 @@ package folder
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"remy_explorer/internal/explorer/dto"
 	modelerr "remy_explorer/internal/explorer/err"
 	"remy_explorer/internal/explorer/model"
+	"remy_explorer/internal/utils"
+	"strconv"
 )
 
+// MaxTreeDepth is the deepest folder tree that can be requested at once.
+const MaxTreeDepth = 10
+
+// maxRenameAttempts bounds the search for a free numbered name.
+const maxRenameAttempts = 1000
+
 type FolderService interface {
-	CreateFolder(ctx context.Context, folder *model.Folder) (*string, error)
+	CreateFolder(ctx context.Context, folder *model.Folder, policy model.ConflictPolicy) (*string, error)
 	GetFolderByID(ctx context.Context, id string) (*model.Folder, error)
-	GetFoldersByParentID(ctx context.Context, parentID string) ([]*model.Folder, error)
+	GetRootFolder(ctx context.Context, ownerID string) (*model.Folder, error)
+	GetFoldersByParentID(ctx context.Context, parentID string, opts model.ListOptions) ([]*model.Folder, string, error)
+	GetFolderContent(ctx context.Context, folderID string, opts model.ListOptions) ([]*model.ContentItem, string, error)
+	GetFolderPath(ctx context.Context, id string) ([]*model.Folder, error)
+	GetFolderTree(ctx context.Context, id string, depth int) (*model.FolderNode, error)
+	// UpdateFolder, MoveFolder, DeleteFolder and DeleteFolderRecursive change a folder
+	// only if it is at the given revision, zero means any.
 	UpdateFolder(ctx context.Context, folder *model.Folder) error
-	DeleteFolder(ctx context.Context, id string) error
+	PatchFolder(ctx context.Context, patch *model.FolderPatch) (*model.Folder, error)
+	MoveFolder(ctx context.Context, id, parentID string, policy model.ConflictPolicy, revision int) error
+	CopyFolder(ctx context.Context, id, parentID string, policy model.ConflictPolicy) ([]*model.CopiedItem, error)
+	DeleteFolder(ctx context.Context, id string, revision int) error
+	DeleteFolderRecursive(ctx context.Context, id string, revision int) error
 }
 
 type service struct {
-	repo dto.FolderRepository
-	log  log.Logger
+	repo   dto.FolderRepository
+	quotas dto.QuotaRepository
+	audits dto.AuditRepository
+	tx     dto.Transactor
+	quota  model.Quota
+	log    log.Logger
 }
 
-func (s service) CreateFolder(ctx context.Context, f *model.Folder) (*string, error) {
+// CreateFolder creates a folder, resolving a name already taken in the parent according to policy.
+// A folder without a parent is created in the root folder of its owner.
+func (s service) CreateFolder(ctx context.Context, f *model.Folder, policy model.ConflictPolicy) (*string, error) {
 	logger := log.With(s.log, "folder", "CreateFolder")
 	folderDTO := dto.FolderToDTO(f)
-	id, err := s.repo.CreateFolder(ctx, folderDTO)
+	var id *string
+	err := s.tx.WithTx(ctx, func(ctx context.Context) (err error) {
+		if !folderDTO.ParentID.Valid {
+			root, err := s.ensureRoot(ctx, f.OwnerID)
+			if err != nil {
+				return err
+			}
+			folderDTO.ParentID = sql.NullString{String: strconv.Itoa(root.ID), Valid: true}
+		}
+		if folderDTO.Name, err = s.freeName(ctx, folderDTO.ParentID.String, f.Name, policy); err != nil {
+			return err
+		}
+		if id, err = s.repo.CreateFolder(ctx, folderDTO); err != nil {
+			return err
+		}
+		return s.auditChange(ctx, model.AuditCreate, nil, *id)
+	})
 	if err != nil {
 		level.Error(logger).Log("err", err)
 		return nil, err
@@ -52,25 +94,165 @@ func (s service) GetFolderByID(ctx context.Context, id string) (*model.Folder, e
 	return folderDTO.ToDomain(), nil
 }
 
-func (s service) GetFoldersByParentID(ctx context.Context, parentID string) ([]*model.Folder, error) {
-	logger := log.With(s.log, "folder", "GetFoldersByParentID")
-	folderDTOs, err := s.repo.GetFoldersByParentID(ctx, parentID)
+// GetRootFolder returns the root folder of an owner, provisioning it on first use.
+func (s service) GetRootFolder(ctx context.Context, ownerID string) (*model.Folder, error) {
+	logger := log.With(s.log, "folder", "GetRootFolder")
+	root, err := s.ensureRoot(ctx, ownerID)
 	if err != nil {
 		level.Error(logger).Log("err", err)
 		return nil, err
 	}
+	logger.Log("message", "Root folder retrieved", "owner", ownerID, "id", root.ID)
+	return root.ToDomain(), nil
+}
+
+// ensureRoot validates the owner ID and returns the owner's root folder.
+func (s service) ensureRoot(ctx context.Context, ownerID string) (*dto.FolderDTO, error) {
+	if _, err := strconv.ParseInt(ownerID, 10, 64); err != nil {
+		return nil, &modelerr.InvalidArgument{Name: "owner_id", Reason: "must be an integer"}
+	}
+	return s.repo.EnsureRootFolder(ctx, ownerID)
+}
+
+// GetFoldersByParentID returns a page of the subfolders of a folder and the cursor of the next page, empty on the last one.
+func (s service) GetFoldersByParentID(ctx context.Context, parentID string, opts model.ListOptions) ([]*model.Folder, string, error) {
+	logger := log.With(s.log, "folder", "GetFoldersByParentID")
+	sort, err := dto.NewFolderSortOption(opts.Sort, opts.Order)
+	if err != nil {
+		return nil, "", err
+	}
+	page, err := dto.NewPageRequest(opts.Limit, opts.Cursor, sort)
+	if err != nil {
+		return nil, "", err
+	}
+	folderDTOs, err := s.repo.GetFoldersByParentID(ctx, parentID, page)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return nil, "", err
+	}
+	folderDTOs, more := dto.TrimPage(page, folderDTOs)
+	var next string
+	if more {
+		next = folderDTOs[len(folderDTOs)-1].Cursor(sort).Encode()
+	}
 	folders := make([]*model.Folder, len(folderDTOs))
 	for i, f := range folderDTOs {
 		folders[i] = f.ToDomain()
 	}
 	logger.Log("message", "Folders retrieved", "count", len(folders))
-	return folders, nil
+	return folders, next, nil
+}
+
+// GetFolderContent returns a page of the subfolders followed by the files of a folder
+// and the cursor of the next page, empty on the last one.
+func (s service) GetFolderContent(ctx context.Context, folderID string, opts model.ListOptions) ([]*model.ContentItem, string, error) {
+	logger := log.With(s.log, "folder", "GetFolderContent")
+	sort, err := dto.NewSortOption(opts.Sort, opts.Order)
+	if err != nil {
+		return nil, "", err
+	}
+	page, err := dto.NewPageRequest(opts.Limit, opts.Cursor, sort)
+	if err != nil {
+		return nil, "", err
+	}
+	itemDTOs, err := s.repo.GetFolderContent(ctx, folderID, page)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return nil, "", err
+	}
+	itemDTOs, more := dto.TrimPage(page, itemDTOs)
+	var next string
+	if more {
+		next = itemDTOs[len(itemDTOs)-1].Cursor(sort).Encode()
+	}
+	items := make([]*model.ContentItem, len(itemDTOs))
+	for i, c := range itemDTOs {
+		items[i] = c.ToDomain()
+	}
+	logger.Log("message", "Folder content retrieved", "count", len(items))
+	return items, next, nil
+}
+
+// GetFolderPath returns the breadcrumb of a folder: its ancestors from the root, ending with the folder itself.
+func (s service) GetFolderPath(ctx context.Context, id string) ([]*model.Folder, error) {
+	logger := log.With(s.log, "folder", "GetFolderPath")
+	pathDTOs, err := s.repo.GetFolderPath(ctx, id)
+	if err != nil {
+		var errNotFound *modelerr.NotFound
+		if errors.As(err, &errNotFound) {
+			level.Info(logger).Log("err", err, "msg", "folder not found")
+			return nil, err
+		}
+		level.Error(logger).Log("err", err)
+		return nil, err
+	}
+	path := make([]*model.Folder, len(pathDTOs))
+	for i, f := range pathDTOs {
+		path[i] = f.ToDomain()
+	}
+	logger.Log("message", "Folder path retrieved", "id", id, "depth", len(path))
+	return path, nil
+}
+
+// GetFolderTree returns the folder with its subfolders nested up to depth levels below it.
+func (s service) GetFolderTree(ctx context.Context, id string, depth int) (*model.FolderNode, error) {
+	logger := log.With(s.log, "folder", "GetFolderTree")
+	if depth < 0 || depth > MaxTreeDepth {
+		return nil, &modelerr.InvalidArgument{Name: "depth", Reason: fmt.Sprintf("must be between 0 and %d", MaxTreeDepth)}
+	}
+	nodeDTOs, err := s.repo.GetFolderTree(ctx, id, depth)
+	if err != nil {
+		var errNotFound *modelerr.NotFound
+		if errors.As(err, &errNotFound) {
+			level.Info(logger).Log("err", err, "msg", "folder not found")
+			return nil, err
+		}
+		level.Error(logger).Log("err", err)
+		return nil, err
+	}
+	// Parents come before their children, so every child finds its parent already indexed
+	root := nodeDTOs[0].ToDomain()
+	byID := map[string]*model.FolderNode{root.ID: root}
+	for _, n := range nodeDTOs[1:] {
+		node := n.ToDomain()
+		byID[node.ID] = node
+		if parent, ok := byID[n.ParentID.String]; ok {
+			parent.Children = append(parent.Children, node)
+		}
+	}
+	logger.Log("message", "Folder tree retrieved", "id", id, "nodes", len(nodeDTOs))
+	return root, nil
 }
 
+// UpdateFolder renames a folder and, with a parent given, moves it.
+// A non-zero folder.Revision must match the current revision of the folder.
 func (s service) UpdateFolder(ctx context.Context, folder *model.Folder) error {
 	logger := log.With(s.log, "folder", "UpdateFolder")
 	folderDTO := dto.FolderToDTO(folder)
-	if err := s.repo.UpdateFolder(ctx, folderDTO); err != nil {
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.checkRevision(ctx, folder.ID, folder.Revision); err != nil {
+			return err
+		}
+		current, err := s.repo.GetFolderByID(ctx, folder.ID)
+		if err != nil {
+			return err
+		}
+		// An empty parent keeps the folder where it is, any other one must pass the move checks
+		if folder.ParentID == "" {
+			folderDTO.ParentID = current.ParentID
+		} else if !current.ParentID.Valid {
+			return &modelerr.InvalidMove{ID: folder.ID, TargetID: folder.ParentID, Reason: "the root folder cannot be moved"}
+		} else if folder.ParentID != current.ParentID.String {
+			if err := s.checkMove(ctx, current, folder.ParentID); err != nil {
+				return err
+			}
+		}
+		if err := s.repo.UpdateFolder(ctx, folderDTO); err != nil {
+			return err
+		}
+		return s.auditChange(ctx, model.AuditUpdate, current, folder.ID)
+	})
+	if err != nil {
 		level.Error(logger).Log("err", err)
 		return err
 	}
@@ -78,19 +260,361 @@ func (s service) UpdateFolder(ctx context.Context, folder *model.Folder) error {
 	return nil
 }
 
-func (s service) DeleteFolder(ctx context.Context, id string) error {
+// PatchFolder changes only the fields set in patch and returns the updated folder.
+// A new parent must pass the same checks as a move, without resolving name conflicts.
+func (s service) PatchFolder(ctx context.Context, patch *model.FolderPatch) (*model.Folder, error) {
+	logger := log.With(s.log, "folder", "PatchFolder")
+	if patch.Name != nil && *patch.Name == "" {
+		return nil, &modelerr.InvalidArgument{Name: "name", Reason: "must not be empty"}
+	}
+	var updated *dto.FolderDTO
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.checkRevision(ctx, patch.ID, patch.Revision); err != nil {
+			return err
+		}
+		current, err := s.repo.GetFolderByID(ctx, patch.ID)
+		if err != nil {
+			return err
+		}
+		folder := *current
+		var fields []dto.FolderField
+		if patch.ParentID != nil && *patch.ParentID != current.ParentID.String {
+			if !current.ParentID.Valid {
+				return &modelerr.InvalidMove{ID: patch.ID, TargetID: *patch.ParentID, Reason: "the root folder cannot be moved"}
+			}
+			if err := s.checkMove(ctx, current, *patch.ParentID); err != nil {
+				return err
+			}
+			folder.ParentID = sql.NullString{String: *patch.ParentID, Valid: true}
+			fields = append(fields, dto.FolderParentID)
+		}
+		if patch.Name != nil && *patch.Name != current.Name {
+			folder.Name = *patch.Name
+			fields = append(fields, dto.FolderName)
+		}
+		if len(fields) > 0 {
+			if err := s.repo.PatchFolder(ctx, &folder, fields); err != nil {
+				return err
+			}
+		}
+		if updated, err = s.repo.GetFolderByID(ctx, patch.ID); err != nil {
+			return err
+		}
+		return s.audit(ctx, model.AuditUpdate, current, updated)
+	})
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return nil, err
+	}
+	logger.Log("message", "Folder patched", "id", patch.ID)
+	return updated.ToDomain(), nil
+}
+
+// MoveFolder moves a folder under a new parent, resolving a name already taken there according to policy.
+// The target must exist, belong to the same owner and lie outside the moved subtree.
+// A non-zero revision must match the current revision of the folder.
+func (s service) MoveFolder(ctx context.Context, id, parentID string, policy model.ConflictPolicy, revision int) error {
+	logger := log.With(s.log, "folder", "MoveFolder")
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.checkRevision(ctx, id, revision); err != nil {
+			return err
+		}
+		current, err := s.repo.GetFolderByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !current.ParentID.Valid {
+			return &modelerr.InvalidMove{ID: id, TargetID: parentID, Reason: "the root folder cannot be moved"}
+		}
+		if current.ParentID.String == parentID {
+			return nil
+		}
+		if err := s.checkMove(ctx, current, parentID); err != nil {
+			return err
+		}
+		name, err := s.freeName(ctx, parentID, current.Name, policy)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.MoveFolder(ctx, id, parentID, name); err != nil {
+			return err
+		}
+		return s.auditChange(ctx, model.AuditMove, current, id)
+	})
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+	logger.Log("message", "Folder moved", "id", id, "parent", parentID)
+	return nil
+}
+
+// CopyFolder copies a folder with the subfolders and files below it under parentID, resolving a name already taken
+// there according to policy, and returns the folders followed by the files copied. Items in the trash are not copied.
+// Only the metadata is copied, the copied files share the stored objects of the original ones.
+// The copied files must fit in the quota of the owner.
+func (s service) CopyFolder(ctx context.Context, id, parentID string, policy model.ConflictPolicy) ([]*model.CopiedItem, error) {
+	logger := log.With(s.log, "folder", "CopyFolder")
+	var copied []*dto.CopiedItemDTO
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		current, err := s.repo.GetFolderByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.checkTarget(ctx, current, parentID, "copy"); err != nil {
+			return err
+		}
+		name, err := s.freeName(ctx, parentID, current.Name, policy)
+		if err != nil {
+			return err
+		}
+		if err := s.checkQuota(ctx, current); err != nil {
+			return err
+		}
+		if copied, err = s.repo.CopyFolder(ctx, id, parentID, name); err != nil {
+			return err
+		}
+		return s.auditCopies(ctx, current.OwnerID, copied)
+	})
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return nil, err
+	}
+	items := make([]*model.CopiedItem, len(copied))
+	for i, c := range copied {
+		items[i] = c.ToDomain()
+	}
+	logger.Log("message", "Folder copied", "id", id, "parent", parentID, "items", len(items))
+	return items, nil
+}
+
+// checkQuota rejects copying the files below folder when they do not fit in the quota of the owner.
+// It holds the quota lock of the owner until the end of the transaction, so it must run in one.
+func (s service) checkQuota(ctx context.Context, folder *dto.FolderDTO) error {
+	if err := s.quotas.LockQuota(ctx, folder.OwnerID); err != nil {
+		return err
+	}
+	// No file of the owner can be added any more, so the totals read now are the ones copied
+	current, err := s.repo.GetFolderByID(ctx, strconv.Itoa(folder.ID))
+	if err != nil {
+		return err
+	}
+	usage, err := s.quotas.GetUsage(ctx, folder.OwnerID)
+	if err != nil {
+		return err
+	}
+	return usage.ToDomain(s.quota).Check(current.TotalSize, current.FileCount)
+}
+
+// checkMove validates that folder may be moved under parentID.
+// It locks the owner's tree, so the result stays valid until the surrounding transaction ends.
+func (s service) checkMove(ctx context.Context, folder *dto.FolderDTO, parentID string) error {
+	if err := s.checkTarget(ctx, folder, parentID, "move"); err != nil {
+		return err
+	}
+	id := strconv.Itoa(folder.ID)
+	inSubtree, err := s.repo.IsInSubtree(ctx, id, parentID)
+	if err != nil {
+		return err
+	}
+	if inSubtree {
+		return &modelerr.MoveCycle{ID: id, TargetID: parentID}
+	}
+	return nil
+}
+
+// checkTarget validates that parentID may receive folder by op, a move or a copy.
+// It locks the owner's tree, so the result stays valid until the surrounding transaction ends.
+func (s service) checkTarget(ctx context.Context, folder *dto.FolderDTO, parentID, op string) error {
+	id := strconv.Itoa(folder.ID)
+	if err := s.repo.LockTree(ctx, folder.OwnerID); err != nil {
+		return err
+	}
+	target, err := s.repo.GetFolderByID(ctx, parentID)
+	if err != nil {
+		var errNotFound *modelerr.NotFound
+		if errors.As(err, &errNotFound) {
+			return &modelerr.InvalidMove{ID: id, TargetID: parentID, Reason: "target folder does not exist", Op: op}
+		}
+		return err
+	}
+	if target.OwnerID != folder.OwnerID {
+		return &modelerr.InvalidMove{ID: id, TargetID: parentID, Reason: "target folder belongs to another owner", Op: op}
+	}
+	return nil
+}
+
+// freeName applies policy to a folder name about to be used in parentID and returns the name to use.
+// With ConflictFail the name is kept and the unique index reports a conflict.
+func (s service) freeName(ctx context.Context, parentID, name string, policy model.ConflictPolicy) (string, error) {
+	switch policy {
+	case model.ConflictFail:
+		return name, nil
+	case model.ConflictOverwrite:
+		return "", &modelerr.InvalidArgument{Name: "on_conflict", Reason: "overwrite is only supported for files"}
+	}
+	if err := s.repo.LockFolder(ctx, parentID); err != nil {
+		return "", err
+	}
+	candidate := name
+	for n := 1; n <= maxRenameAttempts; n++ {
+		_, err := s.repo.GetFolderByName(ctx, parentID, candidate)
+		var errNotFound *modelerr.NotFound
+		if errors.As(err, &errNotFound) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		candidate = utils.NumberedName(name, n, false)
+	}
+	return "", &modelerr.DuplicateError{Name: name, FolderID: parentID}
+}
+
+// DeleteFolder moves an empty folder to the trash.
+// A non-zero revision must match the current revision of the folder.
+func (s service) DeleteFolder(ctx context.Context, id string, revision int) error {
 	logger := log.With(s.log, "folder", "DeleteFolder")
-	if err := s.repo.DeleteFolder(ctx, id); err != nil {
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.checkRevision(ctx, id, revision); err != nil {
+			return err
+		}
+		current, err := s.checkNotRoot(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.TrashFolder(ctx, id); err != nil {
+			return err
+		}
+		return s.audit(ctx, model.AuditDelete, current, nil)
+	})
+	if err != nil {
 		level.Error(logger).Log("err", err)
 		return err
 	}
-	logger.Log("message", "Folder deleted", "id", id)
+	logger.Log("message", "Folder moved to trash", "id", id)
 	return nil
 }
 
-func NewService(repo dto.FolderRepository, logger log.Logger) FolderService {
+// DeleteFolderRecursive moves a folder with its whole subtree to the trash in one transaction.
+// The subtree is restored or purged as a whole. A non-zero revision must match the current revision of the folder.
+func (s service) DeleteFolderRecursive(ctx context.Context, id string, revision int) error {
+	logger := log.With(s.log, "folder", "DeleteFolderRecursive")
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.checkRevision(ctx, id, revision); err != nil {
+			return err
+		}
+		current, err := s.checkNotRoot(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.TrashFolderRecursive(ctx, id); err != nil {
+			return err
+		}
+		// The subtree goes to the trash as a whole, so its deletion is recorded for the folder only
+		return s.audit(ctx, model.AuditDelete, current, nil)
+	})
+	if err != nil {
+		var errNotFound *modelerr.NotFound
+		if errors.As(err, &errNotFound) {
+			level.Info(logger).Log("err", err, "msg", "folder not found")
+			return err
+		}
+		level.Error(logger).Log("err", err)
+		return err
+	}
+	logger.Log("message", "Folder moved to trash recursively", "id", id)
+	return nil
+}
+
+// checkRevision rejects changing a folder that is not at the expected revision, zero expects any.
+// It locks the folder, so its revision cannot change before the surrounding transaction ends.
+func (s service) checkRevision(ctx context.Context, id string, expected int) error {
+	if expected == 0 {
+		return nil
+	}
+	current, err := s.repo.GetFolderRevision(ctx, id)
+	if err != nil {
+		return err
+	}
+	if current != expected {
+		return &modelerr.PreconditionFailed{ID: id, Expected: expected, Current: current}
+	}
+	return nil
+}
+
+// checkNotRoot rejects deleting the root folder of an owner, which every other folder hangs from.
+// It returns the folder otherwise.
+func (s service) checkNotRoot(ctx context.Context, id string) (*dto.FolderDTO, error) {
+	f, err := s.repo.GetFolderByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !f.ParentID.Valid {
+		return nil, &modelerr.InvalidArgument{Name: "id", Reason: "the root folder cannot be deleted"}
+	}
+	return f, nil
+}
+
+// auditChange records a change of the folder id from before, nil for a created folder, to its current state.
+func (s service) auditChange(ctx context.Context, action string, before *dto.FolderDTO, id string) error {
+	after, err := s.repo.GetFolderByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.audit(ctx, action, before, after)
+}
+
+// audit records a change of a folder in the transaction bound to ctx.
+// before is nil for a created folder and after for a deleted one. An update changing nothing is not recorded.
+func (s service) audit(ctx context.Context, action string, before, after *dto.FolderDTO) error {
+	item := after
+	var b, a *model.Folder
+	if before != nil {
+		b, item = before.ToDomain(), before
+	}
+	if after != nil {
+		a, item = after.ToDomain(), after
+	}
+	record, err := model.NewAuditRecord(ctx, action, model.KindFolder, strconv.Itoa(item.ID), item.OwnerID, b, a)
+	if err != nil {
+		return err
+	}
+	if action == model.AuditUpdate && len(record.Changes) == 0 {
+		return nil
+	}
+	recordDTO, err := dto.AuditRecordToDTO(record)
+	if err != nil {
+		return err
+	}
+	return s.audits.AddRecords(ctx, recordDTO)
+}
+
+// auditCopies records the creation of the folders and files of a copied subtree of an owner, in one statement.
+func (s service) auditCopies(ctx context.Context, ownerID string, copied []*dto.CopiedItemDTO) error {
+	records := make([]*dto.AuditRecordDTO, len(copied))
+	for i, c := range copied {
+		record, err := model.NewAuditRecord(ctx, model.AuditCreate, c.Kind, strconv.Itoa(c.ID), ownerID, nil, c.ToDomain())
+		if err != nil {
+			return err
+		}
+		if records[i], err = dto.AuditRecordToDTO(record); err != nil {
+			return err
+		}
+	}
+	return s.audits.AddRecords(ctx, records...)
+}
+
+// NewService creates a FolderService. Mutations run as units of work started by tx.
+// Owners without a quota override in quotas get the limits of quota.
+// Every change is recorded in audits in the transaction making it.
+func NewService(repo dto.FolderRepository, quotas dto.QuotaRepository, audits dto.AuditRepository, tx dto.Transactor, quota model.Quota, logger log.Logger) FolderService {
 	return &service{
-		repo: repo,
-		log:  log.With(logger, "service", "folder"),
+		repo:   repo,
+		quotas: quotas,
+		audits: audits,
+		tx:     tx,
+		quota:  quota,
+		log:    log.With(logger, "service", "folder"),
 	}
 }
@@ -0,0 +1,92 @@
+package recent
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"remy_explorer/internal/explorer/dto"
+	modelerr "remy_explorer/internal/explorer/err"
+	"remy_explorer/internal/explorer/model"
+	"strconv"
+)
+
+// RecentService records the files owners open and provides their recent feeds
+type RecentService interface {
+	RecordAccess(ctx context.Context, fileID string) error
+	GetRecent(ctx context.Context, ownerID, kind string, limit int) ([]*model.RecentFile, error)
+}
+
+type service struct {
+	repo       dto.RecentRepository
+	tx         dto.Transactor
+	maxEntries int
+	log        log.Logger
+}
+
+// RecordAccess records that a file was opened now. Opening a file again moves it to the top of the opened feed.
+func (s service) RecordAccess(ctx context.Context, fileID string) error {
+	logger := log.With(s.log, "recent", "RecordAccess")
+	if _, err := strconv.ParseInt(fileID, 10, 64); err != nil {
+		return &modelerr.InvalidArgument{Name: "id", Reason: "must be an integer"}
+	}
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		return s.repo.RecordAccess(ctx, fileID, s.maxEntries)
+	})
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+	logger.Log("message", "File access recorded", "id", fileID)
+	return nil
+}
+
+// GetRecent returns the last files an owner opened or changed, depending on kind, most recent first.
+// Each file is listed once and the feed holds at most as many files as the history of an owner keeps.
+func (s service) GetRecent(ctx context.Context, ownerID, kind string, limit int) ([]*model.RecentFile, error) {
+	logger := log.With(s.log, "recent", "GetRecent")
+	if _, err := strconv.ParseInt(ownerID, 10, 64); err != nil {
+		return nil, &modelerr.InvalidArgument{Name: "owner_id", Reason: "must be an integer"}
+	}
+	if limit == 0 {
+		limit = dto.DefaultPageLimit
+	}
+	if limit < 0 || limit > dto.MaxPageLimit {
+		return nil, &modelerr.InvalidArgument{Name: "limit", Reason: fmt.Sprintf("must be between 1 and %d", dto.MaxPageLimit)}
+	}
+	if s.maxEntries > 0 && limit > s.maxEntries {
+		limit = s.maxEntries
+	}
+	var fileDTOs []*dto.RecentFileDTO
+	var err error
+	switch kind {
+	case model.RecentOpened, "":
+		kind = model.RecentOpened
+		fileDTOs, err = s.repo.GetOpened(ctx, ownerID, limit)
+	case model.RecentModified:
+		fileDTOs, err = s.repo.GetModified(ctx, ownerID, limit)
+	default:
+		return nil, &modelerr.InvalidArgument{Name: "kind", Reason: "must be opened or modified"}
+	}
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return nil, err
+	}
+	files := make([]*model.RecentFile, len(fileDTOs))
+	for i, f := range fileDTOs {
+		files[i] = f.ToDomain()
+	}
+	logger.Log("message", "Recent files retrieved", "owner", ownerID, "kind", kind, "count", len(files))
+	return files, nil
+}
+
+// NewService creates a new recent service.
+// The opened feed of an owner keeps at most maxEntries files, zero keeps them all.
+func NewService(repo dto.RecentRepository, tx dto.Transactor, maxEntries int, logger log.Logger) RecentService {
+	return &service{
+		repo:       repo,
+		tx:         tx,
+		maxEntries: maxEntries,
+		log:        log.With(logger, "service", "recent"),
+	}
+}
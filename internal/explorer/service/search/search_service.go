@@ -0,0 +1,125 @@
+package search
+
+import (
+	"context"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"remy_explorer/internal/explorer/dto"
+	modelerr "remy_explorer/internal/explorer/err"
+	"remy_explorer/internal/explorer/model"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxTextLength is the maximum number of characters of a searched text, the length of the longest name.
+const maxTextLength = 255
+
+// SearchService provides the search of folders and files by name across all the folders of an owner
+type SearchService interface {
+	Search(ctx context.Context, q model.SearchQuery) ([]*model.SearchHit, string, error)
+}
+
+type service struct {
+	repo dto.SearchRepository
+	log  log.Logger
+}
+
+// Search returns a page of the folders and files of an owner matching q, most relevant first,
+// and the cursor of the next page, empty on the last one. Items in the trash are not searched.
+func (s service) Search(ctx context.Context, q model.SearchQuery) ([]*model.SearchHit, string, error) {
+	logger := log.With(s.log, "search", "Search")
+	filter, err := newFilter(q)
+	if err != nil {
+		return nil, "", err
+	}
+	page, err := dto.NewPageRequest(q.Limit, q.Cursor, dto.SearchSortOption)
+	if err != nil {
+		return nil, "", err
+	}
+	hitDTOs, err := s.repo.Search(ctx, filter, page)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return nil, "", err
+	}
+	hitDTOs, more := dto.TrimPage(page, hitDTOs)
+	var next string
+	if more {
+		next = hitDTOs[len(hitDTOs)-1].Cursor(dto.SearchSortOption).Encode()
+	}
+	hits := make([]*model.SearchHit, len(hitDTOs))
+	for i, h := range hitDTOs {
+		hits[i] = h.ToDomain()
+	}
+	logger.Log("message", "Search done", "owner", q.OwnerID, "count", len(hits))
+	return hits, next, nil
+}
+
+// newFilter validates a search query and normalizes its text and tags.
+// The text is matched case-insensitively with its whitespace collapsed, like the tags.
+func newFilter(q model.SearchQuery) (*dto.SearchFilter, error) {
+	if _, err := strconv.ParseInt(q.OwnerID, 10, 64); err != nil {
+		return nil, &modelerr.InvalidArgument{Name: "owner_id", Reason: "must be an integer"}
+	}
+	text := strings.ToLower(strings.Join(strings.Fields(q.Text), " "))
+	if text == "" {
+		return nil, &modelerr.InvalidArgument{Name: "q", Reason: "must not be blank"}
+	}
+	if utf8.RuneCountInString(text) > maxTextLength {
+		return nil, &modelerr.InvalidArgument{Name: "q", Reason: "is too long"}
+	}
+	switch q.Kind {
+	case "", model.KindFolder, model.KindFile:
+	default:
+		return nil, &modelerr.InvalidArgument{Name: "kind", Reason: "must be folder or file"}
+	}
+	if q.MinSize != nil && *q.MinSize < 0 {
+		return nil, &modelerr.InvalidArgument{Name: "min_size", Reason: "must not be negative"}
+	}
+	if q.MaxSize != nil && *q.MaxSize < 0 {
+		return nil, &modelerr.InvalidArgument{Name: "max_size", Reason: "must not be negative"}
+	}
+	if q.MinSize != nil && q.MaxSize != nil && *q.MinSize > *q.MaxSize {
+		return nil, &modelerr.InvalidArgument{Name: "max_size", Reason: "must not be less than min_size"}
+	}
+	if err := checkRange("created_before", q.CreatedAfter, q.CreatedBefore); err != nil {
+		return nil, err
+	}
+	if err := checkRange("updated_before", q.UpdatedAfter, q.UpdatedBefore); err != nil {
+		return nil, err
+	}
+	tags, err := model.NormalizeTags(q.Tags)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.SearchFilter{
+		OwnerID:       q.OwnerID,
+		Text:          text,
+		Kind:          q.Kind,
+		Type:          q.Type,
+		MinSize:       q.MinSize,
+		MaxSize:       q.MaxSize,
+		CreatedAfter:  q.CreatedAfter,
+		CreatedBefore: q.CreatedBefore,
+		UpdatedAfter:  q.UpdatedAfter,
+		UpdatedBefore: q.UpdatedBefore,
+		Tags:          tags,
+	}, nil
+}
+
+// checkRange rejects a date range whose end, named name, is not after its start.
+func checkRange(name string, after, before *time.Time) error {
+	if after != nil && before != nil && !before.After(*after) {
+		return &modelerr.InvalidArgument{Name: name, Reason: "must be later than the start of the range"}
+	}
+	return nil
+}
+
+// NewService creates a SearchService.
+func NewService(repo dto.SearchRepository, logger log.Logger) SearchService {
+	return &service{
+		repo: repo,
+		log:  log.With(logger, "service", "search"),
+	}
+}
@@ -0,0 +1,89 @@
+package star
+
+import (
+	"context"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"remy_explorer/internal/explorer/dto"
+	modelerr "remy_explorer/internal/explorer/err"
+	"remy_explorer/internal/explorer/model"
+	"strconv"
+)
+
+// StarService provides the operations on the folders and files owners starred
+type StarService interface {
+	Star(ctx context.Context, kind, id string) error
+	Unstar(ctx context.Context, kind, id string) error
+	GetStarred(ctx context.Context, ownerID string, opts model.ListOptions) ([]*model.StarredItem, string, error)
+}
+
+type service struct {
+	repo dto.StarRepository
+	log  log.Logger
+}
+
+// Star stars a folder or file. Starring a starred item changes nothing.
+func (s service) Star(ctx context.Context, kind, id string) error {
+	logger := log.With(s.log, "star", "Star")
+	if err := s.setStarred(ctx, kind, id, true); err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+	logger.Log("message", "Item starred", "kind", kind, "id", id)
+	return nil
+}
+
+// Unstar removes the star of a folder or file. Unstarring an item without a star changes nothing.
+func (s service) Unstar(ctx context.Context, kind, id string) error {
+	logger := log.With(s.log, "star", "Unstar")
+	if err := s.setStarred(ctx, kind, id, false); err != nil {
+		level.Error(logger).Log("err", err)
+		return err
+	}
+	logger.Log("message", "Item unstarred", "kind", kind, "id", id)
+	return nil
+}
+
+func (s service) setStarred(ctx context.Context, kind, id string, starred bool) error {
+	if kind != model.KindFile && kind != model.KindFolder {
+		return &modelerr.InvalidArgument{Name: "kind", Reason: "must be folder or file"}
+	}
+	return s.repo.SetStarred(ctx, kind, id, starred)
+}
+
+// GetStarred returns a page of the items an owner starred, most recently starred first,
+// and the cursor of the next page, empty on the last one. Only the limit and cursor of opts are used.
+func (s service) GetStarred(ctx context.Context, ownerID string, opts model.ListOptions) ([]*model.StarredItem, string, error) {
+	logger := log.With(s.log, "star", "GetStarred")
+	if _, err := strconv.ParseInt(ownerID, 10, 64); err != nil {
+		return nil, "", &modelerr.InvalidArgument{Name: "owner_id", Reason: "must be an integer"}
+	}
+	page, err := dto.NewPageRequest(opts.Limit, opts.Cursor, dto.StarSortOption)
+	if err != nil {
+		return nil, "", err
+	}
+	itemDTOs, err := s.repo.GetStarred(ctx, ownerID, page)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return nil, "", err
+	}
+	itemDTOs, more := dto.TrimPage(page, itemDTOs)
+	var next string
+	if more {
+		next = itemDTOs[len(itemDTOs)-1].Cursor(dto.StarSortOption).Encode()
+	}
+	items := make([]*model.StarredItem, len(itemDTOs))
+	for i, t := range itemDTOs {
+		items[i] = t.ToDomain()
+	}
+	logger.Log("message", "Starred items retrieved", "owner", ownerID, "count", len(items))
+	return items, next, nil
+}
+
+// NewService creates a new star service
+func NewService(repo dto.StarRepository, logger log.Logger) StarService {
+	return &service{
+		repo: repo,
+		log:  log.With(logger, "service", "star"),
+	}
+}
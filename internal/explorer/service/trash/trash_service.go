@@ -0,0 +1,212 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"remy_explorer/internal/explorer/dto"
+	modelerr "remy_explorer/internal/explorer/err"
+	"remy_explorer/internal/explorer/model"
+	"remy_explorer/internal/utils"
+	"strconv"
+	"time"
+)
+
+// maxRenameAttempts bounds the search for a free numbered name.
+const maxRenameAttempts = 1000
+
+// TrashService provides the operations on deleted folders and files
+type TrashService interface {
+	GetTrash(ctx context.Context, ownerID string, opts model.ListOptions) ([]*model.TrashItem, string, error)
+	Restore(ctx context.Context, kind, id string) (*model.TrashItem, error)
+	Purge(ctx context.Context, kind, id string) ([]*model.DeletedFile, error)
+	PurgeExpired(ctx context.Context) ([]*model.DeletedFile, error)
+}
+
+type service struct {
+	repo      dto.TrashRepository
+	files     dto.FileRepository
+	folders   dto.FolderRepository
+	tx        dto.Transactor
+	retention time.Duration
+	log       log.Logger
+}
+
+// GetTrash returns a page of the items an owner deleted, most recently deleted first,
+// and the cursor of the next page, empty on the last one. Only the limit and cursor of opts are used.
+func (s service) GetTrash(ctx context.Context, ownerID string, opts model.ListOptions) ([]*model.TrashItem, string, error) {
+	logger := log.With(s.log, "trash", "GetTrash")
+	if _, err := strconv.ParseInt(ownerID, 10, 64); err != nil {
+		return nil, "", &modelerr.InvalidArgument{Name: "owner_id", Reason: "must be an integer"}
+	}
+	page, err := dto.NewPageRequest(opts.Limit, opts.Cursor, dto.TrashSortOption)
+	if err != nil {
+		return nil, "", err
+	}
+	itemDTOs, err := s.repo.GetTrash(ctx, ownerID, page)
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return nil, "", err
+	}
+	itemDTOs, more := dto.TrimPage(page, itemDTOs)
+	var next string
+	if more {
+		next = itemDTOs[len(itemDTOs)-1].Cursor(dto.TrashSortOption).Encode()
+	}
+	items := make([]*model.TrashItem, len(itemDTOs))
+	for i, t := range itemDTOs {
+		items[i] = t.ToDomain()
+	}
+	logger.Log("message", "Trash retrieved", "owner", ownerID, "count", len(items))
+	return items, next, nil
+}
+
+// Restore takes an item out of the trash, a folder together with the items deleted with it.
+// The item returns to its original folder, or to the owner's root folder if that one is gone.
+// It is renamed if its name has been taken in the meantime. The restored item is returned at its new place.
+func (s service) Restore(ctx context.Context, kind, id string) (*model.TrashItem, error) {
+	logger := log.With(s.log, "trash", "Restore")
+	if err := checkKind(kind); err != nil {
+		return nil, err
+	}
+	var item *dto.TrashItemDTO
+	err := s.tx.WithTx(ctx, func(ctx context.Context) (err error) {
+		item, err = s.repo.GetTrashItem(ctx, kind, id)
+		if err != nil {
+			return err
+		}
+		parentID := strconv.Itoa(item.ParentID)
+		if _, err := s.folders.GetFolderByID(ctx, parentID); err != nil {
+			var errNotFound *modelerr.NotFound
+			if !errors.As(err, &errNotFound) {
+				return err
+			}
+			root, err := s.folders.EnsureRootFolder(ctx, item.OwnerID)
+			if err != nil {
+				return err
+			}
+			item.ParentID = root.ID
+			parentID = strconv.Itoa(root.ID)
+		}
+		if item.Name, err = s.freeName(ctx, kind, parentID, item.Name); err != nil {
+			return err
+		}
+		if kind == model.KindFile {
+			return s.repo.RestoreFile(ctx, id, parentID, item.Name)
+		}
+		return s.repo.RestoreFolder(ctx, id, parentID, item.Name)
+	})
+	if err != nil {
+		var errNotFound *modelerr.NotFound
+		if errors.As(err, &errNotFound) {
+			level.Info(logger).Log("err", err, "msg", "trash item not found")
+			return nil, err
+		}
+		level.Error(logger).Log("err", err)
+		return nil, err
+	}
+	logger.Log("message", "Item restored", "kind", kind, "id", id, "parent", item.ParentID)
+	return item.ToDomain(), nil
+}
+
+// freeName returns name, or the first free numbered variant of it if an item of the kind in parentID uses it.
+func (s service) freeName(ctx context.Context, kind, parentID, name string) (string, error) {
+	if err := s.folders.LockFolder(ctx, parentID); err != nil {
+		return "", err
+	}
+	candidate := name
+	for n := 1; n <= maxRenameAttempts; n++ {
+		var err error
+		if kind == model.KindFile {
+			_, err = s.files.GetFileByName(ctx, parentID, candidate)
+		} else {
+			_, err = s.folders.GetFolderByName(ctx, parentID, candidate)
+		}
+		var errNotFound *modelerr.NotFound
+		if errors.As(err, &errNotFound) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		candidate = utils.NumberedName(name, n, kind == model.KindFile)
+	}
+	return "", &modelerr.DuplicateError{Name: name, FolderID: parentID}
+}
+
+// Purge deletes an item of the trash for good, a folder with its whole subtree,
+// and returns the deleted files so their stored objects can be purged.
+func (s service) Purge(ctx context.Context, kind, id string) ([]*model.DeletedFile, error) {
+	logger := log.With(s.log, "trash", "Purge")
+	if err := checkKind(kind); err != nil {
+		return nil, err
+	}
+	var deleted []*dto.DeletedFileDTO
+	err := s.tx.WithTx(ctx, func(ctx context.Context) (err error) {
+		if kind == model.KindFolder {
+			deleted, err = s.repo.PurgeFolder(ctx, id)
+		} else {
+			deleted, err = s.repo.PurgeFile(ctx, id)
+		}
+		return err
+	})
+	if err != nil {
+		var errNotFound *modelerr.NotFound
+		if errors.As(err, &errNotFound) {
+			level.Info(logger).Log("err", err, "msg", "trash item not found")
+			return nil, err
+		}
+		level.Error(logger).Log("err", err)
+		return nil, err
+	}
+	files := toDeletedFiles(deleted)
+	logger.Log("message", "Item purged", "kind", kind, "id", id, "files", len(files))
+	return files, nil
+}
+
+// PurgeExpired deletes for good every item that has been in the trash longer than the retention period
+// and returns the deleted files so their stored objects can be purged.
+func (s service) PurgeExpired(ctx context.Context) ([]*model.DeletedFile, error) {
+	logger := log.With(s.log, "trash", "PurgeExpired")
+	var deleted []*dto.DeletedFileDTO
+	err := s.tx.WithTx(ctx, func(ctx context.Context) (err error) {
+		deleted, err = s.repo.PurgeExpired(ctx, s.retention)
+		return err
+	})
+	if err != nil {
+		level.Error(logger).Log("err", err)
+		return nil, err
+	}
+	files := toDeletedFiles(deleted)
+	logger.Log("message", "Expired items purged", "retention", s.retention, "files", len(files))
+	return files, nil
+}
+
+// checkKind validates the kind of a trash item.
+func checkKind(kind string) error {
+	if kind != model.KindFile && kind != model.KindFolder {
+		return &modelerr.InvalidArgument{Name: "kind", Reason: "must be folder or file"}
+	}
+	return nil
+}
+
+func toDeletedFiles(deleted []*dto.DeletedFileDTO) []*model.DeletedFile {
+	files := make([]*model.DeletedFile, len(deleted))
+	for i, f := range deleted {
+		files[i] = f.ToDomain()
+	}
+	return files
+}
+
+// NewService creates a TrashService. Items stay in the trash for the retention period before PurgeExpired deletes them.
+func NewService(repo dto.TrashRepository, files dto.FileRepository, folders dto.FolderRepository, tx dto.Transactor, retention time.Duration, logger log.Logger) TrashService {
+	return &service{
+		repo:      repo,
+		files:     files,
+		folders:   folders,
+		tx:        tx,
+		retention: retention,
+		log:       log.With(logger, "service", "trash"),
+	}
+}
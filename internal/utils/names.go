@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// NumberedName returns the n-th alternative of a name that is already taken, e.g. "report (1).pdf".
+// With keepExt the number is put before the file extension.
+func NumberedName(name string, n int, keepExt bool) string {
+	var ext string
+	if keepExt {
+		ext = path.Ext(name)
+		if ext == name {
+			// A dot file such as ".env" has no extension to keep
+			ext = ""
+		}
+	}
+	return fmt.Sprintf("%s (%d)%s", strings.TrimSuffix(name, ext), n, ext)
+}
@@ -0,0 +1,24 @@
+package utils
+
+import "testing"
+
+func TestNumberedName(t *testing.T) {
+	tests := []struct {
+		name    string
+		n       int
+		keepExt bool
+		want    string
+	}{
+		{"report.pdf", 1, true, "report (1).pdf"},
+		{"report.pdf", 2, false, "report.pdf (2)"},
+		{"archive.tar.gz", 3, true, "archive.tar (3).gz"},
+		{"notes", 1, true, "notes (1)"},
+		{".env", 1, true, ".env (1)"},
+		{"photos", 10, false, "photos (10)"},
+	}
+	for _, tt := range tests {
+		if got := NumberedName(tt.name, tt.n, tt.keepExt); got != tt.want {
+			t.Errorf("NumberedName(%q, %d, %v) = %q, want %q", tt.name, tt.n, tt.keepExt, got, tt.want)
+		}
+	}
+}